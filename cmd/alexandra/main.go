@@ -4,25 +4,31 @@ import (
 	"flag"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
+	"time"
 
 	"git.tu-berlin.de/mcc-fred/fred/pkg/alexandra"
+	"git.tu-berlin.de/mcc-fred/fred/pkg/certs"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 )
 
 type config struct {
-	lightHouse    string
-	caCert        string
-	alexandraCert string
-	alexandraKey  string
-	nodesCert     string
-	nodesKey      string
-	loglevel      string
-	logHandler    string
-	isProxied     bool
-	proxyHost     string
-	address       string
+	lightHouse     string
+	caCert         string
+	alexandraCert  string
+	alexandraKey   string
+	nodesCert      string
+	nodesKey       string
+	loglevel       string
+	logHandler     string
+	isProxied      bool
+	proxyHost      string
+	address        string
+	allowTokens    bool
+	crlFiles       string
+	reloadInterval int
 }
 
 func parseArgs() (c config) {
@@ -37,6 +43,9 @@ func parseArgs() (c config) {
 	flag.BoolVar(&(c.isProxied), "is-proxy", false, "Is this behind a proxy?")
 	flag.StringVar(&(c.proxyHost), "proxy-host", "", "Proxy host if this is proxied")
 	flag.StringVar(&(c.address), "address", "172.26.4.1:10000", "where to start the server")
+	flag.BoolVar(&(c.allowTokens), "allow-tokens", false, "Allow clients without a certificate that authenticate with a bearer token instead.")
+	flag.StringVar(&(c.crlFiles), "crl-file", "", "Comma-separated list of certificate revocation lists to check certificates against.")
+	flag.IntVar(&(c.reloadInterval), "tls-reload-interval", 10, "Interval in seconds to check certificates, keys, CA certificates, and CRLs for changes. 0 disables reloading.")
 	flag.Parse()
 	return
 }
@@ -76,8 +85,25 @@ func main() {
 		log.Info().Msg("No Loglevel specified, using 'debug'")
 	}
 
+	serverTLS, err := certs.NewProvider(c.alexandraCert, c.alexandraKey, []string{c.caCert}, strings.Split(c.crlFiles, ","))
+
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not load TLS configuration for clients")
+	}
+
+	nodesTLS, err := certs.NewProvider(c.nodesCert, c.nodesKey, []string{c.caCert}, strings.Split(c.crlFiles, ","))
+
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not load TLS configuration for FReD nodes")
+	}
+
+	if c.reloadInterval > 0 {
+		serverTLS.Watch(time.Duration(c.reloadInterval) * time.Second)
+		nodesTLS.Watch(time.Duration(c.reloadInterval) * time.Second)
+	}
+
 	// Setup alexandra
-	server := alexandra.NewServer(c.address, c.caCert, c.alexandraCert, c.alexandraKey, c.nodesCert, c.nodesKey, c.lightHouse, c.isProxied, c.proxyHost)
+	server := alexandra.NewServer(c.address, serverTLS, nodesTLS, c.lightHouse, c.isProxied, c.proxyHost, c.allowTokens)
 
 	// Quitting stuff
 	quit := make(chan os.Signal, 1)
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"git.tu-berlin.de/mcc-fred/fred/pkg/certs"
+	"git.tu-berlin.de/mcc-fred/fred/proto/client"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+const usage = `Usage:
+  fredbackup [flags] backup <keygroup> <file>
+  fredbackup [flags] restore <file>
+
+Flags:
+`
+
+// chunkSize is the size of the chunks that a backup is sent in when it is restored.
+const chunkSize = 64 * 1024
+
+func main() {
+	host := flag.String("host", "localhost:9001", "Address of the client interface of the FReD node or proxy")
+	cert := flag.String("cert", "", "Certificate file for the connection to FReD")
+	key := flag.String("key", "", "Key file for the connection to FReD")
+	ca := flag.String("ca-file", "", "CA root certificate file for the connection to FReD")
+
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+
+	flag.Parse()
+
+	log.Logger = log.Output(
+		zerolog.ConsoleWriter{
+			Out:     os.Stderr,
+			NoColor: false,
+		},
+	)
+
+	args := flag.Args()
+
+	if len(args) < 1 || (args[0] == "backup" && len(args) != 3) || (args[0] == "restore" && len(args) != 2) {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	p, err := certs.NewProvider(*cert, *key, []string{*ca}, nil)
+
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not load certificates")
+	}
+
+	conn, err := grpc.Dial(*host, grpc.WithTransportCredentials(credentials.NewTLS(p.ClientConfig(false))))
+
+	if err != nil {
+		log.Fatal().Err(err).Msgf("could not connect to %s", *host)
+	}
+
+	defer conn.Close()
+
+	c := client.NewClientClient(conn)
+
+	switch args[0] {
+	case "backup":
+		err = backup(c, args[1], args[2])
+	case "restore":
+		err = restore(c, args[1])
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err != nil {
+		log.Fatal().Err(err).Msgf("%s failed", args[0])
+	}
+}
+
+// backup writes a backup of a keygroup to a file. The file is only left behind if the backup is complete.
+func backup(c client.ClientClient, keygroup string, file string) error {
+	stream, err := c.Backup(context.Background(), &client.BackupRequest{Keygroup: keygroup})
+
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(file)
+
+	if err != nil {
+		return err
+	}
+
+	var n int
+
+	for {
+		chunk, err := stream.Recv()
+
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			f.Close()
+			os.Remove(file)
+			return err
+		}
+
+		w, err := f.Write(chunk.Data)
+		n += w
+
+		if err != nil {
+			f.Close()
+			os.Remove(file)
+			return err
+		}
+	}
+
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	log.Info().Msgf("wrote backup of keygroup %s to %s (%d bytes)", keygroup, file, n)
+
+	return nil
+}
+
+// restore sends a backup from a file to FReD.
+func restore(c client.ClientClient, file string) error {
+	f, err := os.Open(file)
+
+	if err != nil {
+		return err
+	}
+
+	defer f.Close()
+
+	stream, err := c.Restore(context.Background())
+
+	if err != nil {
+		return err
+	}
+
+	buf := make([]byte, chunkSize)
+
+	for {
+		n, err := f.Read(buf)
+
+		if n > 0 {
+			// io.EOF means that FReD has stopped reading, the reason is returned by CloseAndRecv
+			if err := stream.Send(&client.BackupChunk{Data: append([]byte(nil), buf[:n]...)}); err == io.EOF {
+				break
+			} else if err != nil {
+				return err
+			}
+		}
+
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	res, err := stream.CloseAndRecv()
+
+	if err != nil {
+		return err
+	}
+
+	log.Info().Msgf("restored keygroup %s from %s", res.Keygroup, file)
+
+	return nil
+}
@@ -1,13 +1,17 @@
 package main
 
 import (
+	"encoding/base64"
 	"flag"
+	"io/ioutil"
 	"os"
 	"os/signal"
 	"runtime"
 	"runtime/pprof"
+	"strconv"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/caarlos0/env/v6"
 	"github.com/go-errors/errors"
@@ -16,9 +20,12 @@ import (
 	"github.com/rs/zerolog/log"
 
 	"git.tu-berlin.de/mcc-fred/fred/pkg/api"
+	"git.tu-berlin.de/mcc-fred/fred/pkg/auditlog"
 	"git.tu-berlin.de/mcc-fred/fred/pkg/badgerdb"
+	"git.tu-berlin.de/mcc-fred/fred/pkg/certs"
 	"git.tu-berlin.de/mcc-fred/fred/pkg/dynamo"
 	"git.tu-berlin.de/mcc-fred/fred/pkg/etcdnase"
+	"git.tu-berlin.de/mcc-fred/fred/pkg/filestore"
 	"git.tu-berlin.de/mcc-fred/fred/pkg/fred"
 	"git.tu-berlin.de/mcc-fred/fred/pkg/peering"
 	"git.tu-berlin.de/mcc-fred/fred/pkg/storageclient"
@@ -48,6 +55,8 @@ type fredConfig struct {
 		Cert  string `env:"PEERING_CERT"`
 		Key   string `env:"PEERING_KEY"`
 		CA    string `env:"PEERING_CA"`
+		// Compress is whether requests to peers are compressed with gzip
+		Compress bool `env:"PEERING_COMPRESSION"`
 	}
 	Log struct {
 		Level   string `env:"LOG_LEVEL"`
@@ -75,10 +84,80 @@ type fredConfig struct {
 	Bdb struct {
 		Path string `env:"BADGERDB_PATH"`
 	}
+	File struct {
+		Path string `env:"FILE_STORE_PATH"`
+	}
+	Cache struct {
+		Size          int64  `env:"CACHE_SIZE"`
+		TTL           int    `env:"CACHE_TTL"`
+		Keygroups     string `env:"CACHE_KEYGROUPS"`
+		StatsInterval int    `env:"CACHE_STATS_INTERVAL"`
+	}
+	Compression struct {
+		Algorithm     string `env:"COMPRESSION"`
+		MinSize       int    `env:"COMPRESSION_MIN_SIZE"`
+		Keygroups     string `env:"COMPRESSION_KEYGROUPS"`
+		StatsInterval int    `env:"COMPRESSION_STATS_INTERVAL"`
+	}
+	Encryption struct {
+		KeyFile    string `env:"ENCRYPTION_KEY_FILE"`
+		Key        string `env:"ENCRYPTION_KEY"`
+		OldKeyFile string `env:"ENCRYPTION_OLD_KEY_FILE"`
+		OldKey     string `env:"ENCRYPTION_OLD_KEY"`
+		Keygroups  string `env:"ENCRYPTION_KEYGROUPS"`
+		Rotation   int    `env:"ENCRYPTION_ROTATION"`
+	}
+	Quota struct {
+		MaxItemSize  int    `env:"QUOTA_MAX_ITEM_SIZE"`
+		MaxItems     int64  `env:"QUOTA_MAX_ITEMS"`
+		MaxBytes     int64  `env:"QUOTA_MAX_BYTES"`
+		Keygroups    string `env:"QUOTA_KEYGROUPS"`
+		NodeMaxItems int64  `env:"QUOTA_NODE_MAX_ITEMS"`
+		NodeMaxBytes int64  `env:"QUOTA_NODE_MAX_BYTES"`
+	}
+	Migrate struct {
+		Adaptor      string `env:"MIGRATE_STORAGE_ADAPTOR"`
+		BdbPath      string `env:"MIGRATE_BADGERDB_PATH"`
+		FilePath     string `env:"MIGRATE_FILE_STORE_PATH"`
+		RemoteHost   string `env:"MIGRATE_REMOTE_STORAGE_HOST"`
+		RemoteCert   string `env:"MIGRATE_REMOTE_STORAGE_CERT"`
+		RemoteKey    string `env:"MIGRATE_REMOTE_STORAGE_KEY"`
+		RemoteCA     string `env:"MIGRATE_REMOTE_STORAGE_CA"`
+		DynamoTable  string `env:"MIGRATE_DYNAMODB_TABLE"`
+		DynamoRegion string `env:"MIGRATE_DYNAMODB_REGION"`
+	}
+	Auth struct {
+		Admins         string `env:"ADMIN_USERS"`
+		RestrictCreate bool   `env:"RESTRICT_CREATE_KEYGROUP"`
+	}
+	Token struct {
+		Keys        string `env:"TOKEN_KEYS"`
+		Issuer      string `env:"TOKEN_ISSUER"`
+		Audience    string `env:"TOKEN_AUDIENCE"`
+		UserClaim   string `env:"TOKEN_USER_CLAIM"`
+		GroupsClaim string `env:"TOKEN_GROUPS_CLAIM"`
+	}
+	Audit struct {
+		Log      string `env:"AUDIT_LOG"`
+		Path     string `env:"AUDIT_LOG_PATH"`
+		MaxSize  int64  `env:"AUDIT_LOG_MAX_SIZE"`
+		MaxFiles int    `env:"AUDIT_LOG_MAX_FILES"`
+		Expiry   int    `env:"AUDIT_LOG_EXPIRY"`
+	}
 	Trigger struct {
-		Cert string `env:"TRIGGER_CERT"`
-		Key  string `env:"TRIGGER_KEY"`
-		CA   string `env:"TRIGGER_CA"`
+		Cert         string `env:"TRIGGER_CERT"`
+		Key          string `env:"TRIGGER_KEY"`
+		CA           string `env:"TRIGGER_CA"`
+		MaxAttempts  int    `env:"TRIGGER_MAX_ATTEMPTS"`
+		MaxBackoff   int    `env:"TRIGGER_MAX_BACKOFF"`
+		BatchSize    int    `env:"TRIGGER_BATCH_SIZE"`
+		BatchLatency int    `env:"TRIGGER_BATCH_LATENCY"`
+		IdleTimeout  int    `env:"TRIGGER_IDLE_TIMEOUT"`
+	}
+	TLS struct {
+		CRL            string `env:"CRL_FILE"`
+		DenyList       bool   `env:"NASE_DENY_LIST"`
+		ReloadInterval int    `env:"TLS_RELOAD_INTERVAL"`
 	}
 	Profiling struct {
 		CPUProfPath string `env:"PROFILING_CPU_PATH"`
@@ -86,6 +165,231 @@ type fredConfig struct {
 	}
 }
 
+// openStore opens the store for a storage adaptor with the configuration of that adaptor.
+func openStore(adaptor, bdbPath, filePath, remoteHost, remoteCert, remoteKey, remoteCA, dynamoTable, dynamoRegion string) (fred.Store, error) {
+	switch adaptor {
+	case "badgerdb":
+		return badgerdb.New(bdbPath), nil
+	case "memory":
+		return badgerdb.NewMemory(), nil
+	case "file":
+		store, err := filestore.New(filePath)
+		if err != nil {
+			return nil, errors.Errorf("could not open file store: %v", err)
+		}
+		return store, nil
+	case "remote":
+		return storageclient.NewClient(remoteHost, remoteCert, remoteKey, strings.Split(remoteCA, ",")), nil
+	case "dynamo":
+		store, err := dynamo.New(dynamoTable, dynamoRegion)
+		if err != nil {
+			return nil, errors.Errorf("could not open a dynamo connection: %v", err)
+		}
+		return store, nil
+	default:
+		return nil, errors.Errorf("unknown storage backend %s", adaptor)
+	}
+}
+
+// migrateStore makes the node read-only and copies its store to the store configured with the migrate flags. The node
+// stays read-only afterwards and has to be restarted with the new store, or with the old one if the migration failed.
+func migrateStore(f fred.Fred, fc fredConfig) {
+	log.Info().Msgf("migrating store from %s to %s", fc.Storage.Adaptor, fc.Migrate.Adaptor)
+
+	to, err := openStore(fc.Migrate.Adaptor, fc.Migrate.BdbPath, fc.Migrate.FilePath, fc.Migrate.RemoteHost, fc.Migrate.RemoteCert, fc.Migrate.RemoteKey, fc.Migrate.RemoteCA, fc.Migrate.DynamoTable, fc.Migrate.DynamoRegion)
+	if err != nil {
+		log.Error().Msgf("could not open store to migrate to: %s", err.(*errors.Error).ErrorStack())
+		return
+	}
+
+	defer func() {
+		log.Err(to.Close()).Msg("closing migrated store")
+	}()
+
+	stats, err := f.MigrateStore(to)
+	if err != nil {
+		log.Error().Err(err).Msg("store migration failed, node stays read-only until it is restarted")
+		return
+	}
+
+	log.Info().Msgf("migrated %d keygroups with %d items (%d had expired) and %d trigger nodes to %s, restart the node with the new store", stats.Keygroups, stats.Items, stats.Expired, stats.Triggers, fc.Migrate.Adaptor)
+}
+
+// parseCacheRules parses a list of keygroup patterns with optional TTLs in seconds, such as "sensors*=5,logs=0,*".
+func parseCacheRules(keygroups string, ttl int) ([]fred.CacheRule, error) {
+	if keygroups == "" {
+		return []fred.CacheRule{{Pattern: "*", TTL: time.Duration(ttl) * time.Second}}, nil
+	}
+
+	var rules []fred.CacheRule
+
+	for _, r := range strings.Split(keygroups, ",") {
+		pattern, t := r, ttl
+
+		if i := strings.Index(r, "="); i >= 0 {
+			pattern = r[:i]
+
+			n, err := strconv.Atoi(r[i+1:])
+			if err != nil || n < 0 {
+				return nil, errors.Errorf("invalid cache TTL in %s", r)
+			}
+			t = n
+		}
+
+		if pattern == "" {
+			return nil, errors.Errorf("missing keygroup pattern in %s", r)
+		}
+
+		rules = append(rules, fred.CacheRule{Pattern: fred.KeygroupName(pattern), TTL: time.Duration(t) * time.Second})
+	}
+
+	return rules, nil
+}
+
+// parseCompressionRules parses a list of keygroup patterns with optional compression algorithms, such as
+// "images*=none,logs=gzip,*".
+func parseCompressionRules(keygroups string, algorithm string, minSize int) ([]fred.CompressionRule, error) {
+	if keygroups == "" {
+		keygroups = "*"
+	}
+
+	var rules []fred.CompressionRule
+
+	for _, r := range strings.Split(keygroups, ",") {
+		pattern, a := r, algorithm
+
+		if i := strings.Index(r, "="); i >= 0 {
+			pattern, a = r[:i], r[i+1:]
+
+			if a == "none" {
+				a = fred.CompressionNone
+			}
+		}
+
+		if pattern == "" {
+			return nil, errors.Errorf("missing keygroup pattern in %s", r)
+		}
+
+		if err := fred.CheckCompressionAlgorithm(a); err != nil {
+			return nil, err
+		}
+
+		rules = append(rules, fred.CompressionRule{Pattern: fred.KeygroupName(pattern), Algorithm: a, MinSize: minSize})
+	}
+
+	return rules, nil
+}
+
+// parseQuotaRules parses a list of keygroup patterns with optional quotas as "items/bytes/item size", such as
+// "logs=100000/1073741824/,sensors*=//4096,*". Empty or missing parts of a quota are taken from the default quota.
+func parseQuotaRules(keygroups string, def fred.Quota) ([]fred.QuotaRule, error) {
+	if keygroups == "" {
+		if def == (fred.Quota{}) {
+			return nil, nil
+		}
+		keygroups = "*"
+	}
+
+	var rules []fred.QuotaRule
+
+	for _, r := range strings.Split(keygroups, ",") {
+		pattern, q := r, def
+
+		if i := strings.Index(r, "="); i >= 0 {
+			pattern = r[:i]
+
+			parts := strings.Split(r[i+1:], "/")
+			if len(parts) > 3 {
+				return nil, errors.Errorf("invalid quota in %s", r)
+			}
+
+			limits := []int64{q.MaxItems, q.MaxBytes, int64(q.MaxItemSize)}
+			for j, p := range parts {
+				if p == "" {
+					continue
+				}
+
+				n, err := strconv.ParseInt(p, 10, 64)
+				if err != nil || n < 0 {
+					return nil, errors.Errorf("invalid quota in %s", r)
+				}
+				limits[j] = n
+			}
+
+			q = fred.Quota{MaxItems: limits[0], MaxBytes: limits[1], MaxItemSize: int(limits[2])}
+		}
+
+		if pattern == "" {
+			return nil, errors.Errorf("missing keygroup pattern in %s", r)
+		}
+
+		rules = append(rules, fred.QuotaRule{Pattern: fred.KeygroupName(pattern), Quota: q})
+	}
+
+	return rules, nil
+}
+
+// loadEncryptionKey reads a base64-encoded master key from a file or, if no file is given, from the value of an
+// environment variable. It returns nil if neither is set.
+func loadEncryptionKey(file string, key string) ([]byte, error) {
+	if file != "" {
+		b, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, errors.Errorf("could not read encryption key file %s: %v", file, err)
+		}
+		key = string(b)
+	}
+
+	if key == "" {
+		return nil, nil
+	}
+
+	k, err := base64.StdEncoding.DecodeString(strings.TrimSpace(key))
+	if err != nil {
+		return nil, errors.Errorf("encryption key is not base64-encoded: %v", err)
+	}
+
+	if len(k) != fred.EncryptionKeySize {
+		return nil, errors.Errorf("encryption key must be %d bytes but has %d bytes", fred.EncryptionKeySize, len(k))
+	}
+
+	return k, nil
+}
+
+// logCompressionStats logs the compression ratio per keygroup in an interval.
+func logCompressionStats(f fred.Fred, interval time.Duration) {
+	for range time.Tick(interval) {
+		for kg, s := range f.CompressionStats() {
+			log.Info().Msgf("compression for keygroup %s: %d of %d values compressed, %d bytes stored for %d bytes (ratio %.1f)", kg, s.Compressed, s.Values, s.StoredBytes, s.RawBytes, s.Ratio())
+		}
+	}
+}
+
+// logCacheStats logs the hit rate of the cache per keygroup in an interval.
+func logCacheStats(f fred.Fred, interval time.Duration) {
+	for range time.Tick(interval) {
+		stats, ok := f.CacheStats()
+		if !ok {
+			return
+		}
+
+		kgs := make(map[fred.KeygroupName]struct{})
+		for kg := range stats.Hits {
+			kgs[kg] = struct{}{}
+		}
+		for kg := range stats.Misses {
+			kgs[kg] = struct{}{}
+		}
+
+		for kg := range kgs {
+			hits, misses := stats.Hits[kg], stats.Misses[kg]
+			log.Info().Msgf("cache for keygroup %s: %d hits, %d misses (%.1f%% hit rate)", kg, hits, misses, 100*float64(hits)/float64(hits+misses))
+		}
+
+		log.Info().Msgf("cache: %d items, %d bytes, %d evictions", stats.Items, stats.Size, stats.Evictions)
+	}
+}
+
 func parseArgs() (fc fredConfig) {
 
 	// General configuration
@@ -110,9 +414,10 @@ func parseArgs() (fc fredConfig) {
 	flag.StringVar(&(fc.Peering.Cert), "peer-cert", "", "Certificate for peering connection. (Env: PEERING_CERT)")
 	flag.StringVar(&(fc.Peering.Key), "peer-key", "", "Key file for peering connection. (Env: PEERING_KEY)")
 	flag.StringVar(&(fc.Peering.CA), "peer-ca", "", "Certificate authority root certificate file for peering connections. (Env: PEERING)")
+	flag.BoolVar(&(fc.Peering.Compress), "peer-compression", false, "Flag to indicate, whether to compress requests to peers with gzip. All peers must support it. (Env: PEERING_COMPRESSION)")
 
 	// storage configuration
-	flag.StringVar(&(fc.Storage.Adaptor), "adaptor", "", "Storage adaptor, can be \"remote\", \"badgerdb\", \"memory\", \"dynamo\". (Env: STORAGE_ADAPTOR)")
+	flag.StringVar(&(fc.Storage.Adaptor), "adaptor", "", "Storage adaptor, can be \"remote\", \"badgerdb\", \"memory\", \"file\", \"dynamo\". (Env: STORAGE_ADAPTOR)")
 
 	flag.StringVar(&(fc.RemoteStore.Host), "remote-storage-host", "", "Host address of GRPC Server for storage connection. (Env: REMOTE_STORAGE_HOST)")
 	flag.StringVar(&(fc.RemoteStore.Cert), "remote-storage-cert", "", "Certificate for storage connection. (Env: REMOTE_STORAGE_CERT)")
@@ -126,6 +431,45 @@ func parseArgs() (fc fredConfig) {
 
 	flag.StringVar(&(fc.Bdb.Path), "badgerdb-path", "", "Path to the BadgerDB database. (Env: BADGERDB_PATH)")
 
+	flag.StringVar(&(fc.File.Path), "file-path", "", "Path to the directory of the file store. (Env: FILE_STORE_PATH)")
+
+	// read cache configuration
+	flag.Int64Var(&(fc.Cache.Size), "cache-size", 0, "Size in bytes of the cache for reads from the store. 0 disables the cache. (Env: CACHE_SIZE)")
+	flag.IntVar(&(fc.Cache.TTL), "cache-ttl", 60, "Number of seconds that items are cached for, unless set for their keygroup. (Env: CACHE_TTL)")
+	flag.StringVar(&(fc.Cache.Keygroups), "cache-keygroups", "", "Comma-separated list of keygroup patterns to cache, each optionally followed by \"=\" and the number of seconds to cache its items, 0 to not cache them, e.g., \"sensors*=5,logs=0,*\". The first matching pattern applies. All keygroups are cached if empty. (Env: CACHE_KEYGROUPS)")
+	flag.IntVar(&(fc.Cache.StatsInterval), "cache-stats-interval", 60, "Interval in seconds to log cache hits and misses. 0 disables logging. (Env: CACHE_STATS_INTERVAL)")
+
+	// value compression configuration
+	flag.StringVar(&(fc.Compression.Algorithm), "compression", "", "Algorithm to compress values with before they are stored, can be \"snappy\", \"gzip\", or empty to not compress them, unless set for their keygroup. (Env: COMPRESSION)")
+	flag.IntVar(&(fc.Compression.MinSize), "compression-min-size", 256, "Size in bytes below which values are not compressed. (Env: COMPRESSION_MIN_SIZE)")
+	flag.StringVar(&(fc.Compression.Keygroups), "compression-keygroups", "", "Comma-separated list of keygroup patterns to compress, each optionally followed by \"=\" and the compression algorithm, \"none\" to not compress them, e.g., \"images*=none,logs=gzip,*\". The first matching pattern applies. All keygroups are compressed if empty. (Env: COMPRESSION_KEYGROUPS)")
+	flag.IntVar(&(fc.Compression.StatsInterval), "compression-stats-interval", 60, "Interval in seconds to log compression ratios. 0 disables logging. (Env: COMPRESSION_STATS_INTERVAL)")
+
+	// encryption at rest configuration, keys can also be given as base64 in ENCRYPTION_KEY and ENCRYPTION_OLD_KEY
+	flag.StringVar(&(fc.Encryption.KeyFile), "encryption-key-file", "", "File with the base64-encoded 32 byte master key to encrypt values with. Values are not encrypted if neither this nor ENCRYPTION_KEY is set. (Env: ENCRYPTION_KEY_FILE)")
+	flag.StringVar(&(fc.Encryption.OldKeyFile), "encryption-old-key-file", "", "File with the previous base64-encoded master key when the master key is rotated. (Env: ENCRYPTION_OLD_KEY_FILE)")
+	flag.StringVar(&(fc.Encryption.Keygroups), "encryption-keygroups", "", "Comma-separated list of keygroup patterns to encrypt, e.g., \"patients*,billing\". All keygroups are encrypted if empty. (Env: ENCRYPTION_KEYGROUPS)")
+	flag.IntVar(&(fc.Encryption.Rotation), "encryption-rotation", 0, "Number of hours after which the data key of a keygroup is replaced and its values are re-encrypted in the background. 0 disables rotation, which can also be started with SIGUSR2. (Env: ENCRYPTION_ROTATION)")
+
+	// quota configuration
+	flag.IntVar(&(fc.Quota.MaxItemSize), "quota-max-item-size", 0, "Largest value in bytes that clients may store in a keygroup, unless set for the keygroup. 0 means no limit. (Env: QUOTA_MAX_ITEM_SIZE)")
+	flag.Int64Var(&(fc.Quota.MaxItems), "quota-max-items", 0, "Number of items that a keygroup may have on this node, unless set for the keygroup. 0 means no limit. (Env: QUOTA_MAX_ITEMS)")
+	flag.Int64Var(&(fc.Quota.MaxBytes), "quota-max-bytes", 0, "Number of bytes of IDs and values that a keygroup may store on this node, unless set for the keygroup. 0 means no limit. (Env: QUOTA_MAX_BYTES)")
+	flag.StringVar(&(fc.Quota.Keygroups), "quota-keygroups", "", "Comma-separated list of keygroup patterns with quotas, each optionally followed by \"=\" and the maximum items, bytes, and item size separated by \"/\", where empty parts are taken from the defaults and 0 means no limit, e.g., \"logs=100000/1073741824/,sensors*=//4096,*\". The first matching pattern applies. All keygroups get the default quota if empty. (Env: QUOTA_KEYGROUPS)")
+	flag.Int64Var(&(fc.Quota.NodeMaxItems), "quota-node-max-items", 0, "Number of items that all keygroups together may have on this node. 0 means no limit. (Env: QUOTA_NODE_MAX_ITEMS)")
+	flag.Int64Var(&(fc.Quota.NodeMaxBytes), "quota-node-max-bytes", 0, "Number of bytes of IDs and values that all keygroups together may store on this node. 0 means no limit. (Env: QUOTA_NODE_MAX_BYTES)")
+
+	// storage migration configuration
+	flag.StringVar(&(fc.Migrate.Adaptor), "migrate-adaptor", "", "Storage adaptor to migrate the store to when the node receives SIGUSR1, can be \"remote\", \"badgerdb\", \"file\", \"dynamo\", or empty to disable migration. (Env: MIGRATE_STORAGE_ADAPTOR)")
+	flag.StringVar(&(fc.Migrate.BdbPath), "migrate-badgerdb-path", "", "Path to the BadgerDB database to migrate to. (Env: MIGRATE_BADGERDB_PATH)")
+	flag.StringVar(&(fc.Migrate.FilePath), "migrate-file-path", "", "Path to the directory of the file store to migrate to. (Env: MIGRATE_FILE_STORE_PATH)")
+	flag.StringVar(&(fc.Migrate.RemoteHost), "migrate-remote-storage-host", "", "Host address of GRPC Server for the storage connection to migrate to. (Env: MIGRATE_REMOTE_STORAGE_HOST)")
+	flag.StringVar(&(fc.Migrate.RemoteCert), "migrate-remote-storage-cert", "", "Certificate for the storage connection to migrate to. (Env: MIGRATE_REMOTE_STORAGE_CERT)")
+	flag.StringVar(&(fc.Migrate.RemoteKey), "migrate-remote-storage-key", "", "Key file for the storage connection to migrate to. (Env: MIGRATE_REMOTE_STORAGE_KEY)")
+	flag.StringVar(&(fc.Migrate.RemoteCA), "migrate-remote-storage-ca", "", "Comma-separated list of CA certificate files for the storage connection to migrate to. (Env: MIGRATE_REMOTE_STORAGE_CA)")
+	flag.StringVar(&(fc.Migrate.DynamoTable), "migrate-dynamo-table", "", "AWS table for the DynamoDB storage backend to migrate to. (Env: MIGRATE_DYNAMODB_TABLE)")
+	flag.StringVar(&(fc.Migrate.DynamoRegion), "migrate-dynamo-region", "", "AWS region for the DynamoDB storage backend to migrate to. (Env: MIGRATE_DYNAMODB_REGION)")
+
 	// logging configuration
 	flag.StringVar(&(fc.Log.Level), "log-level", "debug", "Log level, can be \"debug\", \"info\" ,\"warn\", \"error\", \"fatal\", \"panic\". (Env: LOG_LEVEL)")
 	flag.StringVar(&(fc.Log.Handler), "handler", "dev", "Mode of log handler, can be \"dev\", \"prod\". (Env: LOG_HANDLER)")
@@ -138,10 +482,38 @@ func parseArgs() (fc fredConfig) {
 	flag.StringVar(&(fc.NaSe.CA), "nase-ca", "", "CA certificate file to authenticate against etcd. (Env: NASE_CA)")
 	flag.BoolVar(&(fc.NaSe.Cached), "nase-cached", false, "Flag to indicate, whether to use a cache for NaSe. (Env: NASE_CACHED)")
 
+	// access control configuration
+	flag.StringVar(&(fc.Auth.Admins), "admin-users", "", "Comma-separated list of users that may perform every method on every keygroup, e.g., to set up roles and groups. (Env: ADMIN_USERS)")
+	flag.BoolVar(&(fc.Auth.RestrictCreate), "restrict-create-keygroup", false, "Flag to indicate, whether users need the CreateKeygroup permission to create a keygroup. (Env: RESTRICT_CREATE_KEYGROUP)")
+
+	// bearer token configuration
+	flag.StringVar(&(fc.Token.Keys), "token-keys", "", "Comma-separated list of PEM files with public keys (RSA or ECDSA) to verify bearer tokens with. Tokens are only accepted if this is set. (Env: TOKEN_KEYS)")
+	flag.StringVar(&(fc.Token.Issuer), "token-issuer", "", "Required issuer (\"iss\") of bearer tokens, if set. (Env: TOKEN_ISSUER)")
+	flag.StringVar(&(fc.Token.Audience), "token-audience", "", "Required audience (\"aud\") of bearer tokens, if set. (Env: TOKEN_AUDIENCE)")
+	flag.StringVar(&(fc.Token.UserClaim), "token-user-claim", "sub", "Claim of bearer tokens that contains the user name. (Env: TOKEN_USER_CLAIM)")
+	flag.StringVar(&(fc.Token.GroupsClaim), "token-groups-claim", "groups", "Claim of bearer tokens that contains the groups of the user. (Env: TOKEN_GROUPS_CLAIM)")
+
+	// audit log configuration
+	flag.StringVar(&(fc.Audit.Log), "audit-log", "", "Where to write the audit log of client operations, can be \"file\", \"keygroup\", or empty to disable it. (Env: AUDIT_LOG)")
+	flag.StringVar(&(fc.Audit.Path), "audit-log-path", "audit.log", "Path to the audit log file. (Env: AUDIT_LOG_PATH)")
+	flag.Int64Var(&(fc.Audit.MaxSize), "audit-log-max-size", 100*1024*1024, "Size in bytes after which the audit log file is rotated. (Env: AUDIT_LOG_MAX_SIZE)")
+	flag.IntVar(&(fc.Audit.MaxFiles), "audit-log-max-files", 10, "Number of rotated audit log files to keep. (Env: AUDIT_LOG_MAX_FILES)")
+	flag.IntVar(&(fc.Audit.Expiry), "audit-log-expiry", 0, "Seconds after which entries in the audit log keygroup expire, 0 to keep them forever. (Env: AUDIT_LOG_EXPIRY)")
+
 	// trigger node tls configuration
 	flag.StringVar(&(fc.Trigger.Cert), "trigger-cert", "", "Certificate for trigger node connection. (Env: TRIGGER_CERT)")
 	flag.StringVar(&(fc.Trigger.Key), "trigger-key", "", "Key file for trigger node connection. (Env: TRIGGER_KEY)")
 	flag.StringVar(&(fc.Trigger.CA), "trigger-ca", "", "Comma-separated list of CA certificate files for trigger node connection. (Env: TRIGGER_CA)")
+	flag.IntVar(&(fc.Trigger.MaxAttempts), "trigger-max-attempts", 10, "Number of attempts to deliver an event to a trigger node before it is moved to the failed events. (Env: TRIGGER_MAX_ATTEMPTS)")
+	flag.IntVar(&(fc.Trigger.MaxBackoff), "trigger-max-backoff", 60, "Maximum number of seconds to wait between two attempts to deliver an event to a trigger node. (Env: TRIGGER_MAX_BACKOFF)")
+	flag.IntVar(&(fc.Trigger.BatchSize), "trigger-batch-size", 100, "Maximum number of events to send to a trigger node at once. 1 disables batching. (Env: TRIGGER_BATCH_SIZE)")
+	flag.IntVar(&(fc.Trigger.BatchLatency), "trigger-batch-latency", 0, "Number of milliseconds to wait for more events before sending a batch to a trigger node that is not full. (Env: TRIGGER_BATCH_LATENCY)")
+	flag.IntVar(&(fc.Trigger.IdleTimeout), "trigger-idle-timeout", 300, "Number of seconds after which an unused connection to a trigger node is closed. (Env: TRIGGER_IDLE_TIMEOUT)")
+
+	// certificate revocation and reloading
+	flag.StringVar(&(fc.TLS.CRL), "crl-file", "", "Comma-separated list of certificate revocation lists to check certificates against. (Env: CRL_FILE)")
+	flag.BoolVar(&(fc.TLS.DenyList), "nase-deny-list", false, "Flag to indicate, whether to reject client and peer certificates that are revoked in the NaSe. (Env: NASE_DENY_LIST)")
+	flag.IntVar(&(fc.TLS.ReloadInterval), "tls-reload-interval", 10, "Interval in seconds to check certificates, keys, CA certificates, CRLs, and the deny list for changes. 0 disables reloading. (Env: TLS_RELOAD_INTERVAL)")
 
 	flag.StringVar(&(fc.Profiling.CPUProfPath), "cpuprofile", "", "Enable CPU profiling and specify path for pprof output")
 	flag.StringVar(&(fc.Profiling.MemProfPath), "memprofile", "", "Enable memory profiling and specify path for pprof output")
@@ -164,9 +536,44 @@ func parseArgs() (fc fredConfig) {
 		log.Fatal().Msgf("Given longitutde %f is not within latitude range from -180 to 180.", fc.Location.Lng)
 	}
 
-	if fc.Storage.Adaptor != "remote" && fc.Storage.Adaptor != "badgerdb" && fc.Storage.Adaptor != "memory" && fc.Storage.Adaptor != "dynamo" {
+	if fc.Storage.Adaptor != "remote" && fc.Storage.Adaptor != "badgerdb" && fc.Storage.Adaptor != "memory" && fc.Storage.Adaptor != "file" && fc.Storage.Adaptor != "dynamo" {
+		flag.Usage()
+		log.Fatal().Msgf("Given storage adaptor %s is not one of: \"remote\", \"badgerdb\", \"memory\", \"file\", \"dynamo\".", fc.Storage.Adaptor)
+	}
+
+	if fc.Migrate.Adaptor != "" && fc.Migrate.Adaptor != "remote" && fc.Migrate.Adaptor != "badgerdb" && fc.Migrate.Adaptor != "file" && fc.Migrate.Adaptor != "dynamo" {
 		flag.Usage()
-		log.Fatal().Msgf("Given storage adaptor %s is not one of: \"remote\", \"badgerdb\", \"memory\", \"dynamo\".", fc.Storage.Adaptor)
+		log.Fatal().Msgf("Given storage adaptor to migrate to %s is not one of: \"remote\", \"badgerdb\", \"file\", \"dynamo\", \"\".", fc.Migrate.Adaptor)
+	}
+
+	if fc.Storage.Adaptor == "file" && fc.File.Path == "" {
+		flag.Usage()
+		log.Fatal().Msg("The file storage adaptor needs a path.")
+	}
+
+	if fc.Compression.MinSize < 0 || fc.Compression.StatsInterval < 0 {
+		flag.Usage()
+		log.Fatal().Msg("Compression minimum size and statistics interval must not be negative.")
+	}
+
+	if fc.Quota.MaxItemSize < 0 || fc.Quota.MaxItems < 0 || fc.Quota.MaxBytes < 0 || fc.Quota.NodeMaxItems < 0 || fc.Quota.NodeMaxBytes < 0 {
+		flag.Usage()
+		log.Fatal().Msg("Quotas must not be negative.")
+	}
+
+	if fc.Encryption.Rotation < 0 {
+		flag.Usage()
+		log.Fatal().Msg("Encryption rotation interval must not be negative.")
+	}
+
+	if fc.Cache.Size < 0 || fc.Cache.TTL < 0 || fc.Cache.StatsInterval < 0 {
+		flag.Usage()
+		log.Fatal().Msg("Cache size, TTL, and statistics interval must not be negative.")
+	}
+
+	if fc.Audit.Log != "" && fc.Audit.Log != "file" && fc.Audit.Log != "keygroup" {
+		flag.Usage()
+		log.Fatal().Msgf("Given audit log %s is not one of: \"file\", \"keygroup\", \"\".", fc.Audit.Log)
 	}
 
 	if fc.Log.Handler != "dev" && fc.Log.Handler != "prod" {
@@ -261,57 +668,214 @@ func main() {
 		log.Info().Msg("No Loglevel specified, using 'debug'")
 	}
 
-	var store fred.Store
-
-	switch fc.Storage.Adaptor {
-	case "badgerdb":
+	if fc.Storage.Adaptor == "badgerdb" {
 		log.Debug().Msgf("badgerdb struct is: %#v", fc.Bdb)
-		store = badgerdb.New(fc.Bdb.Path)
-	case "memory":
-		store = badgerdb.NewMemory()
-	case "remote":
-		store = storageclient.NewClient(fc.RemoteStore.Host, fc.RemoteStore.Cert, fc.RemoteStore.Key, strings.Split(fc.RemoteStore.CA, ","))
-	case "dynamo":
-		store, err = dynamo.New(fc.DynamoDB.Table, fc.DynamoDB.Region)
-		if err != nil {
-			log.Fatal().Msgf("could not open a dynamo connection: %s", err.(*errors.Error).ErrorStack())
-		}
-	default:
-		log.Fatal().Msg("unknown storage backend")
+	}
+
+	store, err := openStore(fc.Storage.Adaptor, fc.Bdb.Path, fc.File.Path, fc.RemoteStore.Host, fc.RemoteStore.Cert, fc.RemoteStore.Key, fc.RemoteStore.CA, fc.DynamoDB.Table, fc.DynamoDB.Region)
+	if err != nil {
+		log.Fatal().Msgf("could not open store: %s", err.(*errors.Error).ErrorStack())
+	}
+
+	crls := strings.Split(fc.TLS.CRL, ",")
+
+	serverTLS, err := certs.NewProvider(fc.Server.Cert, fc.Server.Key, []string{fc.Server.CA}, crls)
+	if err != nil {
+		log.Fatal().Msgf("could not load TLS configuration for external connections: %s", err.(*errors.Error).ErrorStack())
+	}
+
+	peeringTLS, err := certs.NewProvider(fc.Peering.Cert, fc.Peering.Key, []string{fc.Peering.CA}, crls)
+	if err != nil {
+		log.Fatal().Msgf("could not load TLS configuration for peering connections: %s", err.(*errors.Error).ErrorStack())
+	}
+
+	triggerTLS, err := certs.NewProvider(fc.Trigger.Cert, fc.Trigger.Key, strings.Split(fc.Trigger.CA, ","), crls)
+	if err != nil {
+		log.Fatal().Msgf("could not load TLS configuration for trigger node connections: %s", err.(*errors.Error).ErrorStack())
 	}
 
 	log.Debug().Msg("Starting Interconnection Client...")
-	c := peering.NewClient(fc.Peering.Cert, fc.Peering.Key, fc.Peering.CA)
+	c := peering.NewClient(fred.NodeID(fc.General.nodeID), peeringTLS, fc.Peering.Compress)
 
 	log.Debug().Msg("Starting NaSe Client...")
 
-	var n fred.NameService
-	n, err = etcdnase.NewNameService(fc.General.nodeID, []string{fc.NaSe.Host}, fc.NaSe.Cert, fc.NaSe.Key, fc.NaSe.CA, fc.NaSe.Cached)
+	n, err := etcdnase.NewNameService(fc.General.nodeID, []string{fc.NaSe.Host}, fc.NaSe.Cert, fc.NaSe.Key, fc.NaSe.CA, fc.NaSe.Cached)
 
 	if err != nil {
 		log.Err(err).Msg(err.(*errors.Error).ErrorStack())
 		panic(err)
 	}
 
+	if fc.TLS.DenyList {
+		for _, p := range []*certs.Provider{serverTLS, peeringTLS, triggerTLS} {
+			if err := p.SetDenyList(n); err != nil {
+				log.Fatal().Msgf("could not load revoked certificates from NaSe: %s", err.(*errors.Error).ErrorStack())
+			}
+		}
+	}
+
+	if fc.TLS.ReloadInterval > 0 {
+		for _, p := range []*certs.Provider{serverTLS, peeringTLS, triggerTLS} {
+			p.Watch(time.Duration(fc.TLS.ReloadInterval) * time.Second)
+		}
+	}
+
+	cacheRules, err := parseCacheRules(fc.Cache.Keygroups, fc.Cache.TTL)
+	if err != nil {
+		log.Fatal().Msgf("could not parse cache keygroups: %s", err.(*errors.Error).ErrorStack())
+	}
+
+	compressionRules, err := parseCompressionRules(fc.Compression.Keygroups, fc.Compression.Algorithm, fc.Compression.MinSize)
+	if err != nil {
+		log.Fatal().Msgf("could not parse compression keygroups: %s", err.(*errors.Error).ErrorStack())
+	}
+
+	compress := false
+	for _, r := range compressionRules {
+		compress = compress || r.Algorithm != fred.CompressionNone
+	}
+
+	// DynamoDB keeps values as strings, which must be valid UTF-8
+	if compress && fc.Storage.Adaptor == "dynamo" {
+		log.Fatal().Msg("values cannot be compressed with the dynamo storage adaptor")
+	}
+
+	quotaRules, err := parseQuotaRules(fc.Quota.Keygroups, fred.Quota{MaxItemSize: fc.Quota.MaxItemSize, MaxItems: fc.Quota.MaxItems, MaxBytes: fc.Quota.MaxBytes})
+	if err != nil {
+		log.Fatal().Msgf("could not parse quota keygroups: %s", err.(*errors.Error).ErrorStack())
+	}
+
+	encryptionKey, err := loadEncryptionKey(fc.Encryption.KeyFile, fc.Encryption.Key)
+	if err != nil {
+		log.Fatal().Msgf("could not load encryption key: %s", err.(*errors.Error).ErrorStack())
+	}
+
+	encryptionOldKey, err := loadEncryptionKey(fc.Encryption.OldKeyFile, fc.Encryption.OldKey)
+	if err != nil {
+		log.Fatal().Msgf("could not load old encryption key: %s", err.(*errors.Error).ErrorStack())
+	}
+
+	if encryptionOldKey != nil && encryptionKey == nil {
+		log.Fatal().Msg("an old encryption key is only used together with a new one")
+	}
+
+	encryptionKeygroups := []fred.KeygroupName{"*"}
+	if fc.Encryption.Keygroups != "" {
+		encryptionKeygroups = nil
+		for _, p := range strings.Split(fc.Encryption.Keygroups, ",") {
+			encryptionKeygroups = append(encryptionKeygroups, fred.KeygroupName(p))
+		}
+	}
+
+	// encrypted values are binary as well
+	if encryptionKey != nil && fc.Storage.Adaptor == "dynamo" {
+		log.Fatal().Msg("values cannot be encrypted with the dynamo storage adaptor")
+	}
+
+	var audit fred.AuditLog
+	var newAudit func(store fred.Store) (fred.AuditLog, error)
+	var auditFile *auditlog.File
+
+	switch fc.Audit.Log {
+	case "file":
+		auditFile, err = auditlog.NewFile(fc.Audit.Path, fc.Audit.MaxSize, fc.Audit.MaxFiles)
+		if err != nil {
+			log.Fatal().Msgf("could not open audit log: %s", err.(*errors.Error).ErrorStack())
+		}
+		audit = auditFile
+	case "keygroup":
+		// the keygroup is written through the store decorators of the node, which only exist once it is set up
+		newAudit = func(store fred.Store) (fred.AuditLog, error) {
+			return auditlog.NewKeygroup(store, fc.Audit.Expiry)
+		}
+	}
+
+	var admins []string
+
+	if fc.Auth.Admins != "" {
+		admins = strings.Split(fc.Auth.Admins, ",")
+	}
+
 	f := fred.New(&fred.Config{
-		Store:             store,
-		Client:            c,
-		NaSe:              n,
-		PeeringHost:       fc.Peering.Host,
-		PeeringHostProxy:  fc.Peering.Proxy,
-		ExternalHost:      fc.Server.Host,
-		ExternalHostProxy: fc.Server.Proxy,
-		TriggerCert:       fc.Trigger.Cert,
-		TriggerKey:        fc.Trigger.Key,
-		TriggerCA:         strings.Split(fc.Trigger.CA, ","),
+		Store:               store,
+		Client:              c,
+		NaSe:                n,
+		PeeringHost:         fc.Peering.Host,
+		PeeringHostProxy:    fc.Peering.Proxy,
+		ExternalHost:        fc.Server.Host,
+		ExternalHostProxy:   fc.Server.Proxy,
+		TriggerTLS:          triggerTLS.ClientConfig(true),
+		TriggerMaxAttempts:  fc.Trigger.MaxAttempts,
+		TriggerMaxBackoff:   time.Duration(fc.Trigger.MaxBackoff) * time.Second,
+		TriggerBatchSize:    fc.Trigger.BatchSize,
+		TriggerBatchLatency: time.Duration(fc.Trigger.BatchLatency) * time.Millisecond,
+		TriggerIdleTimeout:  time.Duration(fc.Trigger.IdleTimeout) * time.Second,
+		Admins:              admins,
+		RestrictCreate:      fc.Auth.RestrictCreate,
+		AuditLog:            audit,
+		NewAuditLog:         newAudit,
+		CacheSize:           fc.Cache.Size,
+		CacheRules:          cacheRules,
+		CompressionRules:    compressionRules,
+		EncryptionKey:       encryptionKey,
+		EncryptionOldKey:    encryptionOldKey,
+		EncryptionKeygroups: encryptionKeygroups,
+		EncryptionRotation:  time.Duration(fc.Encryption.Rotation) * time.Hour,
+		QuotaRules:          quotaRules,
+		NodeMaxItems:        fc.Quota.NodeMaxItems,
+		NodeMaxBytes:        fc.Quota.NodeMaxBytes,
 	})
 
+	if compress && fc.Compression.StatsInterval > 0 {
+		go logCompressionStats(f, time.Duration(fc.Compression.StatsInterval)*time.Second)
+	}
+
+	if fc.Cache.Size > 0 && fc.Cache.StatsInterval > 0 {
+		go logCacheStats(f, time.Duration(fc.Cache.StatsInterval)*time.Second)
+	}
+
 	log.Debug().Msg("Starting Interconnection Server...")
-	is := peering.NewServer(fc.Peering.Host, f.I, fc.Peering.Cert, fc.Peering.Key, fc.Peering.CA)
+	is := peering.NewServer(fc.Peering.Host, f.I, peeringTLS)
+
+	var tokens *api.TokenVerifier
+
+	if fc.Token.Keys != "" {
+		tokens, err = api.NewTokenVerifier(strings.Split(fc.Token.Keys, ","), fc.Token.Issuer, fc.Token.Audience, fc.Token.UserClaim, fc.Token.GroupsClaim)
+		if err != nil {
+			log.Fatal().Msgf("could not set up bearer tokens: %s", err.(*errors.Error).ErrorStack())
+		}
+	}
 
 	log.Debug().Msg("Starting GRPC Server for Client (==Externalconnection)...")
 	isProxied := fc.Server.Proxy != "" && fc.Server.Host != fc.Server.Proxy
-	es := api.NewServer(fc.Server.Host, f.E, fc.Server.Cert, fc.Server.Key, fc.Server.CA, isProxied, fc.Server.Proxy)
+	es := api.NewServer(fc.Server.Host, f.E, serverTLS, isProxied, fc.Server.Proxy, tokens)
+
+	if fc.Migrate.Adaptor != "" {
+		migrate := make(chan os.Signal, 1)
+		signal.Notify(migrate, syscall.SIGUSR1)
+
+		go func() {
+			<-migrate
+			signal.Stop(migrate)
+			migrateStore(f, fc)
+		}()
+	}
+
+	if encryptionKey != nil {
+		rotate := make(chan os.Signal, 1)
+		signal.Notify(rotate, syscall.SIGUSR2)
+
+		go func() {
+			for range rotate {
+				log.Info().Msg("rotating data keys")
+				if err := f.RotateKeys(); err != nil {
+					log.Error().Err(err).Msg("could not rotate all data keys")
+					continue
+				}
+				log.Info().Msg("rotated data keys")
+			}
+		}()
+	}
 
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit,
@@ -324,6 +888,15 @@ func main() {
 	log.Err(is.Close()).Msg("closing peering server")
 	log.Err(es.Close()).Msg("closing api server")
 	log.Err(store.Close()).Msg("closing database")
+	log.Err(n.Close()).Msg("closing nase connection")
+
+	for _, p := range []*certs.Provider{serverTLS, peeringTLS, triggerTLS} {
+		p.Close()
+	}
+
+	if auditFile != nil {
+		log.Err(auditFile.Close()).Msg("closing audit log")
+	}
 
 	if prof.cpu != nil {
 		pprof.StopCPUProfile()
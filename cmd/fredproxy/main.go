@@ -8,7 +8,9 @@ import (
 	"os/signal"
 	"strings"
 	"syscall"
+	"time"
 
+	"git.tu-berlin.de/mcc-fred/fred/pkg/certs"
 	"git.tu-berlin.de/mcc-fred/fred/pkg/proxy"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
@@ -32,6 +34,9 @@ func main() {
 	apiCert := flag.String("api-cert", "", "Certificate for API connection.")
 	apiKey := flag.String("api-key", "", "Key file for API connection.")
 	apiCA := flag.String("api-ca", "", "Certificate authority root certificate file for API connections.")
+	allowTokens := flag.Bool("allow-tokens", false, "Allow clients without a certificate that authenticate with a bearer token instead.")
+	crlFiles := flag.String("crl-file", "", "Comma-separated list of certificate revocation lists to check client certificates against.")
+	reloadInterval := flag.Int("tls-reload-interval", 10, "Interval in seconds to check certificates, keys, CA certificates, and CRLs for changes. 0 disables reloading.")
 
 	flag.Parse()
 
@@ -70,12 +75,27 @@ func main() {
 	// parse machines
 	p := proxy.NewProxy(strings.Split(*machines, ","))
 
-	pS, err := proxy.StartPeeringProxy(p, *peeringPort, *peeringCert, *peeringKey, *peeringCA)
+	peeringTLS, err := certs.NewProvider(*peeringCert, *peeringKey, []string{*peeringCA}, strings.Split(*crlFiles, ","))
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not load TLS configuration for peering")
+	}
+
+	apiTLS, err := certs.NewProvider(*apiCert, *apiKey, []string{*apiCA}, strings.Split(*crlFiles, ","))
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not load TLS configuration for API")
+	}
+
+	if *reloadInterval > 0 {
+		peeringTLS.Watch(time.Duration(*reloadInterval) * time.Second)
+		apiTLS.Watch(time.Duration(*reloadInterval) * time.Second)
+	}
+
+	pS, err := proxy.StartPeeringProxy(p, *peeringPort, peeringTLS)
 	if err != nil {
 		log.Fatal().Err(err).Msg(err.Error())
 	}
 
-	aS, err := proxy.StartAPIProxy(p, *clientPort, *apiCert, *apiKey, *apiCA)
+	aS, err := proxy.StartAPIProxy(p, *clientPort, apiTLS, *allowTokens)
 	if err != nil {
 		log.Fatal().Err(err).Msg(err.Error())
 	}
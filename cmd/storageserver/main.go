@@ -7,6 +7,8 @@ import (
 	"io/ioutil"
 	"net"
 	"os"
+	"strconv"
+	"strings"
 
 	storage2 "git.tu-berlin.de/mcc-fred/fred/proto/storage"
 	"google.golang.org/grpc/credentials"
@@ -21,6 +23,54 @@ import (
 	storage "git.tu-berlin.de/mcc-fred/fred/pkg/storageserver"
 )
 
+// parseQuotas parses a comma-separated list of namespaces, each followed by "=" and its maximum items and bytes
+// separated by "/", where empty parts are taken from the default quota and 0 means no limit.
+func parseQuotas(namespaces string, def storage.Quota) map[string]storage.Quota {
+	quotas := make(map[string]storage.Quota)
+
+	if namespaces == "" {
+		return quotas
+	}
+
+	for _, n := range strings.Split(namespaces, ",") {
+		parts := strings.SplitN(n, "=", 2)
+
+		if len(parts) != 2 {
+			log.Fatal().Msgf("quota %s has no \"=\"", n)
+		}
+
+		limits := strings.Split(parts[1], "/")
+
+		if len(limits) != 2 {
+			log.Fatal().Msgf("quota %s must have the form name=items/bytes", n)
+		}
+
+		q := def
+
+		for i, l := range limits {
+			if l == "" {
+				continue
+			}
+
+			v, err := strconv.ParseInt(l, 10, 64)
+
+			if err != nil || v < 0 {
+				log.Fatal().Msgf("invalid limit %s in quota %s", l, n)
+			}
+
+			if i == 0 {
+				q.MaxItems = v
+			} else {
+				q.MaxBytes = v
+			}
+		}
+
+		quotas[strings.TrimSpace(parts[0])] = q
+	}
+
+	return quotas
+}
+
 func main() {
 	path := flag.String("path", "./db", "Path for badgerdb")
 	host := flag.String("port", ":1337", "Host for the server to listen to")
@@ -31,7 +81,19 @@ func main() {
 	key := flag.String("key", "", "key file for grpc server")
 	ca := flag.String("ca-file", "", "CA root for grpc server")
 
+	legacy := flag.String("legacy-namespace", "", "Common name of the client certificate that uses the store without a namespace, e.g., to keep the data it stored before there were namespaces")
+	maxItems := flag.Int64("quota-max-items", 0, "Number of items that every namespace may store, unless set for the namespace. 0 means no limit.")
+	maxBytes := flag.Int64("quota-max-bytes", 0, "Number of bytes of IDs and values that every namespace may store, unless set for the namespace. 0 means no limit.")
+	namespaces := flag.String("quota-namespaces", "", "Comma-separated list of namespaces with their own quota, each followed by \"=\" and the maximum items and bytes separated by \"/\", where empty parts are taken from the defaults and 0 means no limit, e.g., \"nodeA=100000/,nodeB=0/0\".")
+
 	flag.Parse()
+
+	if *maxItems < 0 || *maxBytes < 0 {
+		flag.Usage()
+		log.Fatal().Msg("Quotas must not be negative.")
+	}
+
+	quota := storage.Quota{MaxItems: *maxItems, MaxBytes: *maxBytes}
 	lis, err := net.Listen("tcp", *host)
 	if err != nil {
 		log.Fatal().Msgf("failed to listen: %v", err)
@@ -97,7 +159,11 @@ func main() {
 
 	var store fred.Store = badgerdb.New(*path)
 	grpcServer := grpc.NewServer(grpc.Creds(credentials.NewTLS(config)))
-	storage2.RegisterDatabaseServer(grpcServer, storage.NewStorageServer(&store))
+	storage2.RegisterDatabaseServer(grpcServer, storage.NewStorageServer(&store, storage.Config{
+		Legacy: *legacy,
+		Quota:  quota,
+		Quotas: parseQuotas(*namespaces, quota),
+	}))
 	log.Debug().Msgf("Server is listening on port %s", *host)
 	log.Fatal().Err(grpcServer.Serve(lis))
 	log.Err(store.Close()).Msg("error closing database")
@@ -7,11 +7,15 @@ require (
 	github.com/caarlos0/env/v6 v6.4.0
 	github.com/cespare/xxhash/v2 v2.1.1
 	github.com/dgraph-io/badger/v3 v3.2103.0
-	github.com/dgraph-io/ristretto v0.1.0
+	github.com/dgraph-io/ristretto v0.1.0 // indirect
+	github.com/form3tech-oss/jwt-go v3.2.3+incompatible
 	github.com/go-errors/errors v1.1.1
+	github.com/golang/snappy v0.0.1
+	github.com/golang/snappy v0.0.1
 	github.com/mmcloughlin/geohash v0.9.0
 	github.com/rs/zerolog v1.17.2
 	github.com/stretchr/testify v1.7.0
+	go.etcd.io/etcd/api/v3 v3.5.0
 	go.etcd.io/etcd/client/pkg/v3 v3.5.0
 	go.etcd.io/etcd/client/v3 v3.5.0
 	go.etcd.io/etcd/server/v3 v3.5.0
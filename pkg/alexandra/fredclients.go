@@ -1,10 +1,7 @@
 package alexandra
 
 import (
-	"crypto/tls"
-	"crypto/x509"
-	"io/ioutil"
-
+	"git.tu-berlin.de/mcc-fred/fred/pkg/certs"
 	alexandraProto "git.tu-berlin.de/mcc-fred/fred/proto/middleware"
 	"github.com/rs/zerolog/log"
 	"google.golang.org/grpc"
@@ -12,8 +9,8 @@ import (
 )
 
 type ClientsMgr struct {
-	clients                            map[string]*Client
-	clientsCert, clientsKey, clientsCA string
+	clients map[string]*Client
+	tls     *certs.Provider
 }
 
 type Client struct {
@@ -21,12 +18,10 @@ type Client struct {
 	conn   *grpc.ClientConn
 }
 
-func newClientsManager(clientsCert, clientsKey, clientsCA string) *ClientsMgr {
+func newClientsManager(provider *certs.Provider) *ClientsMgr {
 	return &ClientsMgr{
-		clients:     make(map[string]*Client),
-		clientsCert: clientsCert,
-		clientsKey:  clientsKey,
-		clientsCA:   clientsCA,
+		clients: make(map[string]*Client),
+		tls:     provider,
 	}
 }
 
@@ -35,36 +30,13 @@ func (m *ClientsMgr) GetClientTo(host string) (client *Client) {
 	if client != nil {
 		return
 	}
-	client = newClient(host, m.clientsCert, m.clientsKey, m.clientsCA)
+	client = newClient(host, m.tls)
 	m.clients[host] = client
 	return
 }
 
-func newClient(host, certFile, keyFile, caFile string) *Client {
-	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
-
-	if err != nil {
-		log.Fatal().Err(err).Str("certFile", certFile).Str("keyFile", keyFile).Msg("Cannot load certificates for new FredClient")
-		return nil
-	}
-
-	// Create a new cert pool and add our own CA certificate
-	rootCAs := x509.NewCertPool()
-
-	loaded, err := ioutil.ReadFile(caFile)
-
-	if err != nil {
-		log.Fatal().Msgf("unexpected missing certfile: %v", err)
-	}
-
-	rootCAs.AppendCertsFromPEM(loaded)
-
-	tlsConfig := &tls.Config{
-		Certificates: []tls.Certificate{cert},
-		RootCAs:      rootCAs,
-	}
-
-	tc := credentials.NewTLS(tlsConfig)
+func newClient(host string, provider *certs.Provider) *Client {
+	tc := credentials.NewTLS(provider.ClientConfig(false))
 
 	conn, err := grpc.Dial(host, grpc.WithTransportCredentials(tc))
 
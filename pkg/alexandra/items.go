@@ -7,21 +7,21 @@ import (
 )
 
 func (s *Server) Read(ctx context.Context, request *alexandraProto.ReadRequest) (*alexandraProto.ReadResponse, error) {
-	return s.clientsMgr.GetClientTo(s.lighthouse).client.Read(ctx, request)
+	return s.clientsMgr.GetClientTo(s.lighthouse).client.Read(forwardToken(ctx), request)
 }
 
 func (s *Server) Scan(ctx context.Context, request *alexandraProto.ScanRequest) (*alexandraProto.ScanResponse, error) {
-	return s.clientsMgr.GetClientTo(s.lighthouse).client.Scan(ctx, request)
+	return s.clientsMgr.GetClientTo(s.lighthouse).client.Scan(forwardToken(ctx), request)
 }
 
 func (s *Server) Update(ctx context.Context, request *alexandraProto.UpdateRequest) (*alexandraProto.StatusResponse, error) {
-	return s.clientsMgr.GetClientTo(s.lighthouse).client.Update(ctx, request)
+	return s.clientsMgr.GetClientTo(s.lighthouse).client.Update(forwardToken(ctx), request)
 }
 
 func (s *Server) Delete(ctx context.Context, request *alexandraProto.DeleteRequest) (*alexandraProto.StatusResponse, error) {
-	return s.clientsMgr.GetClientTo(s.lighthouse).client.Delete(ctx, request)
+	return s.clientsMgr.GetClientTo(s.lighthouse).client.Delete(forwardToken(ctx), request)
 }
 
 func (s *Server) Append(ctx context.Context, request *alexandraProto.AppendRequest) (*alexandraProto.AppendResponse, error) {
-	return s.clientsMgr.GetClientTo(s.lighthouse).client.Append(ctx, request)
+	return s.clientsMgr.GetClientTo(s.lighthouse).client.Append(forwardToken(ctx), request)
 }
@@ -7,9 +7,9 @@ import (
 )
 
 func (s *Server) CreateKeygroup(ctx context.Context, request *alexandraProto.CreateKeygroupRequest) (*alexandraProto.StatusResponse, error) {
-	return s.clientsMgr.GetClientTo(s.lighthouse).client.CreateKeygroup(ctx, request)
+	return s.clientsMgr.GetClientTo(s.lighthouse).client.CreateKeygroup(forwardToken(ctx), request)
 }
 
 func (s *Server) DeleteKeygroup(ctx context.Context, request *alexandraProto.DeleteKeygroupRequest) (*alexandraProto.StatusResponse, error) {
-	return s.clientsMgr.GetClientTo(s.lighthouse).client.DeleteKeygroup(ctx, request)
+	return s.clientsMgr.GetClientTo(s.lighthouse).client.DeleteKeygroup(forwardToken(ctx), request)
 }
@@ -7,23 +7,23 @@ import (
 )
 
 func (s *Server) AddReplica(ctx context.Context, request *alexandraProto.AddReplicaRequest) (*alexandraProto.StatusResponse, error) {
-	return s.clientsMgr.GetClientTo(s.lighthouse).client.AddReplica(ctx, request)
+	return s.clientsMgr.GetClientTo(s.lighthouse).client.AddReplica(forwardToken(ctx), request)
 }
 
 func (s *Server) RemoveReplica(ctx context.Context, request *alexandraProto.RemoveReplicaRequest) (*alexandraProto.StatusResponse, error) {
-	return s.clientsMgr.GetClientTo(s.lighthouse).client.RemoveReplica(ctx, request)
+	return s.clientsMgr.GetClientTo(s.lighthouse).client.RemoveReplica(forwardToken(ctx), request)
 }
 
 func (s *Server) GetReplica(ctx context.Context, request *alexandraProto.GetReplicaRequest) (*alexandraProto.GetReplicaResponse, error) {
-	return s.clientsMgr.GetClientTo(s.lighthouse).client.GetReplica(ctx, request)
+	return s.clientsMgr.GetClientTo(s.lighthouse).client.GetReplica(forwardToken(ctx), request)
 }
 
 func (s *Server) GetAllReplica(ctx context.Context, request *alexandraProto.GetAllReplicaRequest) (*alexandraProto.GetAllReplicaResponse, error) {
-	return s.clientsMgr.GetClientTo(s.lighthouse).client.GetAllReplica(ctx, request)
+	return s.clientsMgr.GetClientTo(s.lighthouse).client.GetAllReplica(forwardToken(ctx), request)
 }
 
 func (s *Server) GetKeygroupReplica(ctx context.Context, request *alexandraProto.GetKeygroupReplicaRequest) (*alexandraProto.GetKeygroupReplicaResponse, error) {
-	return s.clientsMgr.GetClientTo(s.lighthouse).client.GetKeygroupReplica(ctx, request)
+	return s.clientsMgr.GetClientTo(s.lighthouse).client.GetKeygroupReplica(forwardToken(ctx), request)
 }
 
 
@@ -4,22 +4,23 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
-	"io/ioutil"
 	"net"
 	"time"
 
+	"git.tu-berlin.de/mcc-fred/fred/pkg/certs"
 	alexandraProto "git.tu-berlin.de/mcc-fred/fred/proto/middleware"
 	"github.com/go-errors/errors"
 	"github.com/rs/zerolog/log"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/peer"
 )
 
 // Server listens to GRPC requests from clients (and sends them to the relevant Fred Node etc.)
 // The implementation is split up into different files in this folder.
 type Server struct {
-	roots      *x509.CertPool
+	tls        *certs.Provider
 	isProxied  bool
 	proxyHost  string
 	clientsMgr *ClientsMgr
@@ -28,32 +29,15 @@ type Server struct {
 	*grpc.Server
 }
 
-// NewServer creates a new Server for requests from Fred Clients
-func NewServer(host string, caCert string, serverCert string, serverKey string, nodesCert string, nodesKey string, lighthouse string, isProxied bool, proxyHost string) *Server {
-	// Load server's certificate and private key
-	loadedServerCert, err := tls.LoadX509KeyPair(serverCert, serverKey)
+// NewServer creates a new Server for requests from Fred Clients. The server TLS configuration is used for clients, the
+// nodes TLS configuration for connections to FReD nodes.
+// If allowTokens is set, clients may connect without a certificate and authenticate with a bearer token instead, which
+// is forwarded to and checked by the FReD nodes.
+func NewServer(host string, serverTLS *certs.Provider, nodesTLS *certs.Provider, lighthouse string, isProxied bool, proxyHost string, allowTokens bool) *Server {
+	clientAuth := tls.RequireAndVerifyClientCert
 
-	if err != nil {
-		log.Fatal().Msgf("could not load key pair: %v", err)
-		return nil
-	}
-
-	// Create a new cert pool and add our own CA certificate
-	rootCAs := x509.NewCertPool()
-
-	loaded, err := ioutil.ReadFile(caCert)
-
-	if err != nil {
-		log.Fatal().Msgf("unexpected missing certfile: %v", err)
-	}
-
-	rootCAs.AppendCertsFromPEM(loaded)
-	// Create the credentials and return it
-	config := &tls.Config{
-		Certificates: []tls.Certificate{loadedServerCert},
-		ClientAuth:   tls.RequireAndVerifyClientCert,
-		ClientCAs:    rootCAs,
-		MinVersion:   tls.VersionTLS12,
+	if allowTokens {
+		clientAuth = tls.VerifyClientCertIfGiven
 	}
 
 	lis, err := net.Listen("tcp", host)
@@ -64,14 +48,14 @@ func NewServer(host string, caCert string, serverCert string, serverKey string,
 	}
 
 	s := &Server{
-		rootCAs,
+		serverTLS,
 		isProxied,
 		proxyHost,
-		newClientsManager(nodesCert, nodesKey, caCert),
+		newClientsManager(nodesTLS),
 		lighthouse,
 		lis,
 		grpc.NewServer(
-			grpc.Creds(credentials.NewTLS(config)),
+			grpc.Creds(credentials.NewTLS(serverTLS.ServerConfig(clientAuth))),
 		),
 	}
 
@@ -87,6 +71,24 @@ func (s *Server) ServeBlocking() {
 
 }
 
+// forwardToken passes the bearer token of a client request on to the FReD node, if there is one. FReD nodes prefer a
+// token over our own certificate, so the request is then authorized for the user in the token.
+func forwardToken(ctx context.Context) context.Context {
+	md, ok := metadata.FromIncomingContext(ctx)
+
+	if !ok {
+		return ctx
+	}
+
+	auth := md.Get("authorization")
+
+	if len(auth) == 0 {
+		return ctx
+	}
+
+	return metadata.AppendToOutgoingContext(ctx, "authorization", auth[0])
+}
+
 // CheckCert checks the certificate from the given gRPC context for validity and returns the Common Name
 func (s *Server) CheckCert(ctx context.Context) (name string, err error) {
 	// get peer information
@@ -108,6 +110,10 @@ func (s *Server) CheckCert(ctx context.Context) (name string, err error) {
 		return name, errors.Errorf("could not verify peer certificate: %v", tlsAuth.State)
 	}
 
+	if err := s.tls.CheckRevoked(tlsAuth.State.VerifiedChains[0][0]); err != nil {
+		return name, err
+	}
+
 	host, _, err := net.SplitHostPort(p.Addr.String())
 
 	if err != nil {
@@ -122,7 +128,7 @@ func (s *Server) CheckCert(ctx context.Context) (name string, err error) {
 	// 4) the certificate should have the clients address as a SAN
 	if !s.isProxied {
 		_, err = tlsAuth.State.VerifiedChains[0][0].Verify(x509.VerifyOptions{
-			Roots:         s.roots,
+			Roots:         s.tls.Roots(),
 			CurrentTime:   time.Now(),
 			Intermediates: x509.NewCertPool(),
 			KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
@@ -137,7 +143,7 @@ func (s *Server) CheckCert(ctx context.Context) (name string, err error) {
 		// hence if we can ensure that it is indeed the proxy that is talking to us and not someone who has found
 		// their way into the network, we can be sure that the proxy/LB has checked the certificate
 		_, err = tlsAuth.State.VerifiedChains[0][0].Verify(x509.VerifyOptions{
-			Roots:         s.roots,
+			Roots:         s.tls.Roots(),
 			CurrentTime:   time.Now(),
 			Intermediates: x509.NewCertPool(),
 			KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
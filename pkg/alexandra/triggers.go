@@ -7,14 +7,14 @@ import (
 )
 
 func (s *Server) GetKeygroupTriggers(ctx context.Context, request *alexandraProto.GetKeygroupTriggerRequest) (*alexandraProto.GetKeygroupTriggerResponse, error) {
-	return s.clientsMgr.GetClientTo(s.lighthouse).client.GetKeygroupTriggers(ctx, request)
+	return s.clientsMgr.GetClientTo(s.lighthouse).client.GetKeygroupTriggers(forwardToken(ctx), request)
 }
 
 func (s *Server) AddTrigger(ctx context.Context, request *alexandraProto.AddTriggerRequest) (*alexandraProto.StatusResponse, error) {
-	return s.clientsMgr.GetClientTo(s.lighthouse).client.AddTrigger(ctx, request)
+	return s.clientsMgr.GetClientTo(s.lighthouse).client.AddTrigger(forwardToken(ctx), request)
 }
 
 func (s *Server) RemoveTrigger(ctx context.Context, request *alexandraProto.RemoveTriggerRequest) (*alexandraProto.StatusResponse, error) {
-	return s.clientsMgr.GetClientTo(s.lighthouse).client.RemoveTrigger(ctx, request)
+	return s.clientsMgr.GetClientTo(s.lighthouse).client.RemoveTrigger(forwardToken(ctx), request)
 }
 
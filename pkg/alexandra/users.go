@@ -18,9 +18,9 @@ import (
 //)
 
 func (s *Server) AddUser(ctx context.Context, request *alexandraProto.UserRequest) (*alexandraProto.StatusResponse, error) {
-	return s.clientsMgr.GetClientTo(s.lighthouse).client.AddUser(ctx, request)
+	return s.clientsMgr.GetClientTo(s.lighthouse).client.AddUser(forwardToken(ctx), request)
 }
 
 func (s *Server) RemoveUser(ctx context.Context, request *alexandraProto.UserRequest) (*alexandraProto.StatusResponse, error) {
-	return s.clientsMgr.GetClientTo(s.lighthouse).client.RemoveUser(ctx, request)
+	return s.clientsMgr.GetClientTo(s.lighthouse).client.RemoveUser(forwardToken(ctx), request)
 }
@@ -1,13 +1,15 @@
 package api
 
 import (
+	"bufio"
 	"context"
 	"crypto/tls"
 	"crypto/x509"
-	"io/ioutil"
 	"net"
+	"sort"
 	"time"
 
+	"git.tu-berlin.de/mcc-fred/fred/pkg/certs"
 	"git.tu-berlin.de/mcc-fred/fred/pkg/fred"
 	"git.tu-berlin.de/mcc-fred/fred/proto/client"
 	"github.com/go-errors/errors"
@@ -21,7 +23,8 @@ import (
 // Server handles GRPC Requests and calls the according functions of the exthandler
 type Server struct {
 	e         fred.ExtHandler
-	roots     *x509.CertPool
+	tokens    *TokenVerifier
+	tls       *certs.Provider
 	isProxied bool
 	proxyHost string
 	proxyPort string
@@ -40,6 +43,107 @@ var (
 	}
 )
 
+// roleFromRequest returns the custom role of a request if it is set and the built-in role otherwise.
+func roleFromRequest(request *client.UserRequest) fred.Role {
+	if request.CustomRole != "" {
+		return fred.Role(request.CustomRole)
+	}
+
+	return Roles[request.Role]
+}
+
+// triggerFilterFromRequest turns the filter of a request into a fred.TriggerFilter. A missing filter matches everything.
+func triggerFilterFromRequest(f *client.TriggerFilter) fred.TriggerFilter {
+	if f == nil {
+		return fred.TriggerFilter{}
+	}
+
+	ops := make([]fred.TriggerOp, len(f.Operations))
+
+	for i, op := range f.Operations {
+		ops[i] = fred.TriggerOp(op)
+	}
+
+	return fred.TriggerFilter{
+		IDPrefix:       f.IdPrefix,
+		IDRegex:        f.IdRegex,
+		Ops:            ops,
+		ValuePredicate: f.ValuePredicate,
+		Origins:        fred.TriggerOrigins(f.Origins),
+	}
+}
+
+// triggerFilterToResponse turns a fred.TriggerFilter into its representation in a response.
+func triggerFilterToResponse(f fred.TriggerFilter) *client.TriggerFilter {
+	if f.IsEmpty() {
+		return nil
+	}
+
+	ops := make([]string, len(f.Ops))
+
+	for i, op := range f.Ops {
+		ops[i] = string(op)
+	}
+
+	return &client.TriggerFilter{
+		IdPrefix:       f.IDPrefix,
+		IdRegex:        f.IDRegex,
+		Operations:     ops,
+		ValuePredicate: f.ValuePredicate,
+		Origins:        string(f.Origins),
+	}
+}
+
+// unixNano returns the nanoseconds since the Unix epoch, or 0 for the zero time.
+func unixNano(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+
+	return t.UnixNano()
+}
+
+// triggerStatsToResponse turns fred.TriggerStats into their representation in a response.
+func triggerStatsToResponse(s fred.TriggerStats) *client.TriggerStats {
+	return &client.TriggerStats{
+		Delivered:      s.Delivered,
+		Failed:         s.Failed,
+		Dead:           s.Dead,
+		LastSuccess:    unixNano(s.LastSuccess),
+		LastFailure:    unixNano(s.LastFailure),
+		LastError:      s.LastError,
+		Backlog:        uint64(s.Backlog),
+		AverageLatency: int64(s.AvgLatency),
+	}
+}
+
+// authenticate finds out which user sent a request. If bearer tokens are enabled on this node and the request has one,
+// the user and their groups are taken from the token. Otherwise, the user is taken from the client certificate.
+// It returns the handler that should handle the request for that user.
+func (s *Server) authenticate(ctx context.Context) (fred.ExtHandler, string, error) {
+	if s.tokens != nil {
+		if raw, ok := bearerToken(ctx); ok {
+			user, groups, err := s.tokens.Verify(raw)
+
+			if err != nil {
+				return nil, "", err
+			}
+
+			log.Debug().Msgf("authenticate: token for user %s with groups %v", user, groups)
+
+			return s.e.WithGroups(groups), user, nil
+		}
+	}
+
+	user, err := s.CheckCert(ctx)
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	return s.e, user, nil
+}
+
 // CheckCert checks the certificate from the given gRPC context for validity and returns the Common Name
 func (s *Server) CheckCert(ctx context.Context) (string, error) {
 	// get peer information
@@ -61,6 +165,11 @@ func (s *Server) CheckCert(ctx context.Context) (string, error) {
 		return "", errors.Errorf("could not verify peer certificate: %v", tlsAuth.State)
 	}
 
+	// connections can stay open for a long time, so the certificate might have been revoked since the handshake
+	if err := s.tls.CheckRevoked(tlsAuth.State.VerifiedChains[0][0]); err != nil {
+		return "", err
+	}
+
 	host, _, err := net.SplitHostPort(p.Addr.String())
 
 	if err != nil {
@@ -75,7 +184,7 @@ func (s *Server) CheckCert(ctx context.Context) (string, error) {
 	// 4) the certificate should have the clients address as a SAN
 	if !s.isProxied {
 		_, err = tlsAuth.State.VerifiedChains[0][0].Verify(x509.VerifyOptions{
-			Roots:         s.roots,
+			Roots:         s.tls.Roots(),
 			CurrentTime:   time.Now(),
 			Intermediates: x509.NewCertPool(),
 			KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
@@ -107,7 +216,7 @@ func (s *Server) CheckCert(ctx context.Context) (string, error) {
 	}
 
 	_, err = tlsAuth.State.VerifiedChains[0][0].Verify(x509.VerifyOptions{
-		Roots:         s.roots,
+		Roots:         s.tls.Roots(),
 		CurrentTime:   time.Now(),
 		Intermediates: x509.NewCertPool(),
 		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
@@ -132,32 +241,14 @@ func (s *Server) CheckCert(ctx context.Context) (string, error) {
 	return u[0], nil
 }
 
-// NewServer creates a new Server for requests from Fred Clients
-func NewServer(host string, handler fred.ExtHandler, cert string, key string, caCert string, isProxied bool, proxy string) *Server {
-	// Load server's certificate and private key
-	serverCert, err := tls.LoadX509KeyPair(cert, key)
+// NewServer creates a new Server for requests from Fred Clients. If tokens is not nil, clients can also authenticate
+// with a bearer token instead of a client certificate.
+func NewServer(host string, handler fred.ExtHandler, provider *certs.Provider, isProxied bool, proxy string, tokens *TokenVerifier) *Server {
+	// clients with a token don't need a certificate, but if they have one, it must still be valid
+	clientAuth := tls.RequireAndVerifyClientCert
 
-	if err != nil {
-		log.Fatal().Msgf("could not load key pair: %v", err)
-		return nil
-	}
-
-	// Create a new cert pool and add our own CA certificate
-	rootCAs := x509.NewCertPool()
-
-	loaded, err := ioutil.ReadFile(caCert)
-
-	if err != nil {
-		log.Fatal().Msgf("unexpected missing certfile: %v", err)
-	}
-
-	rootCAs.AppendCertsFromPEM(loaded)
-	// Create the credentials and return it
-	config := &tls.Config{
-		Certificates: []tls.Certificate{serverCert},
-		ClientAuth:   tls.RequireAndVerifyClientCert,
-		ClientCAs:    rootCAs,
-		MinVersion:   tls.VersionTLS12,
+	if tokens != nil {
+		clientAuth = tls.VerifyClientCertIfGiven
 	}
 
 	proxyHost, proxyPort, err := net.SplitHostPort(proxy)
@@ -169,12 +260,13 @@ func NewServer(host string, handler fred.ExtHandler, cert string, key string, ca
 
 	s := &Server{
 		e:         handler,
-		roots:     rootCAs,
+		tokens:    tokens,
+		tls:       provider,
 		isProxied: isProxied,
 		proxyHost: proxyHost,
 		proxyPort: proxyPort,
 		Server: grpc.NewServer(
-			grpc.Creds(credentials.NewTLS(config)),
+			grpc.Creds(credentials.NewTLS(provider.ServerConfig(clientAuth))),
 		),
 	}
 
@@ -223,13 +315,13 @@ func (s *Server) CreateKeygroup(ctx context.Context, request *client.CreateKeygr
 
 	log.Info().Msgf("ExtServer has rcvd CreateKeygroup. In: %#v", request)
 
-	user, err := s.CheckCert(ctx)
+	h, user, err := s.authenticate(ctx)
 
 	if err != nil {
 		return statusResponseFromError(err)
 	}
 
-	err = s.e.HandleCreateKeygroup(user, fred.Keygroup{Name: fred.KeygroupName(request.Keygroup), Mutable: request.Mutable, Expiry: int(request.Expiry)})
+	err = h.HandleCreateKeygroup(user, fred.Keygroup{Name: fred.KeygroupName(request.Keygroup), Mutable: request.Mutable, Expiry: int(request.Expiry)})
 
 	return statusResponseFromError(err)
 }
@@ -239,13 +331,13 @@ func (s *Server) DeleteKeygroup(ctx context.Context, request *client.DeleteKeygr
 
 	log.Info().Msgf("ExtServer has rcvd DeleteKeygroup. In: %#v", request)
 
-	user, err := s.CheckCert(ctx)
+	h, user, err := s.authenticate(ctx)
 
 	if err != nil {
 		return statusResponseFromError(err)
 	}
 
-	err = s.e.HandleDeleteKeygroup(user, fred.Keygroup{Name: fred.KeygroupName(request.Keygroup)})
+	err = h.HandleDeleteKeygroup(user, fred.Keygroup{Name: fred.KeygroupName(request.Keygroup)})
 
 	return statusResponseFromError(err)
 }
@@ -254,14 +346,14 @@ func (s *Server) DeleteKeygroup(ctx context.Context, request *client.DeleteKeygr
 func (s *Server) Read(ctx context.Context, request *client.ReadRequest) (*client.ReadResponse, error) {
 	log.Info().Msgf("ExtServer has rcvd Read. In: %#v", request)
 
-	user, err := s.CheckCert(ctx)
+	h, user, err := s.authenticate(ctx)
 
 	if err != nil {
 		_, err = statusResponseFromError(err)
 		return nil, err
 	}
 
-	res, err := s.e.HandleRead(user, fred.Item{Keygroup: fred.KeygroupName(request.Keygroup), ID: request.Id})
+	res, err := h.HandleRead(user, fred.Item{Keygroup: fred.KeygroupName(request.Keygroup), ID: request.Id})
 
 	if err != nil {
 		log.Debug().Msgf("ExtServer is returning error: %#v", err)
@@ -276,14 +368,14 @@ func (s *Server) Read(ctx context.Context, request *client.ReadRequest) (*client
 func (s *Server) Scan(ctx context.Context, request *client.ScanRequest) (*client.ScanResponse, error) {
 	log.Info().Msgf("ExtServer has rcvd Read. In: %#v", request)
 
-	user, err := s.CheckCert(ctx)
+	h, user, err := s.authenticate(ctx)
 
 	if err != nil {
 		_, err = statusResponseFromError(err)
 		return nil, err
 	}
 
-	res, err := s.e.HandleScan(user, fred.Item{Keygroup: fred.KeygroupName(request.Keygroup), ID: request.Id}, request.Count)
+	res, err := h.HandleScan(user, fred.Item{Keygroup: fred.KeygroupName(request.Keygroup), ID: request.Id}, request.Count)
 
 	if err != nil {
 		log.Debug().Msgf("ExtServer is returning error: %#v", err)
@@ -310,14 +402,14 @@ func (s *Server) Scan(ctx context.Context, request *client.ScanRequest) (*client
 func (s *Server) Append(ctx context.Context, request *client.AppendRequest) (*client.AppendResponse, error) {
 	log.Info().Msgf("ExtServer has rcvd Append. In: %#v", request)
 
-	user, err := s.CheckCert(ctx)
+	h, user, err := s.authenticate(ctx)
 
 	if err != nil {
 		_, err = statusResponseFromError(err)
 		return nil, err
 	}
 
-	res, err := s.e.HandleAppend(user, fred.Item{Keygroup: fred.KeygroupName(request.Keygroup), Val: request.Data})
+	res, err := h.HandleAppend(user, fred.Item{Keygroup: fred.KeygroupName(request.Keygroup), Val: request.Data})
 
 	if err != nil {
 		return &client.AppendResponse{}, err
@@ -333,14 +425,14 @@ func (s *Server) Update(ctx context.Context, request *client.UpdateRequest) (*cl
 
 	log.Info().Msgf("ExtServer has rcvd Update. In: %#v", request)
 
-	user, err := s.CheckCert(ctx)
+	h, user, err := s.authenticate(ctx)
 
 	if err != nil {
 		_, err = statusResponseFromError(err)
 		return nil, err
 	}
 
-	err = s.e.HandleUpdate(user, fred.Item{Keygroup: fred.KeygroupName(request.Keygroup), ID: request.Id, Val: request.Data})
+	err = h.HandleUpdate(user, fred.Item{Keygroup: fred.KeygroupName(request.Keygroup), ID: request.Id, Val: request.Data})
 
 	return statusResponseFromError(err)
 }
@@ -349,14 +441,14 @@ func (s *Server) Update(ctx context.Context, request *client.UpdateRequest) (*cl
 func (s *Server) Delete(ctx context.Context, request *client.DeleteRequest) (*client.StatusResponse, error) {
 	log.Info().Msgf("ExtServer has rcvd Delete. In: %#v", request)
 
-	user, err := s.CheckCert(ctx)
+	h, user, err := s.authenticate(ctx)
 
 	if err != nil {
 		_, err = statusResponseFromError(err)
 		return nil, err
 	}
 
-	err = s.e.HandleDelete(user, fred.Item{Keygroup: fred.KeygroupName(request.Keygroup), ID: request.Id})
+	err = h.HandleDelete(user, fred.Item{Keygroup: fred.KeygroupName(request.Keygroup), ID: request.Id})
 
 	return statusResponseFromError(err)
 }
@@ -365,14 +457,14 @@ func (s *Server) Delete(ctx context.Context, request *client.DeleteRequest) (*cl
 func (s *Server) AddReplica(ctx context.Context, request *client.AddReplicaRequest) (*client.StatusResponse, error) {
 	log.Info().Msgf("ExtServer has rcvd AddReplica. In: %#v", request)
 
-	user, err := s.CheckCert(ctx)
+	h, user, err := s.authenticate(ctx)
 
 	if err != nil {
 		_, err = statusResponseFromError(err)
 		return nil, err
 	}
 
-	err = s.e.HandleAddReplica(user, fred.Keygroup{Name: fred.KeygroupName(request.Keygroup), Expiry: int(request.Expiry)}, fred.Node{ID: fred.NodeID(request.NodeId)})
+	err = h.HandleAddReplica(user, fred.Keygroup{Name: fred.KeygroupName(request.Keygroup), Expiry: int(request.Expiry)}, fred.Node{ID: fred.NodeID(request.NodeId)})
 
 	return statusResponseFromError(err)
 }
@@ -381,14 +473,15 @@ func (s *Server) AddReplica(ctx context.Context, request *client.AddReplicaReque
 func (s *Server) GetKeygroupReplica(ctx context.Context, request *client.GetKeygroupReplicaRequest) (*client.GetKeygroupReplicaResponse, error) {
 	log.Info().Msgf("ExtServer has rcvd GetKeygroupReplica. In: %#v", request)
 
-	user, err := s.CheckCert(ctx)
+	h, user, err := s.authenticate(ctx)
 
 	if err != nil {
 		_, err = statusResponseFromError(err)
 		return nil, err
 	}
 
-	n, e, err := s.e.HandleGetKeygroupReplica(user, fred.Keygroup{Name: fred.KeygroupName(request.Keygroup)})
+	rev := h.Revision()
+	n, e, err := h.HandleGetKeygroupReplica(user, fred.Keygroup{Name: fred.KeygroupName(request.Keygroup)})
 
 	// Copy only the interesting values into a new array
 	replicas := make([]*client.KeygroupReplica, len(n))
@@ -406,7 +499,8 @@ func (s *Server) GetKeygroupReplica(ctx context.Context, request *client.GetKeyg
 	}
 
 	return &client.GetKeygroupReplicaResponse{
-		Replica: replicas,
+		Replica:  replicas,
+		Revision: rev,
 	}, nil
 
 }
@@ -415,18 +509,49 @@ func (s *Server) GetKeygroupReplica(ctx context.Context, request *client.GetKeyg
 func (s *Server) RemoveReplica(ctx context.Context, request *client.RemoveReplicaRequest) (*client.StatusResponse, error) {
 	log.Info().Msgf("ExtServer has rcvd RemoveReplica. In: %#v", request)
 
-	user, err := s.CheckCert(ctx)
+	h, user, err := s.authenticate(ctx)
 
 	if err != nil {
 		_, err = statusResponseFromError(err)
 		return nil, err
 	}
 
-	err = s.e.HandleRemoveReplica(user, fred.Keygroup{Name: fred.KeygroupName(request.Keygroup)}, fred.Node{ID: fred.NodeID(request.NodeId)})
+	err = h.HandleRemoveReplica(user, fred.Keygroup{Name: fred.KeygroupName(request.Keygroup)}, fred.Node{ID: fred.NodeID(request.NodeId)})
 
 	return statusResponseFromError(err)
 }
 
+// GetKeygroupUsage calls this method on the exthandler
+func (s *Server) GetKeygroupUsage(ctx context.Context, request *client.GetKeygroupUsageRequest) (*client.GetKeygroupUsageResponse, error) {
+	log.Info().Msgf("ExtServer has rcvd GetKeygroupUsage. In: %#v", request)
+
+	h, user, err := s.authenticate(ctx)
+
+	if err != nil {
+		_, err = statusResponseFromError(err)
+		return nil, err
+	}
+
+	u, err := h.HandleGetKeygroupUsage(user, fred.Keygroup{Name: fred.KeygroupName(request.Keygroup)})
+
+	if err != nil {
+		log.Debug().Msgf("ExtServer is returning error: %#v", err)
+		return &client.GetKeygroupUsageResponse{}, err
+	}
+
+	return &client.GetKeygroupUsageResponse{
+		Items:        u.Items,
+		Bytes:        u.Bytes,
+		MaxItemSize:  int64(u.Quota.MaxItemSize),
+		MaxItems:     u.Quota.MaxItems,
+		MaxBytes:     u.Quota.MaxBytes,
+		NodeItems:    u.NodeItems,
+		NodeBytes:    u.NodeBytes,
+		NodeMaxItems: u.NodeMaxItems,
+		NodeMaxBytes: u.NodeMaxBytes,
+	}, nil
+}
+
 func replicaResponseFromNode(n fred.Node) *client.GetReplicaResponse {
 	return &client.GetReplicaResponse{NodeId: string(n.ID), Host: n.Host}
 }
@@ -435,14 +560,14 @@ func replicaResponseFromNode(n fred.Node) *client.GetReplicaResponse {
 func (s *Server) GetReplica(ctx context.Context, request *client.GetReplicaRequest) (*client.GetReplicaResponse, error) {
 	log.Info().Msgf("ExtServer has rcvd GetReplica. In: %#v", request)
 
-	user, err := s.CheckCert(ctx)
+	h, user, err := s.authenticate(ctx)
 
 	if err != nil {
 		_, err = statusResponseFromError(err)
 		return nil, err
 	}
 
-	res, err := s.e.HandleGetReplica(user, fred.Node{ID: fred.NodeID(request.NodeId)})
+	res, err := h.HandleGetReplica(user, fred.Node{ID: fred.NodeID(request.NodeId)})
 
 	return replicaResponseFromNode(res), err
 }
@@ -451,14 +576,15 @@ func (s *Server) GetReplica(ctx context.Context, request *client.GetReplicaReque
 func (s *Server) GetAllReplica(ctx context.Context, request *client.GetAllReplicaRequest) (*client.GetAllReplicaResponse, error) {
 	log.Info().Msgf("ExtServer has rcvd GetAllReplica. In: %#v", request)
 
-	user, err := s.CheckCert(ctx)
+	h, user, err := s.authenticate(ctx)
 
 	if err != nil {
 		_, err = statusResponseFromError(err)
 		return nil, err
 	}
 
-	res, err := s.e.HandleGetAllReplica(user)
+	rev := h.Revision()
+	res, err := h.HandleGetAllReplica(user)
 
 	if err != nil {
 		log.Debug().Msgf("ExtServer is returning error: %#v", err)
@@ -471,21 +597,21 @@ func (s *Server) GetAllReplica(ctx context.Context, request *client.GetAllReplic
 		replicas[i] = replicaResponseFromNode(res[i])
 	}
 
-	return &client.GetAllReplicaResponse{Replicas: replicas}, nil
+	return &client.GetAllReplicaResponse{Replicas: replicas, Revision: rev}, nil
 }
 
 // GetKeygroupTriggers calls this method on the exthandler
 func (s *Server) GetKeygroupTriggers(ctx context.Context, request *client.GetKeygroupTriggerRequest) (*client.GetKeygroupTriggerResponse, error) {
 	log.Info().Msgf("ExtServer has rcvd GetKeygroupTriggers. In: %#v", request)
 
-	user, err := s.CheckCert(ctx)
+	h, user, err := s.authenticate(ctx)
 
 	if err != nil {
 		_, err = statusResponseFromError(err)
 		return nil, err
 	}
 
-	res, err := s.e.HandleGetKeygroupTriggers(user, fred.Keygroup{Name: fred.KeygroupName(request.Keygroup)})
+	res, err := h.HandleGetKeygroupTriggers(user, fred.Keygroup{Name: fred.KeygroupName(request.Keygroup)})
 	if err != nil {
 		log.Debug().Msgf("ExtServer is returning error: %#v", err)
 		return &client.GetKeygroupTriggerResponse{}, err
@@ -493,8 +619,23 @@ func (s *Server) GetKeygroupTriggers(ctx context.Context, request *client.GetKey
 	triggers := make([]*client.Trigger, len(res))
 	for i := 0; i < len(res); i++ {
 		triggers[i] = &client.Trigger{
-			Id:   res[i].ID,
-			Host: res[i].Host,
+			Id:     res[i].ID,
+			Host:   res[i].Host,
+			Filter: triggerFilterToResponse(res[i].Filter),
+			Stats:  triggerStatsToResponse(res[i].Stats),
+		}
+
+		// the secret of a webhook is not given out again
+		if res[i].Webhook.URL != "" {
+			triggers[i].Webhook = &client.Webhook{Url: res[i].Webhook.URL}
+		}
+
+		if res[i].Compute.Target != "" {
+			triggers[i].Compute = &client.Compute{
+				Target: string(res[i].Compute.Target),
+				Key:    res[i].Compute.Key,
+				Fields: res[i].Compute.Fields,
+			}
 		}
 	}
 	return &client.GetKeygroupTriggerResponse{Triggers: triggers}, nil
@@ -504,14 +645,37 @@ func (s *Server) GetKeygroupTriggers(ctx context.Context, request *client.GetKey
 func (s *Server) AddTrigger(ctx context.Context, request *client.AddTriggerRequest) (*client.StatusResponse, error) {
 	log.Info().Msgf("ExtServer has rcvd AddTrigger. In: %#v", request)
 
-	user, err := s.CheckCert(ctx)
+	h, user, err := s.authenticate(ctx)
 
 	if err != nil {
 		_, err = statusResponseFromError(err)
 		return nil, err
 	}
 
-	err = s.e.HandleAddTrigger(user, fred.Keygroup{Name: fred.KeygroupName(request.Keygroup)}, fred.Trigger{ID: request.TriggerId, Host: request.TriggerHost})
+	t := fred.Trigger{
+		ID:     request.TriggerId,
+		Host:   request.TriggerHost,
+		Filter: triggerFilterFromRequest(request.Filter),
+	}
+
+	if request.Webhook != nil {
+		t.Host = ""
+		t.Webhook = fred.Webhook{
+			URL:    request.Webhook.Url,
+			Secret: request.Webhook.Secret,
+		}
+	}
+
+	if request.Compute != nil {
+		t.Host = ""
+		t.Compute = fred.Compute{
+			Target: fred.KeygroupName(request.Compute.Target),
+			Key:    request.Compute.Key,
+			Fields: request.Compute.Fields,
+		}
+	}
+
+	err = h.HandleAddTrigger(user, fred.Keygroup{Name: fred.KeygroupName(request.Keygroup)}, t)
 
 	return statusResponseFromError(err)
 }
@@ -520,14 +684,14 @@ func (s *Server) AddTrigger(ctx context.Context, request *client.AddTriggerReque
 func (s *Server) RemoveTrigger(ctx context.Context, request *client.RemoveTriggerRequest) (*client.StatusResponse, error) {
 	log.Info().Msgf("ExtServer has rcvd RemoveTrigger. In: %#v", request)
 
-	user, err := s.CheckCert(ctx)
+	h, user, err := s.authenticate(ctx)
 
 	if err != nil {
 		_, err = statusResponseFromError(err)
 		return nil, err
 	}
 
-	err = s.e.HandleRemoveTrigger(user, fred.Keygroup{Name: fred.KeygroupName(request.Keygroup)}, fred.Trigger{ID: request.TriggerId})
+	err = h.HandleRemoveTrigger(user, fred.Keygroup{Name: fred.KeygroupName(request.Keygroup)}, fred.Trigger{ID: request.TriggerId})
 
 	return statusResponseFromError(err)
 }
@@ -536,14 +700,20 @@ func (s *Server) RemoveTrigger(ctx context.Context, request *client.RemoveTrigge
 func (s *Server) AddUser(ctx context.Context, request *client.UserRequest) (*client.StatusResponse, error) {
 	log.Info().Msgf("ExtServer has rcvd AddUser. In: %#v", request)
 
-	user, err := s.CheckCert(ctx)
+	h, user, err := s.authenticate(ctx)
 
 	if err != nil {
 		_, err = statusResponseFromError(err)
 		return nil, err
 	}
 
-	err = s.e.HandleAddUser(user, request.User, fred.Keygroup{Name: fred.KeygroupName(request.Keygroup)}, Roles[request.Role])
+	k := fred.Keygroup{Name: fred.KeygroupName(request.Keygroup)}
+
+	if request.Group {
+		err = h.HandleAddGroupRole(user, request.User, k, roleFromRequest(request))
+	} else {
+		err = h.HandleAddUser(user, request.User, k, roleFromRequest(request))
+	}
 
 	return statusResponseFromError(err)
 }
@@ -552,14 +722,377 @@ func (s *Server) AddUser(ctx context.Context, request *client.UserRequest) (*cli
 func (s *Server) RemoveUser(ctx context.Context, request *client.UserRequest) (*client.StatusResponse, error) {
 	log.Info().Msgf("ExtServer has rcvd RemoveUser. In: %#v", request)
 
-	user, err := s.CheckCert(ctx)
+	h, user, err := s.authenticate(ctx)
 
 	if err != nil {
 		_, err = statusResponseFromError(err)
 		return nil, err
 	}
 
-	err = s.e.HandleRemoveUser(user, request.User, fred.Keygroup{Name: fred.KeygroupName(request.Keygroup)}, Roles[request.Role])
+	k := fred.Keygroup{Name: fred.KeygroupName(request.Keygroup)}
+
+	if request.Group {
+		err = h.HandleRemoveGroupRole(user, request.User, k, roleFromRequest(request))
+	} else {
+		err = h.HandleRemoveUser(user, request.User, k, roleFromRequest(request))
+	}
 
 	return statusResponseFromError(err)
 }
+
+// GetKeygroupPermissions calls this method on the exthandler
+func (s *Server) GetKeygroupPermissions(ctx context.Context, request *client.GetKeygroupPermissionsRequest) (*client.GetKeygroupPermissionsResponse, error) {
+	log.Info().Msgf("ExtServer has rcvd GetKeygroupPermissions. In: %#v", request)
+
+	h, user, err := s.authenticate(ctx)
+
+	if err != nil {
+		_, err = statusResponseFromError(err)
+		return nil, err
+	}
+
+	rev := h.Revision()
+	res, err := h.HandleGetKeygroupPermissions(user, fred.Keygroup{Name: fred.KeygroupName(request.Keygroup)})
+
+	if err != nil {
+		log.Debug().Msgf("ExtServer is returning error: %#v", err)
+		return &client.GetKeygroupPermissionsResponse{}, err
+	}
+
+	permissions := make([]*client.Permission, 0, len(res))
+
+	for subject, methods := range res {
+		permissions = append(permissions, &client.Permission{
+			Subject: subject.Name,
+			Group:   subject.Group,
+			Methods: methodNames(methods),
+		})
+	}
+
+	sort.Slice(permissions, func(i, j int) bool {
+		if permissions[i].Group != permissions[j].Group {
+			return !permissions[i].Group
+		}
+		return permissions[i].Subject < permissions[j].Subject
+	})
+
+	return &client.GetKeygroupPermissionsResponse{Permissions: permissions, Revision: rev}, nil
+}
+
+// AddRole calls this method on the exthandler
+func (s *Server) AddRole(ctx context.Context, request *client.AddRoleRequest) (*client.StatusResponse, error) {
+	log.Info().Msgf("ExtServer has rcvd AddRole. In: %#v", request)
+
+	h, user, err := s.authenticate(ctx)
+
+	if err != nil {
+		_, err = statusResponseFromError(err)
+		return nil, err
+	}
+
+	methods := make([]fred.Method, len(request.Methods))
+
+	for i, m := range request.Methods {
+		methods[i] = fred.Method(m)
+	}
+
+	err = h.HandleAddRole(user, fred.Role(request.Role), methods)
+
+	return statusResponseFromError(err)
+}
+
+// RemoveRole calls this method on the exthandler
+func (s *Server) RemoveRole(ctx context.Context, request *client.RemoveRoleRequest) (*client.StatusResponse, error) {
+	log.Info().Msgf("ExtServer has rcvd RemoveRole. In: %#v", request)
+
+	h, user, err := s.authenticate(ctx)
+
+	if err != nil {
+		_, err = statusResponseFromError(err)
+		return nil, err
+	}
+
+	err = h.HandleRemoveRole(user, fred.Role(request.Role))
+
+	return statusResponseFromError(err)
+}
+
+// GetRoles calls this method on the exthandler
+func (s *Server) GetRoles(ctx context.Context, request *client.GetRolesRequest) (*client.GetRolesResponse, error) {
+	log.Info().Msgf("ExtServer has rcvd GetRoles. In: %#v", request)
+
+	h, user, err := s.authenticate(ctx)
+
+	if err != nil {
+		_, err = statusResponseFromError(err)
+		return nil, err
+	}
+
+	res, err := h.HandleGetRoles(user)
+
+	if err != nil {
+		log.Debug().Msgf("ExtServer is returning error: %#v", err)
+		return &client.GetRolesResponse{}, err
+	}
+
+	roles := make([]*client.RoleDefinition, 0, len(res))
+
+	for r, methods := range res {
+		roles = append(roles, &client.RoleDefinition{
+			Role:    string(r),
+			Methods: methodNames(methods),
+		})
+	}
+
+	sort.Slice(roles, func(i, j int) bool {
+		return roles[i].Role < roles[j].Role
+	})
+
+	return &client.GetRolesResponse{Roles: roles}, nil
+}
+
+// AddGroupMember calls this method on the exthandler
+func (s *Server) AddGroupMember(ctx context.Context, request *client.GroupMemberRequest) (*client.StatusResponse, error) {
+	log.Info().Msgf("ExtServer has rcvd AddGroupMember. In: %#v", request)
+
+	h, user, err := s.authenticate(ctx)
+
+	if err != nil {
+		_, err = statusResponseFromError(err)
+		return nil, err
+	}
+
+	err = h.HandleAddGroupMember(user, request.Group, request.User)
+
+	return statusResponseFromError(err)
+}
+
+// RemoveGroupMember calls this method on the exthandler
+func (s *Server) RemoveGroupMember(ctx context.Context, request *client.GroupMemberRequest) (*client.StatusResponse, error) {
+	log.Info().Msgf("ExtServer has rcvd RemoveGroupMember. In: %#v", request)
+
+	h, user, err := s.authenticate(ctx)
+
+	if err != nil {
+		_, err = statusResponseFromError(err)
+		return nil, err
+	}
+
+	err = h.HandleRemoveGroupMember(user, request.Group, request.User)
+
+	return statusResponseFromError(err)
+}
+
+// GetGroupMembers calls this method on the exthandler
+func (s *Server) GetGroupMembers(ctx context.Context, request *client.GetGroupMembersRequest) (*client.GetGroupMembersResponse, error) {
+	log.Info().Msgf("ExtServer has rcvd GetGroupMembers. In: %#v", request)
+
+	h, user, err := s.authenticate(ctx)
+
+	if err != nil {
+		_, err = statusResponseFromError(err)
+		return nil, err
+	}
+
+	res, err := h.HandleGetGroupMembers(user, request.Group)
+
+	if err != nil {
+		log.Debug().Msgf("ExtServer is returning error: %#v", err)
+		return &client.GetGroupMembersResponse{}, err
+	}
+
+	return &client.GetGroupMembersResponse{Users: res}, nil
+}
+
+// GetAuditLog calls this method on the exthandler
+func (s *Server) GetAuditLog(ctx context.Context, request *client.GetAuditLogRequest) (*client.GetAuditLogResponse, error) {
+	log.Info().Msgf("ExtServer has rcvd GetAuditLog. In: %#v", request)
+
+	h, user, err := s.authenticate(ctx)
+
+	if err != nil {
+		_, err = statusResponseFromError(err)
+		return nil, err
+	}
+
+	res, err := h.HandleGetAuditLog(user, fred.AuditQuery{
+		Keygroup: fred.KeygroupName(request.Keygroup),
+		User:     request.User,
+		Method:   fred.Method(request.Method),
+		Limit:    int(request.Limit),
+	})
+
+	if err != nil {
+		log.Debug().Msgf("ExtServer is returning error: %#v", err)
+		return &client.GetAuditLogResponse{}, err
+	}
+
+	entries := make([]*client.AuditEntry, len(res))
+
+	for i, e := range res {
+		entries[i] = &client.AuditEntry{
+			Timestamp: e.Time.UnixNano(),
+			Node:      string(e.Node),
+			User:      e.User,
+			Method:    string(e.Method),
+			Keygroup:  string(e.Keygroup),
+			Id:        e.ID,
+			Success:   e.Success,
+			Error:     e.Error,
+		}
+	}
+
+	return &client.GetAuditLogResponse{Entries: entries}, nil
+}
+
+// GetFailedTriggerEvents calls this method on the exthandler
+func (s *Server) GetFailedTriggerEvents(ctx context.Context, request *client.GetFailedTriggerEventsRequest) (*client.GetFailedTriggerEventsResponse, error) {
+	log.Info().Msgf("ExtServer has rcvd GetFailedTriggerEvents. In: %#v", request)
+
+	h, user, err := s.authenticate(ctx)
+
+	if err != nil {
+		_, err = statusResponseFromError(err)
+		return nil, err
+	}
+
+	res, err := h.HandleGetFailedTriggerEvents(user, fred.Keygroup{Name: fred.KeygroupName(request.Keygroup)}, request.TriggerId)
+
+	if err != nil {
+		log.Debug().Msgf("ExtServer is returning error: %#v", err)
+		return &client.GetFailedTriggerEventsResponse{}, err
+	}
+
+	events := make([]*client.TriggerEvent, len(res))
+
+	for i, e := range res {
+		events[i] = &client.TriggerEvent{
+			Seq:            e.Seq,
+			TriggerId:      e.Trigger.ID,
+			TriggerHost:    e.Trigger.Host,
+			Operation:      string(e.Op),
+			Id:             e.ID,
+			Val:            e.Val,
+			Timestamp:      e.Time.UnixNano(),
+			Attempts:       uint64(e.Attempts),
+			Error:          e.LastError,
+			Origin:         string(e.Origin),
+			KeygroupSeq:    e.KeygroupSeq,
+			IdempotencyKey: e.IdempotencyKey,
+		}
+	}
+
+	return &client.GetFailedTriggerEventsResponse{Events: events}, nil
+}
+
+// ReplayTriggerEvents calls this method on the exthandler
+func (s *Server) ReplayTriggerEvents(ctx context.Context, request *client.ReplayTriggerEventsRequest) (*client.StatusResponse, error) {
+	log.Info().Msgf("ExtServer has rcvd ReplayTriggerEvents. In: %#v", request)
+
+	h, user, err := s.authenticate(ctx)
+
+	if err != nil {
+		_, err = statusResponseFromError(err)
+		return nil, err
+	}
+
+	err = h.HandleReplayTriggerEvents(user, fred.Keygroup{Name: fred.KeygroupName(request.Keygroup)}, request.TriggerId, request.Seq)
+
+	return statusResponseFromError(err)
+}
+
+// backupChunkSize is the maximum size of a chunk of a backup that is sent at once.
+const backupChunkSize = 64 * 1024
+
+// backupWriter sends everything that is written to it as chunks of a backup.
+type backupWriter struct {
+	stream client.Client_BackupServer
+}
+
+func (w backupWriter) Write(p []byte) (int, error) {
+	if err := w.stream.Send(&client.BackupChunk{Data: append([]byte(nil), p...)}); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// restoreReader reads the chunks of a backup from a stream.
+type restoreReader struct {
+	stream client.Client_RestoreServer
+	buf    []byte
+}
+
+func (r *restoreReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		c, err := r.stream.Recv()
+
+		if err != nil {
+			return 0, err
+		}
+
+		r.buf = c.Data
+	}
+
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+
+	return n, nil
+}
+
+// Backup calls this method on the exthandler
+func (s *Server) Backup(request *client.BackupRequest, stream client.Client_BackupServer) error {
+	log.Info().Msgf("ExtServer has rcvd Backup. In: %#v", request)
+
+	h, user, err := s.authenticate(stream.Context())
+
+	if err != nil {
+		_, err = statusResponseFromError(err)
+		return err
+	}
+
+	w := bufio.NewWriterSize(backupWriter{stream: stream}, backupChunkSize)
+
+	err = h.HandleBackup(user, fred.Keygroup{Name: fred.KeygroupName(request.Keygroup)}, w)
+
+	if err != nil {
+		log.Debug().Msgf("ExtServer is returning error: %#v", err)
+		return err
+	}
+
+	return w.Flush()
+}
+
+// Restore calls this method on the exthandler
+func (s *Server) Restore(stream client.Client_RestoreServer) error {
+	log.Info().Msg("ExtServer has rcvd Restore.")
+
+	h, user, err := s.authenticate(stream.Context())
+
+	if err != nil {
+		_, err = statusResponseFromError(err)
+		return err
+	}
+
+	k, err := h.HandleRestore(user, &restoreReader{stream: stream})
+
+	if err != nil {
+		log.Debug().Msgf("ExtServer is returning error: %#v", err)
+		return err
+	}
+
+	return stream.SendAndClose(&client.RestoreResponse{Keygroup: string(k.Name)})
+}
+
+// methodNames turns a set of methods into a sorted list of method names.
+func methodNames(methods map[fred.Method]struct{}) []string {
+	names := make([]string, 0, len(methods))
+
+	for m := range methods {
+		names = append(names, string(m))
+	}
+
+	sort.Strings(names)
+
+	return names
+}
@@ -0,0 +1,177 @@
+package api
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"io/ioutil"
+	"strings"
+
+	"github.com/form3tech-oss/jwt-go"
+	"github.com/go-errors/errors"
+	"google.golang.org/grpc/metadata"
+)
+
+const bearerPrefix = "bearer "
+
+// TokenVerifier verifies signed JSON Web Tokens that clients can send as bearer tokens instead of using a client
+// certificate. Only asymmetric signatures (RSA and ECDSA) are accepted, so a node never needs a secret to verify a
+// token.
+type TokenVerifier struct {
+	keys        []interface{}
+	issuer      string
+	audience    string
+	userClaim   string
+	groupsClaim string
+}
+
+// NewTokenVerifier creates a verifier that accepts tokens signed with any of the public keys in the given PEM files.
+// If issuer or audience are set, tokens must have a matching "iss" or "aud" claim. The name of the user is read from
+// userClaim and the groups of the user are read from groupsClaim.
+func NewTokenVerifier(keyFiles []string, issuer string, audience string, userClaim string, groupsClaim string) (*TokenVerifier, error) {
+	if len(keyFiles) == 0 {
+		return nil, errors.Errorf("need at least one public key to verify tokens")
+	}
+
+	if userClaim == "" {
+		return nil, errors.Errorf("need a claim to read the user from")
+	}
+
+	v := &TokenVerifier{
+		keys:        make([]interface{}, 0, len(keyFiles)),
+		issuer:      issuer,
+		audience:    audience,
+		userClaim:   userClaim,
+		groupsClaim: groupsClaim,
+	}
+
+	for _, f := range keyFiles {
+		b, err := ioutil.ReadFile(f)
+
+		if err != nil {
+			return nil, errors.New(err)
+		}
+
+		if key, err := jwt.ParseRSAPublicKeyFromPEM(b); err == nil {
+			v.keys = append(v.keys, key)
+			continue
+		}
+
+		key, err := jwt.ParseECPublicKeyFromPEM(b)
+
+		if err != nil {
+			return nil, errors.Errorf("%s is neither an RSA nor an ECDSA public key", f)
+		}
+
+		v.keys = append(v.keys, key)
+	}
+
+	return v, nil
+}
+
+// keyFunc returns a function that gives the jwt library our key if the token is signed with a matching algorithm.
+func keyFunc(key interface{}) jwt.Keyfunc {
+	return func(t *jwt.Token) (interface{}, error) {
+		switch t.Method.(type) {
+		case *jwt.SigningMethodRSA, *jwt.SigningMethodRSAPSS:
+			if _, ok := key.(*rsa.PublicKey); ok {
+				return key, nil
+			}
+		case *jwt.SigningMethodECDSA:
+			if _, ok := key.(*ecdsa.PublicKey); ok {
+				return key, nil
+			}
+		default:
+			// in particular, this rejects "none" and HMAC signatures
+			return nil, errors.Errorf("unsupported signing method %v", t.Header["alg"])
+		}
+
+		return nil, errors.Errorf("key does not match signing method %v", t.Header["alg"])
+	}
+}
+
+// Verify checks the signature and claims of a token and returns the user and groups from the token.
+func (v *TokenVerifier) Verify(raw string) (string, []string, error) {
+	var t *jwt.Token
+	var err error
+
+	for _, key := range v.keys {
+		t, err = jwt.Parse(raw, keyFunc(key))
+
+		if err == nil {
+			break
+		}
+	}
+
+	if err != nil {
+		return "", nil, errors.Errorf("invalid token: %v", err)
+	}
+
+	claims, ok := t.Claims.(jwt.MapClaims)
+
+	if !ok || !t.Valid {
+		return "", nil, errors.Errorf("invalid token")
+	}
+
+	// the jwt library accepts tokens without expiry, but we don't want tokens that are valid forever
+	if _, ok := claims["exp"]; !ok {
+		return "", nil, errors.Errorf("token has no expiry")
+	}
+
+	if v.issuer != "" && !claims.VerifyIssuer(v.issuer, true) {
+		return "", nil, errors.Errorf("token has wrong issuer")
+	}
+
+	if v.audience != "" && !claims.VerifyAudience(v.audience, true) {
+		return "", nil, errors.Errorf("token has wrong audience")
+	}
+
+	user, ok := claims[v.userClaim].(string)
+
+	if !ok || user == "" {
+		return "", nil, errors.Errorf("token has no user in claim %s", v.userClaim)
+	}
+
+	if v.groupsClaim == "" {
+		return user, nil, nil
+	}
+
+	var groups []string
+
+	switch g := claims[v.groupsClaim].(type) {
+	case nil:
+	case string:
+		groups = []string{g}
+	case []interface{}:
+		for _, group := range g {
+			s, ok := group.(string)
+
+			if !ok {
+				return "", nil, errors.Errorf("token has invalid groups in claim %s", v.groupsClaim)
+			}
+
+			groups = append(groups, s)
+		}
+	default:
+		return "", nil, errors.Errorf("token has invalid groups in claim %s", v.groupsClaim)
+	}
+
+	return user, groups, nil
+}
+
+// bearerToken returns the bearer token from the "authorization" header of a request, if there is one.
+func bearerToken(ctx context.Context) (string, bool) {
+	md, ok := metadata.FromIncomingContext(ctx)
+
+	if !ok {
+		return "", false
+	}
+
+	a := md.Get("authorization")
+
+	if len(a) != 1 || !strings.HasPrefix(strings.ToLower(a[0]), bearerPrefix) {
+		return "", false
+	}
+
+	return strings.TrimSpace(a[0][len(bearerPrefix):]), true
+}
@@ -0,0 +1,128 @@
+package api
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/form3tech-oss/jwt-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+)
+
+func writePublicKey(t *testing.T, dir string, name string, pub interface{}) string {
+	b, err := x509.MarshalPKIXPublicKey(pub)
+	require.NoError(t, err)
+
+	f := filepath.Join(dir, name)
+	require.NoError(t, ioutil.WriteFile(f, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: b}), 0600))
+
+	return f
+}
+
+func sign(t *testing.T, m jwt.SigningMethod, key interface{}, claims jwt.MapClaims) string {
+	s, err := jwt.NewWithClaims(m, claims).SignedString(key)
+	require.NoError(t, err)
+	return s
+}
+
+func TestTokenVerifier(t *testing.T) {
+	dir := t.TempDir()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	v, err := NewTokenVerifier([]string{
+		writePublicKey(t, dir, "rsa.pem", &rsaKey.PublicKey),
+		writePublicKey(t, dir, "ec.pem", &ecKey.PublicKey),
+	}, "issuer", "fred", "sub", "groups")
+	require.NoError(t, err)
+
+	valid := func() jwt.MapClaims {
+		return jwt.MapClaims{
+			"sub":    "alice",
+			"iss":    "issuer",
+			"aud":    "fred",
+			"exp":    time.Now().Add(time.Hour).Unix(),
+			"groups": []string{"devs", "ops"},
+		}
+	}
+
+	user, groups, err := v.Verify(sign(t, jwt.SigningMethodRS256, rsaKey, valid()))
+	assert.NoError(t, err)
+	assert.Equal(t, "alice", user)
+	assert.Equal(t, []string{"devs", "ops"}, groups)
+
+	c := valid()
+	c["groups"] = "devs"
+	user, groups, err = v.Verify(sign(t, jwt.SigningMethodES256, ecKey, c))
+	assert.NoError(t, err)
+	assert.Equal(t, "alice", user)
+	assert.Equal(t, []string{"devs"}, groups)
+
+	c = valid()
+	c["exp"] = time.Now().Add(-time.Minute).Unix()
+	_, _, err = v.Verify(sign(t, jwt.SigningMethodRS256, rsaKey, c))
+	assert.Error(t, err, "expired token")
+
+	c = valid()
+	delete(c, "exp")
+	_, _, err = v.Verify(sign(t, jwt.SigningMethodRS256, rsaKey, c))
+	assert.Error(t, err, "token without expiry")
+
+	c = valid()
+	c["iss"] = "someone else"
+	_, _, err = v.Verify(sign(t, jwt.SigningMethodRS256, rsaKey, c))
+	assert.Error(t, err, "wrong issuer")
+
+	c = valid()
+	c["aud"] = "someone else"
+	_, _, err = v.Verify(sign(t, jwt.SigningMethodRS256, rsaKey, c))
+	assert.Error(t, err, "wrong audience")
+
+	c = valid()
+	delete(c, "sub")
+	_, _, err = v.Verify(sign(t, jwt.SigningMethodRS256, rsaKey, c))
+	assert.Error(t, err, "token without user")
+
+	_, _, err = v.Verify(sign(t, jwt.SigningMethodRS256, otherKey, valid()))
+	assert.Error(t, err, "unknown key")
+
+	// an attacker could try to use the public key as an HMAC secret
+	pub, err := ioutil.ReadFile(filepath.Join(dir, "rsa.pem"))
+	require.NoError(t, err)
+	_, _, err = v.Verify(sign(t, jwt.SigningMethodHS256, pub, valid()))
+	assert.Error(t, err, "HMAC token")
+
+	_, _, err = v.Verify(sign(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, valid()))
+	assert.Error(t, err, "unsigned token")
+}
+
+func TestBearerToken(t *testing.T) {
+	_, ok := bearerToken(context.Background())
+	assert.False(t, ok)
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer abc.def.ghi"))
+	tok, ok := bearerToken(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, "abc.def.ghi", tok)
+
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Basic abc"))
+	_, ok = bearerToken(ctx)
+	assert.False(t, ok)
+}
@@ -0,0 +1,184 @@
+package auditlog
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"git.tu-berlin.de/mcc-fred/fred/pkg/badgerdb"
+	"git.tu-berlin.de/mcc-fred/fred/pkg/fred"
+	"github.com/stretchr/testify/assert"
+)
+
+func entry(t time.Time, user string, m fred.Method, kg string, id string) fred.AuditEntry {
+	return fred.AuditEntry{
+		Time:     t,
+		Node:     "X",
+		User:     user,
+		Method:   m,
+		Keygroup: fred.KeygroupName(kg),
+		ID:       id,
+		Success:  true,
+	}
+}
+
+func testLog(t *testing.T, l fred.AuditLog) {
+	start := time.Now()
+
+	for i := 0; i < 50; i++ {
+		user := "alice"
+		if i%2 == 1 {
+			user = "bob"
+		}
+
+		assert.NoError(t, l.Write(entry(start.Add(time.Duration(i)*time.Millisecond), user, fred.Read, "kg1", "item")))
+	}
+
+	assert.NoError(t, l.Write(entry(start.Add(time.Second), "alice", fred.Update, "kg1", "last")))
+	assert.NoError(t, l.Write(entry(start.Add(time.Second), "alice", fred.Update, "kg2", "other")))
+
+	res, err := l.Query(fred.AuditQuery{Keygroup: "kg1", Limit: 3})
+	assert.NoError(t, err)
+	assert.Len(t, res, 3)
+	assert.Equal(t, "last", res[0].ID)
+	assert.Equal(t, "bob", res[1].User)
+	assert.Equal(t, "alice", res[2].User)
+
+	res, err = l.Query(fred.AuditQuery{Keygroup: "kg1", User: "bob", Limit: 100})
+	assert.NoError(t, err)
+	assert.Len(t, res, 25)
+
+	for _, e := range res {
+		assert.Equal(t, "bob", e.User)
+	}
+
+	res, err = l.Query(fred.AuditQuery{Keygroup: "kg1", Method: fred.Update, Limit: 100})
+	assert.NoError(t, err)
+	assert.Len(t, res, 1)
+	assert.True(t, res[0].Time.Equal(start.Add(time.Second)))
+
+	res, err = l.Query(fred.AuditQuery{Keygroup: "kg3", Limit: 100})
+	assert.NoError(t, err)
+	assert.Len(t, res, 0)
+}
+
+func TestFile(t *testing.T) {
+	dir, err := os.MkdirTemp("", "auditlog")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "audit.log")
+
+	// small enough to rotate a few times, big enough to keep all entries
+	l, err := NewFile(path, 2048, 10)
+	assert.NoError(t, err)
+
+	testLog(t, l)
+
+	_, err = os.Stat(path + ".1")
+	assert.NoError(t, err)
+
+	assert.NoError(t, l.Close())
+}
+
+func TestFileRotation(t *testing.T) {
+	dir, err := os.MkdirTemp("", "auditlog")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "audit.log")
+
+	l, err := NewFile(path, 200, 1)
+	assert.NoError(t, err)
+
+	for i := 0; i < 20; i++ {
+		assert.NoError(t, l.Write(entry(time.Now(), "alice", fred.Read, "kg1", "item")))
+	}
+
+	// old entries are gone once they have been rotated out
+	res, err := l.Query(fred.AuditQuery{Keygroup: "kg1", Limit: 100})
+	assert.NoError(t, err)
+	assert.Less(t, len(res), 20)
+	assert.NotEmpty(t, res)
+
+	_, err = os.Stat(path + ".2")
+	assert.True(t, os.IsNotExist(err))
+
+	assert.NoError(t, l.Close())
+
+	// reopening keeps appending to the same file
+	l, err = NewFile(path, 200, 1)
+	assert.NoError(t, err)
+
+	res2, err := l.Query(fred.AuditQuery{Keygroup: "kg1", Limit: 100})
+	assert.NoError(t, err)
+	assert.Equal(t, len(res), len(res2))
+
+	assert.NoError(t, l.Close())
+}
+
+func TestKeygroup(t *testing.T) {
+	store := badgerdb.NewMemory()
+	defer store.Close()
+
+	l, err := NewKeygroup(store, 0)
+	assert.NoError(t, err)
+
+	testLog(t, l)
+}
+
+// countingStore counts how many items are read from it.
+type countingStore struct {
+	fred.Store
+	read int
+}
+
+func (s *countingStore) ReadSome(kg, id string, count uint64) (map[string]string, error) {
+	items, err := s.Store.ReadSome(kg, id, count)
+	s.read += len(items)
+	return items, err
+}
+
+func (s *countingStore) ReadAll(kg string) (map[string]string, error) {
+	items, err := s.Store.ReadAll(kg)
+	s.read += len(items)
+	return items, err
+}
+
+func TestKeygroupPaging(t *testing.T) {
+	store := &countingStore{Store: badgerdb.NewMemory()}
+	defer store.Close()
+
+	l, err := NewKeygroup(store, 0)
+	assert.NoError(t, err)
+
+	start := time.Now()
+	n := 3*queryPageSize + 10
+
+	for i := 0; i < n; i++ {
+		user := "alice"
+		if i%2 == 1 {
+			user = "bob"
+		}
+
+		assert.NoError(t, l.Write(entry(start.Add(time.Duration(i)*time.Millisecond), user, fred.Read, "kg1", "item")))
+	}
+
+	// a query for the most recent entries only reads the most recent page
+	res, err := l.Query(fred.AuditQuery{Keygroup: "kg1", Limit: 3})
+	assert.NoError(t, err)
+	assert.Len(t, res, 3)
+	assert.True(t, res[0].Time.Equal(start.Add(time.Duration(n-1)*time.Millisecond)))
+	assert.LessOrEqual(t, store.read, queryPageSize)
+
+	// queries that span pages return all matching entries, most recent first
+	res, err = l.Query(fred.AuditQuery{Keygroup: "kg1", User: "bob", Limit: n})
+	assert.NoError(t, err)
+	assert.Len(t, res, n/2)
+
+	for i := 1; i < len(res); i++ {
+		assert.Equal(t, "bob", res[i].User)
+		assert.True(t, res[i].Time.Before(res[i-1].Time))
+	}
+}
@@ -0,0 +1,193 @@
+package auditlog
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"strconv"
+	"sync"
+
+	"git.tu-berlin.de/mcc-fred/fred/pkg/fred"
+	"github.com/go-errors/errors"
+)
+
+// File is an append-only audit log in a local file. Each entry is one line of JSON.
+// Once the file reaches its maximum size, it is rotated: the current file is renamed to path.1, path.1 to path.2, and
+// so on. Only the given number of rotated files is kept, older ones are removed.
+type File struct {
+	sync.Mutex
+	path     string
+	maxSize  int64
+	maxFiles int
+	f        *os.File
+	size     int64
+}
+
+// NewFile opens (or creates) an audit log file at path that is rotated after maxSize bytes, keeping maxFiles old files.
+func NewFile(path string, maxSize int64, maxFiles int) (*File, error) {
+	if maxSize <= 0 {
+		return nil, errors.Errorf("maximum size of audit log must be positive, got %d", maxSize)
+	}
+
+	if maxFiles < 0 {
+		return nil, errors.Errorf("number of rotated audit log files cannot be negative, got %d", maxFiles)
+	}
+
+	l := &File{
+		path:     path,
+		maxSize:  maxSize,
+		maxFiles: maxFiles,
+	}
+
+	if err := l.open(); err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+// open opens the current log file for appending. The caller must hold the lock.
+func (l *File) open() error {
+	f, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+
+	if err != nil {
+		return errors.New(err)
+	}
+
+	info, err := f.Stat()
+
+	if err != nil {
+		_ = f.Close()
+		return errors.New(err)
+	}
+
+	l.f = f
+	l.size = info.Size()
+
+	return nil
+}
+
+// rotated returns the path of the i-th rotated file, where 0 is the current file.
+func (l *File) rotated(i int) string {
+	if i == 0 {
+		return l.path
+	}
+
+	return l.path + "." + strconv.Itoa(i)
+}
+
+// rotate moves all files one position back and starts a new, empty file. The caller must hold the lock.
+func (l *File) rotate() error {
+	if err := l.f.Close(); err != nil {
+		return errors.New(err)
+	}
+
+	if err := os.Remove(l.rotated(l.maxFiles)); err != nil && !os.IsNotExist(err) {
+		return errors.New(err)
+	}
+
+	for i := l.maxFiles - 1; i >= 0; i-- {
+		if err := os.Rename(l.rotated(i), l.rotated(i+1)); err != nil && !os.IsNotExist(err) {
+			return errors.New(err)
+		}
+	}
+
+	return l.open()
+}
+
+// Write appends an entry to the log, rotating the file first if the entry does not fit anymore.
+func (l *File) Write(e fred.AuditEntry) error {
+	b, err := json.Marshal(e)
+
+	if err != nil {
+		return errors.New(err)
+	}
+
+	b = append(b, '\n')
+
+	l.Lock()
+	defer l.Unlock()
+
+	if l.size > 0 && l.size+int64(len(b)) > l.maxSize {
+		if err := l.rotate(); err != nil {
+			return err
+		}
+	}
+
+	n, err := l.f.Write(b)
+	l.size += int64(n)
+
+	if err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+// Query reads the current and all rotated files and returns the most recent entries that match the query.
+func (l *File) Query(q fred.AuditQuery) ([]fred.AuditEntry, error) {
+	l.Lock()
+	defer l.Unlock()
+
+	res := make([]fred.AuditEntry, 0)
+
+	for i := 0; i <= l.maxFiles && len(res) < q.Limit; i++ {
+		lines, err := readLines(l.rotated(i))
+
+		if os.IsNotExist(err) {
+			break
+		}
+
+		if err != nil {
+			return nil, errors.New(err)
+		}
+
+		// newest entries are at the end of each file
+		for j := len(lines) - 1; j >= 0 && len(res) < q.Limit; j-- {
+			var e fred.AuditEntry
+
+			if err := json.Unmarshal(lines[j], &e); err != nil {
+				return nil, errors.Errorf("malformed audit entry in %s: %v", l.rotated(i), err)
+			}
+
+			if q.Matches(e) {
+				res = append(res, e)
+			}
+		}
+	}
+
+	return res, nil
+}
+
+// Close closes the current log file.
+func (l *File) Close() error {
+	l.Lock()
+	defer l.Unlock()
+
+	return l.f.Close()
+}
+
+func readLines(path string) ([][]byte, error) {
+	f, err := os.Open(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	var lines [][]byte
+
+	s := bufio.NewScanner(f)
+	s.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	for s.Scan() {
+		if len(s.Bytes()) == 0 {
+			continue
+		}
+
+		lines = append(lines, append([]byte(nil), s.Bytes()...))
+	}
+
+	return lines, s.Err()
+}
@@ -0,0 +1,119 @@
+package auditlog
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+
+	"git.tu-berlin.de/mcc-fred/fred/pkg/fred"
+	"github.com/go-errors/errors"
+)
+
+// internalKeygroup is the keygroup that audit entries are stored in. Its name is not a valid keygroup name for clients,
+// so they can neither create nor read it through the API.
+const internalKeygroup = "_audit"
+
+// queryPageSize is how many entries a query reads from the internal keygroup at once.
+const queryPageSize = 100
+
+// Keygroup is an audit log that stores entries in an internal keygroup in the local store of the node.
+// Entries are stored with their timestamp as ID so that they are sorted by time.
+type Keygroup struct {
+	sync.Mutex
+	store  fred.Store
+	expiry int
+	last   int64
+}
+
+// NewKeygroup creates an audit log in the given store. If expiry is greater than 0, entries are removed after that many
+// seconds.
+func NewKeygroup(store fred.Store, expiry int) (*Keygroup, error) {
+	if !store.ExistsKeygroup(internalKeygroup) {
+		if err := store.CreateKeygroup(internalKeygroup); err != nil {
+			return nil, errors.New(err)
+		}
+	}
+
+	return &Keygroup{
+		store:  store,
+		expiry: expiry,
+	}, nil
+}
+
+// nextID returns a unique, increasing ID for an entry based on the entry's timestamp.
+func (l *Keygroup) nextID(e fred.AuditEntry) string {
+	l.Lock()
+	defer l.Unlock()
+
+	t := e.Time.UnixNano()
+
+	if t <= l.last {
+		t = l.last + 1
+	}
+
+	l.last = t
+
+	// pad the ID so that sorting IDs as strings also sorts them by time
+	return fmt.Sprintf("%020d", t)
+}
+
+// Write stores an entry in the internal keygroup.
+func (l *Keygroup) Write(e fred.AuditEntry) error {
+	b, err := json.Marshal(e)
+
+	if err != nil {
+		return errors.New(err)
+	}
+
+	return l.store.Update(internalKeygroup, l.nextID(e), string(b), false, l.expiry)
+}
+
+// Query returns the most recent entries that match the query. It only reads the IDs of all entries and then reads the
+// entries themselves page by page from the most recent one backwards, until it has found enough matching entries.
+func (l *Keygroup) Query(q fred.AuditQuery) ([]fred.AuditEntry, error) {
+	ids, err := l.store.IDs(internalKeygroup)
+
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	sort.Strings(ids)
+
+	res := make([]fred.AuditEntry, 0)
+
+	for end := len(ids); end > 0 && len(res) < q.Limit; end -= queryPageSize {
+		start := end - queryPageSize
+
+		if start < 0 {
+			start = 0
+		}
+
+		items, err := l.store.ReadSome(internalKeygroup, ids[start], uint64(end-start))
+
+		if err != nil {
+			return nil, errors.New(err)
+		}
+
+		for i := end - 1; i >= start && len(res) < q.Limit; i-- {
+			val, ok := items[ids[i]]
+
+			// the entry has expired in the meantime
+			if !ok {
+				continue
+			}
+
+			var e fred.AuditEntry
+
+			if err := json.Unmarshal([]byte(val), &e); err != nil {
+				return nil, errors.Errorf("malformed audit entry %s: %v", ids[i], err)
+			}
+
+			if q.Matches(e) {
+				res = append(res, e)
+			}
+		}
+	}
+
+	return res, nil
+}
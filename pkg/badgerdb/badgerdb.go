@@ -2,68 +2,151 @@ package badgerdb
 
 import (
 	"strconv"
-	"strings"
 	"time"
 
+	"git.tu-berlin.de/mcc-fred/fred/pkg/storekey"
 	"github.com/dgraph-io/badger/v3"
 	"github.com/go-errors/errors"
 	"github.com/rs/zerolog/log"
 )
 
-const sep = "|"
 const gcInterval = 5 * time.Minute
 const gcDiscardRatio = 0.7
 
 // Storage is a struct that saves all necessary information to access the database, in this case just a pointer to the BadgerDB database.
 type Storage struct {
-	db  *badger.DB
-	seq map[string]*badger.Sequence
+	db *badger.DB
 }
 
 // makeKeyName creates the internal BadgerDB key given a keygroup name and an id.
 func makeKeyName(kgname string, id string) []byte {
-	return []byte(kgname + sep + id)
+	return []byte(storekey.Item(kgname, id))
 }
 
 // makeKeygroupKeyName creates the internal BadgerDB key given a keygroup name.
 func makeKeygroupKeyName(kgname string) []byte {
-	return []byte(kgname + sep)
+	return []byte(storekey.Keygroup(kgname))
 }
 
 func makeKeygroupConfigKeyName(kgname string) []byte {
-	return []byte(sep + "fred" + sep + "keygroup" + sep + kgname)
+	return []byte(storekey.Meta(storekey.KindKeygroup, kgname, ""))
 }
 
 func makeTriggerConfigKeyName(kgname string, tid string) []byte {
-	return []byte(sep + "fred" + sep + "triggers" + sep + kgname + sep + tid)
+	return []byte(storekey.Meta(storekey.KindTriggers, kgname, tid))
 }
 
 func makeLogConfigKeyName(kgname string) []byte {
-	return []byte(sep + "fred" + sep + "rolling" + sep + kgname)
+	return []byte(storekey.Meta(storekey.KindRolling, kgname, ""))
 }
 
 // getTriggerConfigKey returns the keygroup and id of a key.
 func getTriggerConfigKey(key string) (kg, tid string) {
-	s := strings.Split(key, sep)
-
-	if len(s) == len([]string{"nil", "fred", "triggers", "keygroup", "trigger id"}) {
-		kg = s[3]
-		tid = s[4]
-	}
-
+	kg, tid, _ = storekey.ParseMeta(storekey.KindTriggers, key)
 	return
 }
 
 // getKey returns the keygroup and id of a key.
 func getKey(key string) (kg, id string) {
-	s := strings.Split(key, sep)
-	kg = s[0]
+	kg, id, _ = storekey.ParseItem(key)
+	return
+}
+
+// migrate brings the keys of a database to the current format of the storekey package. Databases without a version
+// are from before keys were encoded, so all their keys are rewritten. Items keep their expiry.
+func migrate(db *badger.DB) error {
+	var version uint64
+
+	err := db.View(func(txn *badger.Txn) error {
+		item, err := txn.Get([]byte(storekey.VersionKey))
+
+		if errors.Is(err, badger.ErrKeyNotFound) {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		v, err := item.ValueCopy(nil)
+
+		if err != nil {
+			return err
+		}
+
+		version, err = strconv.ParseUint(string(v), 10, 64)
+
+		return err
+	})
+
+	if err != nil {
+		return errors.New(err)
+	}
 
-	if len(s) == len([]string{"keygroup", "identifier"}) {
-		id = s[1]
+	if version == storekey.Version {
+		return nil
 	}
 
-	return
+	if version > storekey.Version {
+		return errors.Errorf("database has key format version %d but only version %d is supported, it was written by a newer version of FReD", version, storekey.Version)
+	}
+
+	wb := db.NewWriteBatch()
+	defer wb.Cancel()
+
+	migrated := 0
+
+	err = db.View(func(txn *badger.Txn) error {
+		it := txn.NewIterator(badger.DefaultIteratorOptions)
+		defer it.Close()
+
+		for it.Rewind(); it.Valid(); it.Next() {
+			item := it.Item()
+
+			key, ok := storekey.Migrate(string(item.Key()), false)
+
+			if !ok {
+				continue
+			}
+
+			v, err := item.ValueCopy(nil)
+
+			if err != nil {
+				return err
+			}
+
+			if err := wb.SetEntry(&badger.Entry{Key: []byte(key), Value: v, ExpiresAt: item.ExpiresAt()}); err != nil {
+				return err
+			}
+
+			if err := wb.Delete(item.KeyCopy(nil)); err != nil {
+				return err
+			}
+
+			migrated++
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return errors.New(err)
+	}
+
+	// the version is written last, so that an interrupted migration is continued on the next start
+	if err := wb.Set([]byte(storekey.VersionKey), []byte(strconv.Itoa(storekey.Version))); err != nil {
+		return errors.New(err)
+	}
+
+	if err := wb.Flush(); err != nil {
+		return errors.New(err)
+	}
+
+	if migrated > 0 {
+		log.Info().Msgf("BadgerDB: migrated %d keys to key format version %d", migrated, storekey.Version)
+	}
+
+	return nil
 }
 
 // garbageCollection manages triggering garbage collection for the BadgerDB database. For now, we stick to a schedule.
@@ -86,9 +169,12 @@ func New(dbPath string) (s *Storage) {
 		panic(err)
 	}
 
+	if err := migrate(db); err != nil {
+		panic(err)
+	}
+
 	s = &Storage{
-		db:  db,
-		seq: make(map[string]*badger.Sequence),
+		db: db,
 	}
 
 	go garbageCollection(db)
@@ -103,9 +189,12 @@ func NewMemory() (s *Storage) {
 		panic(err)
 	}
 
+	if err := migrate(db); err != nil {
+		panic(err)
+	}
+
 	s = &Storage{
-		db:  db,
-		seq: make(map[string]*badger.Sequence),
+		db: db,
 	}
 
 	go garbageCollection(db)
@@ -232,6 +321,44 @@ func (s *Storage) ReadAll(kg string) (map[string]string, error) {
 	return items, nil
 }
 
+// Export calls fn for every item in the specified keygroup with the time its expiry passes as a Unix timestamp, or 0
+// if it does not expire. All items are read from the same snapshot of the database, so concurrent writes are either
+// fully part of the export or not at all.
+func (s *Storage) Export(kg string, fn func(id string, val string, expiresAt int64) error) error {
+	err := s.db.View(func(txn *badger.Txn) error {
+		prefix := makeKeygroupKeyName(kg)
+
+		opts := badger.DefaultIteratorOptions
+		opts.Prefix = prefix
+
+		it := txn.NewIterator(opts)
+		defer it.Close()
+
+		for it.Seek(prefix); it.ValidForPrefix(prefix); it.Next() {
+			item := it.Item()
+			_, key := getKey(string(item.Key()))
+
+			v, err := item.ValueCopy(nil)
+
+			if err != nil {
+				return err
+			}
+
+			if err := fn(key, string(v), int64(item.ExpiresAt())); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
 // IDs returns the keys of all items in the specified keygroup.
 func (s *Storage) IDs(kg string) ([]string, error) {
 	var items []string
@@ -263,21 +390,7 @@ func (s *Storage) IDs(kg string) ([]string, error) {
 }
 
 // Update updates the item with the specified id in the specified keygroup.
-func (s *Storage) Update(kg, id, val string, append bool, expiry int) error {
-
-	if append {
-		// make sure that we have our local sequence on point
-		key, err := strconv.Atoi(id)
-		if err != nil {
-			return errors.New(err)
-		}
-		for n, err := s.incrementSequence(kg); n < uint64(key); n, err = s.incrementSequence(kg) {
-			if err != nil {
-				return errors.New(err)
-			}
-		}
-	}
-
+func (s *Storage) Update(kg, id, val string, _ bool, expiry int) error {
 	err := s.db.Update(func(txn *badger.Txn) error {
 		key := makeKeyName(kg, id)
 
@@ -330,72 +443,38 @@ func (s *Storage) Delete(kg string, id string) error {
 	return nil
 }
 
-// Append appends the item to the specified keygroup by incrementing the latest key by one.
-func (s *Storage) Append(kg, val string, expiry int) (string, error) {
-	// first, get the latest key
-	// maximum of 18446744073709551615, though!
-	// if you reach this maximum, please send me a letter
-
-	n, err := s.incrementSequence(kg)
+// Append adds a new item with the specified id to the specified keygroup. It fails if the item already exists.
+func (s *Storage) Append(kg, id, val string, expiry int) error {
+	err := s.db.Update(func(txn *badger.Txn) error {
+		key := makeKeyName(kg, id)
 
-	if err != nil {
-		return "", errors.New(err)
-	}
+		// reading the key first makes concurrent appends with the same id conflict
+		_, err := txn.Get(key)
 
-	id := strconv.FormatUint(n, 10)
-	log.Debug().Msgf("append got next ID: %s", id)
+		if err == nil {
+			return errors.Errorf("item %s already exists in keygroup %s", id, kg)
+		}
 
-	err = s.db.Update(func(txn *badger.Txn) error {
-		key := makeKeyName(kg, id)
+		if !errors.Is(err, badger.ErrKeyNotFound) {
+			return err
+		}
 
 		if expiry > 0 {
-			err := txn.SetEntry(&badger.Entry{
+			return txn.SetEntry(&badger.Entry{
 				Key:       key,
 				Value:     []byte(val),
 				ExpiresAt: uint64(time.Now().Unix()) + uint64(expiry),
 			})
-			if err != nil {
-				return errors.New(err)
-			}
-			return nil
 		}
 
-		err := txn.Set(key, []byte(val))
-
-		if err != nil {
-			return errors.New(err)
-		}
-		return nil
+		return txn.Set(key, []byte(val))
 	})
 
 	if err != nil {
-		return "", errors.New(err)
-	}
-
-	return id, nil
-}
-
-func (s *Storage) incrementSequence(kg string) (uint64, error) {
-	seq, ok := s.seq[kg]
-
-	if !ok {
-		newSeq, err := s.db.GetSequence(makeLogConfigKeyName(kg), 100)
-
-		if err != nil {
-			return 0, errors.New(err)
-		}
-
-		s.seq[kg] = newSeq
-		seq = newSeq
-	}
-
-	n, err := seq.Next()
-
-	if err != nil {
-		return 0, errors.New(err)
+		return errors.New(err)
 	}
 
-	return n, nil
+	return nil
 }
 
 // Exists checks if the given data item exists in the badgerdb database.
@@ -436,6 +515,40 @@ func (s *Storage) ExistsKeygroup(kg string) bool {
 	return false
 }
 
+// Keygroups returns the names of all keygroups in the badgerdb database.
+func (s *Storage) Keygroups() ([]string, error) {
+	var kgs []string
+
+	err := s.db.View(func(txn *badger.Txn) error {
+		prefix := []byte(storekey.MetaPrefix(storekey.KindKeygroup))
+
+		opts := badger.DefaultIteratorOptions
+		opts.Prefix = prefix
+		opts.PrefetchValues = false
+
+		it := txn.NewIterator(opts)
+		defer it.Close()
+
+		for it.Seek(prefix); it.ValidForPrefix(prefix); it.Next() {
+			kg, _, ok := storekey.ParseMeta(storekey.KindKeygroup, string(it.Item().Key()))
+
+			if !ok {
+				continue
+			}
+
+			kgs = append(kgs, kg)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	return kgs, nil
+}
+
 // CreateKeygroup creates the given keygroup in the badgerdb database.
 func (s *Storage) CreateKeygroup(kg string) error {
 	err := s.db.Update(func(txn *badger.Txn) error {
@@ -444,12 +557,6 @@ func (s *Storage) CreateKeygroup(kg string) error {
 			return err
 		}
 
-		s.seq[kg], err = s.db.GetSequence(makeLogConfigKeyName(kg), 100)
-
-		if err != nil {
-			return err
-		}
-
 		return nil
 	})
 
@@ -467,6 +574,12 @@ func (s *Storage) DeleteKeygroup(kg string) error {
 		if err != nil {
 			return err
 		}
+
+		// databases from older versions may still have a sequence for appends
+		err = txn.Delete(makeLogConfigKeyName(kg))
+		if err != nil {
+			return err
+		}
 		return nil
 	})
 
@@ -556,16 +669,6 @@ func (s *Storage) DeleteKeygroup(kg string) error {
 		return errors.New(err)
 	}
 
-	if seq, ok := s.seq[kg]; ok {
-		err = seq.Release()
-
-		if err != nil {
-			return err
-		}
-
-		delete(s.seq, kg)
-	}
-
 	return nil
 }
 
@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/dgraph-io/badger/v3"
 	"github.com/go-errors/errors"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
@@ -68,6 +69,10 @@ func TestKeygroups(t *testing.T) {
 		t.Fatal("Keygroup does not exist after creation")
 	}
 
+	kgs, err := db.Keygroups()
+	assert.NoError(t, err)
+	assert.Contains(t, kgs, kg)
+
 	err = db.DeleteKeygroup(kg)
 
 	if err != nil {
@@ -79,6 +84,9 @@ func TestKeygroups(t *testing.T) {
 		t.Fatal("Keygroup does still exist after deletion")
 	}
 
+	kgs, err = db.Keygroups()
+	assert.NoError(t, err)
+	assert.NotContains(t, kgs, kg)
 }
 
 func TestReadSome(t *testing.T) {
@@ -447,8 +455,6 @@ func TestExpiry(t *testing.T) {
 
 func TestAppend(t *testing.T) {
 	kg := "log"
-	v1 := "value-1"
-	v2 := "value-2"
 
 	err := db.CreateKeygroup(kg)
 
@@ -457,36 +463,28 @@ func TestAppend(t *testing.T) {
 		t.Error(err)
 	}
 
-	key1, err := db.Append(kg, v1, 0)
-
-	if err != nil {
-		t.Error(err)
-	}
-	if key1 != "0" {
-		t.Errorf("Expected to get %s but got %s", "0", key1)
-	}
-
-	key2, err := db.Append(kg, v2, 0)
-
-	if err != nil {
-		t.Error(err)
-	}
-	if key2 != "1" {
-		t.Errorf("Expected to get %s but got %s", "0", key2)
-	}
-
-	for i := 2; i < 100; i++ {
+	for i := 0; i < 100; i++ {
+		id := fmt.Sprintf("%020d-nodeA", i)
 		v := "value-" + strconv.Itoa(i)
-		key, err := db.Append(kg, v, 0)
+
+		err := db.Append(kg, id, v, 0)
 
 		if err != nil {
 			t.Error(err)
 		}
 
-		if key != strconv.Itoa(i) {
-			t.Errorf("Expected to get %s but got %s", strconv.Itoa(i), key)
-		}
+		val, err := db.Read(kg, id)
+		assert.NoError(t, err)
+		assert.Equal(t, v, val)
 	}
+
+	// appended items cannot be overwritten by another append
+	err = db.Append(kg, fmt.Sprintf("%020d-nodeA", 0), "value-new", 0)
+	assert.Error(t, err)
+
+	val, err := db.Read(kg, fmt.Sprintf("%020d-nodeA", 0))
+	assert.NoError(t, err)
+	assert.Equal(t, "value-0", val)
 }
 
 func TestConcurrentAppend(t *testing.T) {
@@ -501,41 +499,34 @@ func TestConcurrentAppend(t *testing.T) {
 		t.Error(err)
 	}
 
-	keys := make([]map[string]struct{}, concurrent)
+	// all goroutines try to append the same ids, but each id may only be appended once
+	succeeded := make([]int, concurrent)
 	done := make(chan struct{})
 
 	for i := 0; i < concurrent; i++ {
-		keys[i] = make(map[string]struct{})
-		go func(id int, keys *map[string]struct{}) {
-			for j := 2; j < items; j++ {
+		go func(id int) {
+			for j := 0; j < items; j++ {
 				v := fmt.Sprintf("value-%d-%d", id, j)
-				key, err := db.Append(kg, v, 0)
 
-				if err != nil {
-					t.Error(err.(*errors.Error).ErrorStack())
+				if err := db.Append(kg, strconv.Itoa(j), v, 0); err == nil {
+					succeeded[id]++
 				}
-
-				(*keys)[key] = struct{}{}
 			}
 			done <- struct{}{}
-		}(i, &keys[i])
+		}(i)
 	}
 
 	for i := 0; i < concurrent; i++ {
 		<-done
 	}
 
-	for i, k := range keys {
-		for key := range k {
-			found := false
-			for j := i + 1; j < concurrent; j++ {
-				_, ok := keys[j][key]
-				found = found || ok
-			}
-			assert.False(t, found, "key given out multiple times")
-		}
+	total := 0
+
+	for _, n := range succeeded {
+		total += n
 	}
 
+	assert.Equal(t, items, total)
 }
 
 func TestTriggerNodes(t *testing.T) {
@@ -650,6 +641,75 @@ func TestTriggerNodes(t *testing.T) {
 
 }
 
+func TestArbitraryIDs(t *testing.T) {
+	kg := "test-kg|ids"
+	other := "test-kg"
+
+	assert.NoError(t, db.CreateKeygroup(kg))
+	assert.NoError(t, db.CreateKeygroup(other))
+
+	ids := []string{"a|b", "alice@example.com", "/home/alice", "123e4567-e89b-12d3-a456-426614174000", "ünïcödé ✓", "|"}
+
+	for _, id := range ids {
+		assert.NoError(t, db.Update(kg, id, "val "+id, false, 0))
+	}
+
+	assert.NoError(t, db.Update(other, "ids|a|b", "other", false, 0))
+	assert.NoError(t, db.AddKeygroupTrigger(kg, "t|1", "host"))
+
+	for _, id := range ids {
+		assert.True(t, db.Exists(kg, id))
+
+		val, err := db.Read(kg, id)
+		assert.NoError(t, err)
+		assert.Equal(t, "val "+id, val)
+	}
+
+	all, err := db.ReadAll(kg)
+	assert.NoError(t, err)
+	assert.Len(t, all, len(ids))
+
+	some, err := db.ReadSome(kg, "a", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"a|b": "val a|b", "alice@example.com": "val alice@example.com"}, some)
+
+	triggers, err := db.GetKeygroupTrigger(kg)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"t|1": "host"}, triggers)
+
+	// deleting one keygroup leaves the other alone
+	assert.NoError(t, db.DeleteKeygroup(kg))
+	assert.False(t, db.Exists(kg, "a|b"))
+	assert.True(t, db.Exists(other, "ids|a|b"))
+}
+
+func TestExport(t *testing.T) {
+	kg := "test-export"
+
+	assert.NoError(t, db.CreateKeygroup(kg))
+	assert.NoError(t, db.Update(kg, "forever", "1", false, 0))
+	assert.NoError(t, db.Update(kg, "expiring", "2", false, 100))
+
+	exported := make(map[string]int64)
+
+	err := db.Export(kg, func(id string, val string, expiresAt int64) error {
+		exported[id] = expiresAt
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, exported, 2)
+	assert.Equal(t, int64(0), exported["forever"])
+	assert.InDelta(t, time.Now().Unix()+100, exported["expiring"], 5)
+
+	// errors stop the export
+	err = db.Export(kg, func(id string, val string, expiresAt int64) error {
+		return errors.New("stop")
+	})
+
+	assert.Error(t, err)
+}
+
 func TestClose(t *testing.T) {
 	kg := "test-kg-item"
 	id := "name"
@@ -687,3 +747,62 @@ func TestClose(t *testing.T) {
 	assert.Error(t, err)
 
 }
+
+func TestMigrate(t *testing.T) {
+	path := t.TempDir()
+
+	old, err := badger.Open(badger.DefaultOptions(path))
+	assert.NoError(t, err)
+
+	// this is how keys were stored before they were encoded
+	assert.NoError(t, old.Update(func(txn *badger.Txn) error {
+		for k, v := range map[string]string{
+			"|fred|keygroup|kg":    "kg",
+			"|fred|triggers|kg|t1": "host1",
+			"kg|id1":               "val1",
+			"kg|id2":               "val2",
+		} {
+			if err := txn.Set([]byte(k), []byte(v)); err != nil {
+				return err
+			}
+		}
+
+		return txn.SetEntry(&badger.Entry{Key: []byte("kg|expires"), Value: []byte("val3"), ExpiresAt: uint64(time.Now().Unix()) + 5})
+	}))
+
+	seq, err := old.GetSequence([]byte("|fred|rolling|kg"), 100)
+	assert.NoError(t, err)
+
+	for i := 0; i < 5; i++ {
+		_, err = seq.Next()
+		assert.NoError(t, err)
+	}
+
+	assert.NoError(t, seq.Release())
+	assert.NoError(t, old.Close())
+
+	// migrating twice does not change anything
+	for i := 0; i < 2; i++ {
+		s := New(path)
+
+		assert.True(t, s.ExistsKeygroup("kg"))
+
+		all, err := s.ReadAll("kg")
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"id1": "val1", "id2": "val2", "expires": "val3"}, all)
+
+		triggers, err := s.GetKeygroupTrigger("kg")
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"t1": "host1"}, triggers)
+
+		assert.NoError(t, s.Close())
+	}
+
+	s := New(path)
+
+	// the expiry is kept
+	time.Sleep(6 * time.Second)
+	assert.False(t, s.Exists("kg", "expires"))
+
+	assert.NoError(t, s.Close())
+}
@@ -0,0 +1,80 @@
+package certs
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/rs/zerolog/log"
+)
+
+// parseCertificates parses all certificates in a PEM file.
+func parseCertificates(data []byte) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+
+		if block == nil {
+			break
+		}
+
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		c, err := x509.ParseCertificate(block.Bytes)
+
+		if err != nil {
+			return nil, errors.New(err)
+		}
+
+		certs = append(certs, c)
+	}
+
+	if len(certs) == 0 {
+		return nil, errors.Errorf("no certificates found")
+	}
+
+	return certs, nil
+}
+
+// parseCRL parses a CRL in PEM or DER format and returns the serial numbers of the revoked certificates.
+// A CRL that is not signed by one of our CAs is ignored: it cannot be trusted, and it is about certificates that we
+// would not accept anyway.
+func parseCRL(data []byte, cas []*x509.Certificate) ([]string, error) {
+	crl, err := x509.ParseCRL(data)
+
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	var issuer *x509.Certificate
+
+	for _, ca := range cas {
+		if ca.CheckCRLSignature(crl) == nil {
+			issuer = ca
+			break
+		}
+	}
+
+	if issuer == nil {
+		log.Warn().Msgf("ignoring CRL by %s as it is not signed by any of our CA certificates", crl.TBSCertList.Issuer.String())
+		return nil, nil
+	}
+
+	// an old CRL is still better than no CRL, but someone should update it
+	if crl.HasExpired(time.Now()) {
+		log.Warn().Msgf("CRL by %s should have been updated at %s", issuer.Subject.CommonName, crl.TBSCertList.NextUpdate)
+	}
+
+	serials := make([]string, 0, len(crl.TBSCertList.RevokedCertificates))
+
+	for _, r := range crl.TBSCertList.RevokedCertificates {
+		serials = append(serials, SerialString(r.SerialNumber))
+	}
+
+	return serials, nil
+}
@@ -0,0 +1,400 @@
+// Package certs provides TLS configurations for all servers and clients of FReD. The certificates, keys, CA
+// certificates, and certificate revocation lists are watched and reloaded when they change, so that certificates can
+// be rotated and revoked without restarting anything. Connections that are already open are kept.
+package certs
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/rs/zerolog/log"
+)
+
+// DenyList is a list of revoked certificates that is kept outside of a CRL, e.g., in the NameService.
+type DenyList interface {
+	// Returns: serial numbers of revoked certificates in hex
+	GetRevokedCertificates() ([]string, error)
+}
+
+// Provider loads a certificate, a key, CA certificates, and optional CRLs from files and keeps them up to date.
+type Provider struct {
+	certFile string
+	keyFile  string
+	caFiles  []string
+	crlFiles []string
+	deny     DenyList
+
+	sync.RWMutex
+	cert        *tls.Certificate
+	roots       *x509.CertPool
+	systemRoots *x509.CertPool
+	revoked     map[string]struct{}
+	denied      map[string]struct{}
+	modTimes    map[string]time.Time
+
+	done chan struct{}
+}
+
+// NewProvider loads the given files. If any of them cannot be loaded, an error is returned.
+// CRLs that are not signed by one of the CA certificates are ignored.
+func NewProvider(certFile string, keyFile string, caFiles []string, crlFiles []string) (*Provider, error) {
+	p := &Provider{
+		certFile: certFile,
+		keyFile:  keyFile,
+		caFiles:  nonEmpty(caFiles),
+		crlFiles: nonEmpty(crlFiles),
+		denied:   make(map[string]struct{}),
+		done:     make(chan struct{}),
+	}
+
+	if err := p.load(); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// nonEmpty removes empty file names, e.g., from splitting an empty flag.
+func nonEmpty(files []string) []string {
+	res := make([]string, 0, len(files))
+
+	for _, f := range files {
+		if f != "" {
+			res = append(res, f)
+		}
+	}
+
+	return res
+}
+
+// SerialString returns the hex representation of a serial number that is used for revoked certificates.
+func SerialString(serial *big.Int) string {
+	return serial.Text(16)
+}
+
+// normalizeSerial brings serial numbers in the format of SerialString, no matter if they are upper or lower case or
+// separated by colons, as printed by openssl.
+func normalizeSerial(s string) string {
+	s = strings.TrimLeft(strings.ToLower(strings.ReplaceAll(s, ":", "")), "0")
+
+	if s == "" {
+		return "0"
+	}
+
+	return s
+}
+
+// SetDenyList adds a deny list that is checked in addition to the CRLs. It is loaded right away and then updated
+// together with the files.
+func (p *Provider) SetDenyList(d DenyList) error {
+	p.Lock()
+	p.deny = d
+	p.Unlock()
+
+	return p.loadDenyList()
+}
+
+// Watch checks the files for changes in the given interval and reloads them if necessary until Close is called.
+func (p *Provider) Watch(interval time.Duration) {
+	go func() {
+		t := time.NewTicker(interval)
+		defer t.Stop()
+
+		for {
+			select {
+			case <-p.done:
+				return
+			case <-t.C:
+				p.reload()
+			}
+		}
+	}()
+}
+
+// Close stops watching the files.
+func (p *Provider) Close() {
+	close(p.done)
+}
+
+// reload reloads the files if any of them have changed and updates the deny list. If anything goes wrong, the old
+// configuration is kept, as the files might just be in the middle of being replaced.
+func (p *Provider) reload() {
+	if p.changed() {
+		if err := p.load(); err != nil {
+			log.Err(err).Msgf("could not reload TLS configuration from %s, keeping the old one", p.certFile)
+		} else {
+			log.Info().Msgf("reloaded TLS configuration from %s", p.certFile)
+		}
+	}
+
+	if err := p.loadDenyList(); err != nil {
+		log.Err(err).Msg("could not update list of revoked certificates, keeping the old one")
+	}
+}
+
+func (p *Provider) files() []string {
+	files := append([]string{p.certFile, p.keyFile}, p.caFiles...)
+	return append(files, p.crlFiles...)
+}
+
+func modTimes(files []string) (map[string]time.Time, error) {
+	m := make(map[string]time.Time, len(files))
+
+	for _, f := range files {
+		info, err := os.Stat(f)
+
+		if err != nil {
+			return nil, errors.New(err)
+		}
+
+		m[f] = info.ModTime()
+	}
+
+	return m, nil
+}
+
+// changed checks whether any of the files has a different modification time than when we loaded it.
+func (p *Provider) changed() bool {
+	m, err := modTimes(p.files())
+
+	if err != nil {
+		log.Err(err).Msg("could not check TLS files for changes")
+		return false
+	}
+
+	p.RLock()
+	defer p.RUnlock()
+
+	for f, t := range m {
+		if !t.Equal(p.modTimes[f]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p *Provider) load() error {
+	// get the modification times first, so that we load the file again if it changes while we read it
+	m, err := modTimes(p.files())
+
+	if err != nil {
+		return err
+	}
+
+	cert, err := tls.LoadX509KeyPair(p.certFile, p.keyFile)
+
+	if err != nil {
+		return errors.Errorf("could not load key pair: %v", err)
+	}
+
+	roots := x509.NewCertPool()
+	systemRoots, err := x509.SystemCertPool()
+
+	if err != nil {
+		return errors.Errorf("could not load system root certificates: %v", err)
+	}
+
+	var cas []*x509.Certificate
+
+	for _, f := range p.caFiles {
+		loaded, err := ioutil.ReadFile(f)
+
+		if err != nil {
+			return errors.Errorf("could not load CA certificate: %v", err)
+		}
+
+		c, err := parseCertificates(loaded)
+
+		if err != nil {
+			return errors.Errorf("could not parse CA certificate %s: %v", f, err)
+		}
+
+		for _, ca := range c {
+			roots.AddCert(ca)
+			systemRoots.AddCert(ca)
+		}
+
+		cas = append(cas, c...)
+	}
+
+	revoked := make(map[string]struct{})
+
+	for _, f := range p.crlFiles {
+		loaded, err := ioutil.ReadFile(f)
+
+		if err != nil {
+			return errors.Errorf("could not load CRL: %v", err)
+		}
+
+		serials, err := parseCRL(loaded, cas)
+
+		if err != nil {
+			return errors.Errorf("could not parse CRL %s: %v", f, err)
+		}
+
+		for _, s := range serials {
+			revoked[s] = struct{}{}
+		}
+	}
+
+	p.Lock()
+	defer p.Unlock()
+
+	p.cert = &cert
+	p.roots = roots
+	p.systemRoots = systemRoots
+	p.revoked = revoked
+	p.modTimes = m
+
+	return nil
+}
+
+func (p *Provider) loadDenyList() error {
+	p.RLock()
+	d := p.deny
+	p.RUnlock()
+
+	if d == nil {
+		return nil
+	}
+
+	serials, err := d.GetRevokedCertificates()
+
+	if err != nil {
+		return errors.New(err)
+	}
+
+	denied := make(map[string]struct{}, len(serials))
+
+	for _, s := range serials {
+		denied[normalizeSerial(s)] = struct{}{}
+	}
+
+	p.Lock()
+	p.denied = denied
+	p.Unlock()
+
+	return nil
+}
+
+// Roots returns the current pool of our CA certificates.
+func (p *Provider) Roots() *x509.CertPool {
+	p.RLock()
+	defer p.RUnlock()
+
+	return p.roots
+}
+
+// CheckRevoked returns an error if a certificate is in one of the CRLs or on the deny list.
+func (p *Provider) CheckRevoked(c *x509.Certificate) error {
+	s := SerialString(c.SerialNumber)
+
+	p.RLock()
+	defer p.RUnlock()
+
+	if _, ok := p.revoked[s]; ok {
+		return errors.Errorf("certificate %s for %s has been revoked", s, c.Subject.CommonName)
+	}
+
+	if _, ok := p.denied[s]; ok {
+		return errors.Errorf("certificate %s for %s has been revoked", s, c.Subject.CommonName)
+	}
+
+	return nil
+}
+
+// verifyClient rejects revoked client certificates during the handshake.
+func (p *Provider) verifyClient(_ [][]byte, verifiedChains [][]*x509.Certificate) error {
+	for _, chain := range verifiedChains {
+		if len(chain) > 0 {
+			if err := p.CheckRevoked(chain[0]); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// verifyServer verifies a server certificate against our current roots, as tls.Config.RootCAs cannot be changed once
+// a connection is set up. It does the same checks as crypto/tls would do and checks for revoked certificates.
+func (p *Provider) verifyServer(systemRoots bool) func(cs tls.ConnectionState) error {
+	return func(cs tls.ConnectionState) error {
+		if len(cs.PeerCertificates) == 0 {
+			return errors.Errorf("server did not send a certificate")
+		}
+
+		intermediates := x509.NewCertPool()
+
+		for _, c := range cs.PeerCertificates[1:] {
+			intermediates.AddCert(c)
+		}
+
+		p.RLock()
+		roots := p.roots
+		if systemRoots {
+			roots = p.systemRoots
+		}
+		p.RUnlock()
+
+		_, err := cs.PeerCertificates[0].Verify(x509.VerifyOptions{
+			Roots:         roots,
+			CurrentTime:   time.Now(),
+			DNSName:       cs.ServerName,
+			Intermediates: intermediates,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		return p.CheckRevoked(cs.PeerCertificates[0])
+	}
+}
+
+// ServerConfig returns a TLS configuration for a server. Every handshake uses the current certificate and CA
+// certificates.
+func (p *Provider) ServerConfig(clientAuth tls.ClientAuthType) *tls.Config {
+	return &tls.Config{
+		ClientAuth: clientAuth,
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			p.RLock()
+			defer p.RUnlock()
+
+			return &tls.Config{
+				Certificates:          []tls.Certificate{*p.cert},
+				ClientAuth:            clientAuth,
+				ClientCAs:             p.roots,
+				MinVersion:            tls.VersionTLS12,
+				NextProtos:            []string{"h2"},
+				VerifyPeerCertificate: p.verifyClient,
+			}, nil
+		},
+	}
+}
+
+// ClientConfig returns a TLS configuration for a client. Every handshake uses the current certificate and CA
+// certificates. If systemRoots is set, servers may also have certificates from the system's CAs.
+func (p *Provider) ClientConfig(systemRoots bool) *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			p.RLock()
+			defer p.RUnlock()
+
+			return p.cert, nil
+		},
+		// the server certificate is still verified, but in verifyServer, so that we can use the current roots
+		InsecureSkipVerify: true, // #nosec G402
+		VerifyConnection:   p.verifyServer(systemRoots),
+	}
+}
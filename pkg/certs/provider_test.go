@@ -0,0 +1,273 @@
+package certs
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	file string
+}
+
+func newTestCA(t *testing.T, dir string, name string) *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	f := filepath.Join(dir, name+".crt")
+	require.NoError(t, ioutil.WriteFile(f, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+
+	return &testCA{cert: cert, key: key, file: f}
+}
+
+// issue writes a certificate and key for localhost with the given serial number and returns the file names.
+func (ca *testCA) issue(t *testing.T, dir string, name string, serial int64) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		DNSNames:     []string{"localhost"},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+
+	k, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile := filepath.Join(dir, name+".crt")
+	keyFile := filepath.Join(dir, name+".key")
+
+	require.NoError(t, ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	require.NoError(t, ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: k}), 0600))
+
+	return certFile, keyFile
+}
+
+// revoke writes a CRL with the given serial numbers and returns the file name.
+func (ca *testCA) revoke(t *testing.T, dir string, serials ...int64) string {
+	revoked := make([]pkix.RevokedCertificate, 0, len(serials))
+
+	for _, s := range serials {
+		revoked = append(revoked, pkix.RevokedCertificate{SerialNumber: big.NewInt(s), RevocationTime: time.Now()})
+	}
+
+	der, err := x509.CreateRevocationList(rand.Reader, &x509.RevocationList{
+		Number:              big.NewInt(time.Now().UnixNano()),
+		ThisUpdate:          time.Now(),
+		NextUpdate:          time.Now().Add(time.Hour),
+		RevokedCertificates: revoked,
+	}, ca.cert, ca.key)
+	require.NoError(t, err)
+
+	f := filepath.Join(dir, ca.cert.Subject.CommonName+".crl")
+	require.NoError(t, ioutil.WriteFile(f, pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: der}), 0600))
+
+	return f
+}
+
+// touch makes sure that a file looks changed, even if the file system has a coarse modification time.
+func touch(t *testing.T, f string) {
+	later := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(f, later, later))
+}
+
+// handshake connects a client to a server and returns the error of the client and the server.
+func handshake(t *testing.T, server *tls.Config, client *tls.Config) (error, error) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer lis.Close()
+
+	errs := make(chan error, 1)
+
+	go func() {
+		s, err := lis.Accept()
+
+		if err != nil {
+			errs <- err
+			return
+		}
+
+		defer s.Close()
+
+		conn := tls.Server(s, server)
+		err = conn.Handshake()
+
+		// with TLS 1.3, the server only checks the client certificate after the client is done with the handshake
+		if err == nil {
+			_, err = conn.Read(make([]byte, 1))
+		}
+
+		errs <- err
+	}()
+
+	client = client.Clone()
+	client.ServerName = "localhost"
+
+	c, err := tls.Dial("tcp", lis.Addr().String(), client)
+
+	if err != nil {
+		return err, <-errs
+	}
+
+	_, cerr := c.Write([]byte{1})
+
+	if cerr == nil {
+		_, cerr = c.Read(make([]byte, 1))
+		// the server closes the connection after reading our byte, anything else means it rejected us
+		if cerr == io.EOF {
+			cerr = nil
+		}
+	}
+
+	c.Close()
+
+	return cerr, <-errs
+}
+
+type denyList []string
+
+func (d denyList) GetRevokedCertificates() ([]string, error) {
+	return d, nil
+}
+
+func TestRevocation(t *testing.T) {
+	dir := t.TempDir()
+
+	ca := newTestCA(t, dir, "ca")
+	other := newTestCA(t, dir, "other")
+
+	serverCert, serverKey := ca.issue(t, dir, "server", 10)
+	clientCert, clientKey := ca.issue(t, dir, "client", 11)
+
+	// a CRL of another CA should not affect us
+	server, err := NewProvider(serverCert, serverKey, []string{ca.file}, []string{other.revoke(t, dir, 11)})
+	require.NoError(t, err)
+
+	client, err := NewProvider(clientCert, clientKey, []string{ca.file}, nil)
+	require.NoError(t, err)
+
+	cerr, serr := handshake(t, server.ServerConfig(tls.RequireAndVerifyClientCert), client.ClientConfig(false))
+	assert.NoError(t, cerr)
+	assert.NoError(t, serr)
+
+	crl := ca.revoke(t, dir, 11)
+	server, err = NewProvider(serverCert, serverKey, []string{ca.file}, []string{crl})
+	require.NoError(t, err)
+
+	_, serr = handshake(t, server.ServerConfig(tls.RequireAndVerifyClientCert), client.ClientConfig(false))
+	assert.Error(t, serr)
+
+	// the client should also reject a revoked server
+	client, err = NewProvider(clientCert, clientKey, []string{ca.file}, []string{ca.revoke(t, dir, 10)})
+	require.NoError(t, err)
+
+	cerr, _ = handshake(t, server.ServerConfig(tls.VerifyClientCertIfGiven), client.ClientConfig(false))
+	assert.Error(t, cerr)
+
+	// serial numbers in the deny list can be written like openssl prints them
+	server, err = NewProvider(serverCert, serverKey, []string{ca.file}, nil)
+	require.NoError(t, err)
+
+	c, err := tls.LoadX509KeyPair(clientCert, clientKey)
+	require.NoError(t, err)
+	leaf, err := x509.ParseCertificate(c.Certificate[0])
+	require.NoError(t, err)
+
+	assert.NoError(t, server.CheckRevoked(leaf))
+	require.NoError(t, server.SetDenyList(denyList{"0B"}))
+	assert.Error(t, server.CheckRevoked(leaf))
+}
+
+func TestReload(t *testing.T) {
+	dir := t.TempDir()
+
+	ca := newTestCA(t, dir, "ca")
+	serverCert, serverKey := ca.issue(t, dir, "server", 10)
+	clientCert, clientKey := ca.issue(t, dir, "client", 11)
+
+	server, err := NewProvider(serverCert, serverKey, []string{ca.file}, nil)
+	require.NoError(t, err)
+
+	client, err := NewProvider(clientCert, clientKey, []string{ca.file}, nil)
+	require.NoError(t, err)
+
+	// the config is taken once, like a gRPC server would, and should still pick up the changes
+	serverConfig := server.ServerConfig(tls.RequireAndVerifyClientCert)
+	clientConfig := client.ClientConfig(false)
+
+	cerr, serr := handshake(t, serverConfig, clientConfig)
+	require.NoError(t, cerr)
+	require.NoError(t, serr)
+
+	// rotate to a new CA: first only on the server, so the client should no longer trust it
+	newCA := newTestCA(t, dir, "newca")
+	serverCert, serverKey = newCA.issue(t, dir, "server", 20)
+	require.NoError(t, os.Rename(newCA.file, ca.file))
+	touch(t, serverCert)
+	touch(t, ca.file)
+
+	server.reload()
+
+	cerr, _ = handshake(t, serverConfig, clientConfig)
+	assert.Error(t, cerr)
+
+	// now the client gets its new certificate as well
+	clientCert, _ = newCA.issue(t, dir, "client", 21)
+	touch(t, clientCert)
+
+	client.reload()
+
+	cerr, serr = handshake(t, serverConfig, clientConfig)
+	assert.NoError(t, cerr)
+	assert.NoError(t, serr)
+
+	// a broken file should not break the running configuration
+	require.NoError(t, ioutil.WriteFile(serverCert, []byte("garbage"), 0600))
+	touch(t, serverCert)
+
+	server.reload()
+
+	cerr, serr = handshake(t, serverConfig, clientConfig)
+	assert.NoError(t, cerr)
+	assert.NoError(t, serr)
+}
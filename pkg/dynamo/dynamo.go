@@ -2,7 +2,6 @@ package dynamo
 
 import (
 	"strconv"
-	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -13,11 +12,12 @@ import (
 	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
 	"github.com/go-errors/errors"
 	"github.com/rs/zerolog/log"
+
+	"git.tu-berlin.de/mcc-fred/fred/pkg/storekey"
 )
 
 const (
 	keyName = "Key"
-	sep     = "|"
 )
 
 // Storage is a struct that saves all necessary information to access the database, in this case the session for DynamoDB and the table name.
@@ -28,37 +28,42 @@ type Storage struct {
 
 // makeKeyName creates the internal DynamoDB key given a keygroup name and an id.
 func makeKeyName(kgname string, id string) string {
-	return kgname + sep + id
+	return storekey.Item(kgname, id)
 }
 
-// makeKeygroupKeyName creates the internal DynamoDB key given a keygroup name.
+// makeKeygroupKeyName creates the prefix of all internal DynamoDB keys of items in a keygroup.
 func makeKeygroupKeyName(kgname string) string {
-	return kgname + sep
+	return storekey.Keygroup(kgname)
+}
+
+// makeKeygroupConfigKeyName creates the internal DynamoDB key that marks that a keygroup exists.
+func makeKeygroupConfigKeyName(kgname string) string {
+	return storekey.Meta(storekey.KindKeygroup, kgname, "")
 }
 
 func makeTriggerConfigKeyName(kgname string, tid string) string {
-	return sep + "fred" + sep + "triggers" + sep + kgname + sep + tid
+	return storekey.Meta(storekey.KindTriggers, kgname, tid)
 }
 
 // getTriggerConfigKey returns the keygroup and id of a key.
 func getTriggerConfigKey(key string) (kg, tid string) {
-	s := strings.Split(key, sep)
+	kg, tid, _ = storekey.ParseMeta(storekey.KindTriggers, key)
+	return
+}
 
-	if len(s) == len([]string{"nil", "fred", "triggers", "kgname", "triggerID"}) {
-		kg = s[3]
-		tid = s[4]
+// expiresAt returns the time at which an item that is written now with an expiry in seconds expires, or 0 if it does
+// not expire, so that DynamoDB never removes it.
+func expiresAt(expiry int) int64 {
+	if expiry <= 0 {
+		return 0
 	}
 
-	return
+	return time.Now().Unix() + int64(expiry)
 }
 
 // getKey returns the keygroup and id of a key.
 func getKey(key string) (kg, id string) {
-	s := strings.Split(key, sep)
-	kg = s[0]
-	if len(s) >= len([]string{"kgname", "id"}) {
-		id = s[1]
-	}
+	kg, id, _ = storekey.ParseItem(key)
 	return
 }
 
@@ -105,10 +110,127 @@ func New(table, region string) (s *Storage, err error) {
 
 	log.Debug().Msg("Checked table keys - OK!")
 
-	return &Storage{
+	s = &Storage{
 		dynamotable: table,
 		svc:         dynamodbiface.DynamoDBAPI(svc),
-	}, nil
+	}
+
+	if err := s.migrate(); err != nil {
+		return nil, err
+	}
+
+	log.Debug().Msg("Checked key format - OK!")
+
+	return s, nil
+}
+
+// migrate rewrites all keys of a table that was written before keys were encoded. Old and new keys never collide, so if
+// this is interrupted, it simply continues the next time.
+func (s *Storage) migrate() error {
+	result, err := s.svc.GetItem(&dynamodb.GetItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			keyName: {
+				S: aws.String(storekey.VersionKey),
+			},
+		},
+		TableName: &s.dynamotable,
+	})
+
+	if err != nil {
+		return errors.New(err)
+	}
+
+	if result.Item != nil {
+		v := struct {
+			Value string
+		}{}
+
+		if err := dynamodbattribute.UnmarshalMap(result.Item, &v); err != nil {
+			return errors.New(err)
+		}
+
+		if v.Value == strconv.Itoa(storekey.Version) {
+			return nil
+		}
+
+		return errors.Errorf("table %s has key format version %s but only version %d is supported", s.dynamotable, v.Value, storekey.Version)
+	}
+
+	migrated := 0
+	var merr error
+
+	err = s.svc.ScanPages(&dynamodb.ScanInput{
+		TableName: aws.String(s.dynamotable),
+	}, func(page *dynamodb.ScanOutput, _ bool) bool {
+		for _, i := range page.Items {
+			item := struct {
+				Key string
+			}{}
+
+			if merr = dynamodbattribute.UnmarshalMap(i, &item); merr != nil {
+				return false
+			}
+
+			key, ok := storekey.Migrate(item.Key, true)
+
+			if !ok {
+				continue
+			}
+
+			// keep everything else, such as the value and the expiry
+			i[keyName] = &dynamodb.AttributeValue{S: aws.String(key)}
+
+			if _, merr = s.svc.PutItem(&dynamodb.PutItemInput{
+				Item:      i,
+				TableName: aws.String(s.dynamotable),
+			}); merr != nil {
+				return false
+			}
+
+			if _, merr = s.svc.DeleteItem(&dynamodb.DeleteItemInput{
+				Key: map[string]*dynamodb.AttributeValue{
+					keyName: {
+						S: aws.String(item.Key),
+					},
+				},
+				TableName: aws.String(s.dynamotable),
+			}); merr != nil {
+				return false
+			}
+
+			migrated++
+		}
+
+		return true
+	})
+
+	if err != nil {
+		return errors.New(err)
+	}
+
+	if merr != nil {
+		return errors.New(merr)
+	}
+
+	_, err = s.svc.PutItem(&dynamodb.PutItemInput{
+		Item: map[string]*dynamodb.AttributeValue{
+			keyName: {
+				S: aws.String(storekey.VersionKey),
+			},
+			"Value": {
+				S: aws.String(strconv.Itoa(storekey.Version)),
+			},
+		},
+		TableName: aws.String(s.dynamotable),
+	})
+
+	if err != nil {
+		return errors.New(err)
+	}
+
+	log.Info().Msgf("migrated %d keys in table %s to key format version %d", migrated, s.dynamotable, storekey.Version)
+
+	return nil
 }
 
 // Close closes the underlying DynamoDB connection (no cleanup needed at the moment).
@@ -156,7 +278,7 @@ func (s *Storage) ReadSome(kg, id string, count uint64) (map[string]string, erro
 	key := makeKeygroupKeyName(kg)
 	start := makeKeyName(kg, id)
 
-	filt := expression.Name(keyName).BeginsWith(key).And(expression.Name(key).GreaterThan(expression.Key(start)))
+	filt := expression.Name(keyName).BeginsWith(key).And(expression.Name(keyName).GreaterThanEqual(expression.Value(start)))
 
 	expr, err := expression.NewBuilder().WithFilter(filt).Build()
 	if err != nil {
@@ -194,10 +316,6 @@ func (s *Storage) ReadSome(kg, id string, count uint64) (map[string]string, erro
 			return items, errors.New(err)
 		}
 
-		if item.Key == key {
-			continue
-		}
-
 		_, id := getKey(item.Key)
 
 		items[id] = item.Value
@@ -248,10 +366,6 @@ func (s *Storage) ReadAll(kg string) (map[string]string, error) {
 			return items, errors.New(err)
 		}
 
-		if item.Key == key {
-			continue
-		}
-
 		_, id := getKey(item.Key)
 
 		items[id] = item.Value
@@ -261,102 +375,100 @@ func (s *Storage) ReadAll(kg string) (map[string]string, error) {
 	return items, nil
 }
 
-// Append appends the item to the specified keygroup by incrementing the latest key by one.
-func (s *Storage) Append(kg, val string, expiry int) (string, error) {
-	// first, get the latest key
-	// maximum of 18446744073709551615, though!
-	// if you reach this maximum, please send me a letter
-	var newest uint64
-
+// Export calls fn for every item in the specified keygroup with the time at which it expires. Items that do not expire
+// have no expiry in the table. Items that are still in the table past their expiry have expired and are skipped, as
+// DynamoDB only removes them eventually.
+func (s *Storage) Export(kg string, fn func(id string, val string, expiresAt int64) error) error {
 	key := makeKeygroupKeyName(kg)
 
 	filt := expression.Name(keyName).BeginsWith(key)
 
 	expr, err := expression.NewBuilder().WithFilter(filt).Build()
 	if err != nil {
-		return "", errors.New(err)
+		return errors.New(err)
 	}
 
-	params := &dynamodb.ScanInput{
+	now := time.Now().Unix()
+	var ferr error
+
+	err = s.svc.ScanPages(&dynamodb.ScanInput{
 		ExpressionAttributeNames:  expr.Names(),
 		ExpressionAttributeValues: expr.Values(),
 		FilterExpression:          expr.Filter(),
-		ProjectionExpression:      expr.Projection(),
 		TableName:                 aws.String(s.dynamotable),
-	}
-
-	// Make the DynamoDB Query API call
-	result, err := s.svc.Scan(params)
-	if err != nil {
-		return "", errors.New(err)
-	}
-
-	if len(result.Items) == 0 {
-		// nothing in existence yet, return MaxUInt (so we can increment it to 0)
-		newest = ^uint64(0)
-	} else {
-		for _, i := range result.Items {
-
+	}, func(page *dynamodb.ScanOutput, _ bool) bool {
+		for _, i := range page.Items {
 			item := struct {
-				Key string
+				Key    string
+				Value  string
+				Expiry int64
 			}{}
 
-			err = dynamodbattribute.UnmarshalMap(i, &item)
-
-			if err != nil {
-				return "", errors.New(err)
-			}
-
-			if item.Key == key {
-				continue
+			if ferr = dynamodbattribute.UnmarshalMap(i, &item); ferr != nil {
+				ferr = errors.New(ferr)
+				return false
 			}
 
 			_, id := getKey(item.Key)
 
-			parsed, err := strconv.ParseUint(id, 10, 64)
-
-			if err != nil {
-				return "", errors.New(err)
+			if item.Expiry != 0 && item.Expiry <= now {
+				continue
 			}
 
-			if parsed > newest {
-				newest = parsed
+			if ferr = fn(id, item.Value, item.Expiry); ferr != nil {
+				return false
 			}
-
 		}
+
+		return true
+	})
+
+	if err != nil {
+		return errors.New(err)
 	}
 
-	// increment by one
-	// conveniently, if we reach MaxUint64, we can still increment by 1 to get back to 0
-	id := strconv.FormatUint(newest+1, 10)
+	return ferr
+}
+
+// Append adds a new item with the specified id to the specified keygroup. It fails if the item already exists.
+func (s *Storage) Append(kg, id, val string, expiry int) error {
+	key := makeKeyName(kg, id)
 
 	Item := struct {
 		Key    string
 		Value  string
-		Expiry int64
+		Expiry int64 `dynamodbav:",omitempty"`
 	}{
-		Key:    id,
+		Key:    key,
 		Value:  val,
-		Expiry: time.Now().Unix() + int64(expiry),
+		Expiry: expiresAt(expiry),
 	}
 
 	av, err := dynamodbattribute.MarshalMap(Item)
 
 	if err != nil {
-		return "", errors.New(err)
+		return errors.New(err)
+	}
+
+	cond, err := expression.NewBuilder().WithCondition(expression.AttributeNotExists(expression.Name(keyName))).Build()
+
+	if err != nil {
+		return errors.New(err)
 	}
 
 	input := &dynamodb.PutItemInput{
-		Item:      av,
-		TableName: aws.String(s.dynamotable),
+		Item:                     av,
+		ConditionExpression:      cond.Condition(),
+		ExpressionAttributeNames: cond.Names(),
+		TableName:                aws.String(s.dynamotable),
 	}
 
 	_, err = s.svc.PutItem(input)
 	if err != nil {
-		return "", errors.New(err)
+		return errors.New(err)
 	}
 
-	return id, nil
+	return nil
 }
 
 // IDs returns the keys of all items in the specified keygroup.
@@ -398,10 +510,6 @@ func (s *Storage) IDs(kg string) ([]string, error) {
 			return ids, errors.New(err)
 		}
 
-		if item.Key == key {
-			continue
-		}
-
 		_, id := getKey(item.Key)
 
 		ids = append(ids, id)
@@ -419,11 +527,11 @@ func (s *Storage) Update(kg, id, val string, _ bool, expiry int) error {
 	Item := struct {
 		Key    string
 		Value  string
-		Expiry int64
+		Expiry int64 `dynamodbav:",omitempty"`
 	}{
 		Key:    key,
 		Value:  val,
-		Expiry: time.Now().Unix() + int64(expiry),
+		Expiry: expiresAt(expiry),
 	}
 
 	av, err := dynamodbattribute.MarshalMap(Item)
@@ -492,7 +600,7 @@ func (s *Storage) Exists(kg string, id string) bool {
 
 // ExistsKeygroup checks if the given keygroup exists in the DynamoDB database.
 func (s *Storage) ExistsKeygroup(kg string) bool {
-	key := makeKeygroupKeyName(kg)
+	key := makeKeygroupConfigKeyName(kg)
 
 	result, err := s.svc.GetItem(&dynamodb.GetItemInput{
 		Key: map[string]*dynamodb.AttributeValue{
@@ -514,16 +622,62 @@ func (s *Storage) ExistsKeygroup(kg string) bool {
 	return true
 }
 
+// Keygroups returns the names of all keygroups in the DynamoDB database.
+func (s *Storage) Keygroups() ([]string, error) {
+	filt := expression.Name(keyName).BeginsWith(storekey.MetaPrefix(storekey.KindKeygroup))
+
+	expr, err := expression.NewBuilder().WithFilter(filt).Build()
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	var kgs []string
+	var perr error
+
+	err = s.svc.ScanPages(&dynamodb.ScanInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		FilterExpression:          expr.Filter(),
+		TableName:                 aws.String(s.dynamotable),
+	}, func(page *dynamodb.ScanOutput, _ bool) bool {
+		for _, i := range page.Items {
+			item := struct {
+				Key string
+			}{}
+
+			if perr = dynamodbattribute.UnmarshalMap(i, &item); perr != nil {
+				return false
+			}
+
+			if kg, _, ok := storekey.ParseMeta(storekey.KindKeygroup, item.Key); ok {
+				kgs = append(kgs, kg)
+			}
+		}
+
+		return true
+	})
+
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	if perr != nil {
+		return nil, errors.New(perr)
+	}
+
+	return kgs, nil
+}
+
 // CreateKeygroup creates the given keygroup in the DynamoDB database.
 func (s *Storage) CreateKeygroup(kg string) error {
-	key := makeKeygroupKeyName(kg)
+	key := makeKeygroupConfigKeyName(kg)
 
 	Item := struct {
 		Key   string
 		Value string
 	}{
 		Key:   key,
-		Value: key,
+		Value: kg,
 	}
 
 	av, err := dynamodbattribute.MarshalMap(Item)
@@ -654,6 +808,21 @@ func (s *Storage) DeleteKeygroup(kg string) error {
 		}
 	}
 
+	// delete the keygroup itself
+
+	_, err = s.svc.DeleteItem(&dynamodb.DeleteItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			keyName: {
+				S: aws.String(makeKeygroupConfigKeyName(kg)),
+			},
+		},
+		TableName: aws.String(s.dynamotable),
+	})
+
+	if err != nil {
+		return errors.New(err)
+	}
+
 	return nil
 }
 
@@ -749,10 +918,6 @@ func (s *Storage) GetKeygroupTrigger(kg string) (map[string]string, error) {
 			return triggers, errors.New(err)
 		}
 
-		if item.Key == key {
-			continue
-		}
-
 		_, id := getTriggerConfigKey(item.Key)
 
 		triggers[id] = item.Value
@@ -2,6 +2,7 @@ package etcdnase
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"git.tu-berlin.de/mcc-fred/fred/pkg/fred"
@@ -10,13 +11,13 @@ import (
 // RevokeUserPermissions removes user's permission to perform method on kg by deleting the key in etcd.
 func (n *NameService) RevokeUserPermissions(user string, method fred.Method, kg fred.KeygroupName) error {
 	prefix := fmt.Sprintf(fmtUserPermissionStringPrefix, user, string(kg))
-	return n.delete(prefix+string(method), prefix)
+	return n.delete(prefix + string(method))
 }
 
 // AddUserPermissions adds user's permission to perform method on kg by adding the key to etcd.
 func (n *NameService) AddUserPermissions(user string, method fred.Method, kg fred.KeygroupName) error {
 	prefix := fmt.Sprintf(fmtUserPermissionStringPrefix, user, string(kg))
-	return n.put(prefix+string(method), "ok", prefix)
+	return n.put(prefix+string(method), "ok")
 }
 
 // GetUserPermissions returns a set of all of the user's permissions on kg from etcd.
@@ -35,3 +36,222 @@ func (n *NameService) GetUserPermissions(user string, kg fred.KeygroupName) (map
 
 	return permissions, nil
 }
+
+// GetAllUserPermissions returns all users that have single permissions on kg with these permissions.
+func (n *NameService) GetAllUserPermissions(kg fred.KeygroupName) (map[string]map[fred.Method]struct{}, error) {
+	res, err := n.getPrefix(userPrefixString)
+
+	if err != nil {
+		return nil, err
+	}
+
+	permissions := make(map[string]map[fred.Method]struct{})
+
+	for k := range res {
+		// user|[user]|kg|[kg]|method|[method]
+		s := strings.Split(k, sep)
+
+		if len(s) != 6 || s[2] != "kg" || s[3] != string(kg) || s[4] != "method" {
+			continue
+		}
+
+		if _, ok := permissions[s[1]]; !ok {
+			permissions[s[1]] = make(map[fred.Method]struct{})
+		}
+
+		permissions[s[1]][fred.Method(s[5])] = struct{}{}
+	}
+
+	return permissions, nil
+}
+
+// AddRole adds methods to a custom role.
+func (n *NameService) AddRole(role fred.Role, methods []fred.Method) error {
+	prefix := fmt.Sprintf(fmtRoleMethodStringPrefix, string(role))
+
+	for _, m := range methods {
+		if err := n.put(prefix+string(m), "ok"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RemoveRole removes a custom role with all its methods.
+func (n *NameService) RemoveRole(role fred.Role) error {
+	return n.deletePrefix(fmt.Sprintf(fmtRoleMethodStringPrefix, string(role)))
+}
+
+// GetRoles returns all custom roles with their methods.
+func (n *NameService) GetRoles() (map[fred.Role]map[fred.Method]struct{}, error) {
+	res, err := n.getPrefix(rolePrefixString)
+
+	if err != nil {
+		return nil, err
+	}
+
+	roles := make(map[fred.Role]map[fred.Method]struct{})
+
+	for k := range res {
+		// role|[role]|method|[method]
+		s := strings.Split(k, sep)
+
+		if len(s) != 4 {
+			continue
+		}
+
+		r := fred.Role(s[1])
+
+		if _, ok := roles[r]; !ok {
+			roles[r] = make(map[fred.Method]struct{})
+		}
+
+		roles[r][fred.Method(s[3])] = struct{}{}
+	}
+
+	return roles, nil
+}
+
+// AddGroupMember adds a user to a group.
+func (n *NameService) AddGroupMember(group string, user string) error {
+	return n.put(fmt.Sprintf(fmtUserGroupStringPrefix, user)+group, "ok")
+}
+
+// RemoveGroupMember removes a user from a group.
+func (n *NameService) RemoveGroupMember(group string, user string) error {
+	return n.delete(fmt.Sprintf(fmtUserGroupStringPrefix, user) + group)
+}
+
+// GetGroupMembers returns all users in a group.
+// Group memberships are stored with the user so that they are quick to find when checking permissions, which means
+// we have to look at all users here.
+func (n *NameService) GetGroupMembers(group string) ([]string, error) {
+	res, err := n.getPrefix(userPrefixString)
+
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]string, 0)
+
+	for k := range res {
+		// user|[user]|group|[group]
+		s := strings.Split(k, sep)
+
+		if len(s) != 4 || s[2] != "group" || s[3] != group {
+			continue
+		}
+
+		members = append(members, s[1])
+	}
+
+	sort.Strings(members)
+
+	return members, nil
+}
+
+// GetUserGroups returns all groups that a user is a member of.
+func (n *NameService) GetUserGroups(user string) ([]string, error) {
+	res, err := n.getPrefix(fmt.Sprintf(fmtUserGroupStringPrefix, user))
+
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make([]string, 0, len(res))
+
+	for k := range res {
+		groups = append(groups, strings.Split(k, sep)[3])
+	}
+
+	sort.Strings(groups)
+
+	return groups, nil
+}
+
+// fmtRoleBinding returns the key for a role binding.
+func fmtRoleBinding(b fred.RoleBinding) string {
+	if b.Subject.Group {
+		return fmt.Sprintf(fmtGroupRoleStringPrefix, b.Subject.Name, string(b.Keygroup)) + string(b.Role)
+	}
+
+	return fmt.Sprintf(fmtUserRoleStringPrefix, b.Subject.Name, string(b.Keygroup)) + string(b.Role)
+}
+
+// parseRoleBinding parses a key of the form [user|group]|[name]|kg|[kg]|role|[role]. It returns false if the key is
+// not a role binding.
+func parseRoleBinding(key string) (fred.RoleBinding, bool) {
+	s := strings.Split(key, sep)
+
+	if len(s) != 6 || s[2] != "kg" || s[4] != "role" {
+		return fred.RoleBinding{}, false
+	}
+
+	return fred.RoleBinding{
+		Subject: fred.Subject{
+			Name:  s[1],
+			Group: s[0]+sep == groupPrefixString,
+		},
+		Role:     fred.Role(s[5]),
+		Keygroup: fred.KeygroupName(s[3]),
+	}, true
+}
+
+// AddRoleBinding grants a role to a user or group on a keygroup pattern.
+func (n *NameService) AddRoleBinding(b fred.RoleBinding) error {
+	return n.put(fmtRoleBinding(b), "ok")
+}
+
+// RevokeRoleBinding revokes a role from a user or group on a keygroup pattern.
+func (n *NameService) RevokeRoleBinding(b fred.RoleBinding) error {
+	return n.delete(fmtRoleBinding(b))
+}
+
+// GetRoleBindings returns all roles that were granted to a user or group directly.
+func (n *NameService) GetRoleBindings(s fred.Subject) ([]fred.RoleBinding, error) {
+	var prefix string
+
+	if s.Group {
+		prefix = fmt.Sprintf(fmtGroupKgStringPrefix, s.Name)
+	} else {
+		prefix = fmt.Sprintf(fmtUserKgStringPrefix, s.Name)
+	}
+
+	res, err := n.getPrefix(prefix)
+
+	if err != nil {
+		return nil, err
+	}
+
+	bindings := make([]fred.RoleBinding, 0, len(res))
+
+	for k := range res {
+		if b, ok := parseRoleBinding(k); ok {
+			bindings = append(bindings, b)
+		}
+	}
+
+	return bindings, nil
+}
+
+// GetAllRoleBindings returns the role bindings of all users and groups.
+func (n *NameService) GetAllRoleBindings() ([]fred.RoleBinding, error) {
+	bindings := make([]fred.RoleBinding, 0)
+
+	for _, prefix := range []string{userPrefixString, groupPrefixString} {
+		res, err := n.getPrefix(prefix)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for k := range res {
+			if b, ok := parseRoleBinding(k); ok {
+				bindings = append(bindings, b)
+			}
+		}
+	}
+
+	return bindings, nil
+}
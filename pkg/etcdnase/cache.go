@@ -0,0 +1,231 @@
+package etcdnase
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/rs/zerolog/log"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	"go.etcd.io/etcd/client/v3"
+)
+
+const resyncInterval = 1 * time.Second
+
+// mirror is a local copy of the NaSe key space. It is filled once on startup and then kept up to date by a single
+// long-lived watch on the NaSe that applies every put and delete as it happens, so there is nothing to invalidate.
+// The revision is the revision of the NaSe that the mirror reflects.
+type mirror struct {
+	sync.RWMutex
+	kv       map[string]string
+	keys     []string
+	revision int64
+	changed  chan struct{}
+}
+
+func newMirror() *mirror {
+	return &mirror{
+		kv:      make(map[string]string),
+		changed: make(chan struct{}),
+	}
+}
+
+// set stores a key and keeps the sorted list of keys intact. The caller must hold the write lock.
+func (m *mirror) set(key string, val string) {
+	if _, ok := m.kv[key]; !ok {
+		i := sort.SearchStrings(m.keys, key)
+		m.keys = append(m.keys, "")
+		copy(m.keys[i+1:], m.keys[i:])
+		m.keys[i] = key
+	}
+
+	m.kv[key] = val
+}
+
+// del removes a key. The caller must hold the write lock.
+func (m *mirror) del(key string) {
+	if _, ok := m.kv[key]; !ok {
+		return
+	}
+
+	delete(m.kv, key)
+
+	i := sort.SearchStrings(m.keys, key)
+	m.keys = append(m.keys[:i], m.keys[i+1:]...)
+}
+
+// advance moves the mirror to a new revision and wakes up everyone waiting for it. The caller must hold the write lock.
+func (m *mirror) advance(rev int64) {
+	if rev <= m.revision {
+		return
+	}
+
+	m.revision = rev
+	close(m.changed)
+	m.changed = make(chan struct{})
+}
+
+// load replaces the contents of the mirror with a full copy of the NaSe at the given revision.
+func (m *mirror) load(kvs []*mvccpb.KeyValue, rev int64) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.kv = make(map[string]string, len(kvs))
+	m.keys = make([]string, 0, len(kvs))
+
+	for _, kv := range kvs {
+		m.kv[string(kv.Key)] = string(kv.Value)
+		m.keys = append(m.keys, string(kv.Key))
+	}
+
+	sort.Strings(m.keys)
+
+	m.advance(rev)
+}
+
+// apply applies a batch of changes from the watch. If there are no events, the batch is a progress notification and
+// the header revision is used.
+func (m *mirror) apply(events []*clientv3.Event, rev int64) {
+	m.Lock()
+	defer m.Unlock()
+
+	if len(events) == 0 {
+		m.advance(rev)
+		return
+	}
+
+	var latest int64
+
+	for _, ev := range events {
+		switch ev.Type {
+		case clientv3.EventTypePut:
+			m.set(string(ev.Kv.Key), string(ev.Kv.Value))
+		case clientv3.EventTypeDelete:
+			m.del(string(ev.Kv.Key))
+		}
+
+		if ev.Kv.ModRevision > latest {
+			latest = ev.Kv.ModRevision
+		}
+	}
+
+	m.advance(latest)
+}
+
+// get returns the value for a key and whether that key exists.
+func (m *mirror) get(key string) (string, bool) {
+	m.RLock()
+	defer m.RUnlock()
+
+	v, ok := m.kv[key]
+	return v, ok
+}
+
+// getPrefix returns all keys that start with the given prefix.
+func (m *mirror) getPrefix(prefix string) map[string]string {
+	m.RLock()
+	defer m.RUnlock()
+
+	kv := make(map[string]string)
+
+	for i := sort.SearchStrings(m.keys, prefix); i < len(m.keys) && strings.HasPrefix(m.keys[i], prefix); i++ {
+		kv[m.keys[i]] = m.kv[m.keys[i]]
+	}
+
+	return kv
+}
+
+// getRevision returns the NaSe revision that the mirror currently reflects.
+func (m *mirror) getRevision() int64 {
+	m.RLock()
+	defer m.RUnlock()
+
+	return m.revision
+}
+
+// waitFor blocks until the mirror has caught up with the given revision or the timeout is reached.
+// We use this after our own writes so that a node can always read what it has just written.
+func (m *mirror) waitFor(rev int64, timeout time.Duration) error {
+	t := time.NewTimer(timeout)
+	defer t.Stop()
+
+	for {
+		m.RLock()
+		curr := m.revision
+		changed := m.changed
+		m.RUnlock()
+
+		if curr >= rev {
+			return nil
+		}
+
+		select {
+		case <-changed:
+		case <-t.C:
+			return errors.Errorf("local nase mirror is at revision %d but needs to be at %d", curr, rev)
+		}
+	}
+}
+
+// loadMirror reads the entire NaSe key space into the local mirror and returns the revision it was read at.
+func (n *NameService) loadMirror() (int64, error) {
+	ctx, cncl := context.WithTimeout(context.Background(), timeout)
+
+	defer cncl()
+
+	resp, err := n.cli.Get(ctx, "", clientv3.WithPrefix())
+
+	if err != nil {
+		return 0, errors.New(err)
+	}
+
+	n.local.load(resp.Kvs, resp.Header.Revision)
+
+	log.Debug().Msgf("nase mirror: loaded %d keys at revision %d", len(resp.Kvs), resp.Header.Revision)
+
+	return resp.Header.Revision, nil
+}
+
+// watchMirror keeps the local mirror up to date with a single watch on the whole NaSe key space, starting after the
+// given revision. If the watch fails (e.g., because the revision we wanted to continue from has been compacted), the
+// mirror is loaded from scratch and the watch is restarted.
+func (n *NameService) watchMirror(ctx context.Context, rev int64) {
+	for {
+		// every watch gets its own context, so that it is closed on the server before the next one is started
+		wctx, cancel := context.WithCancel(ctx)
+		c := n.cli.Watch(clientv3.WithRequireLeader(wctx), "", clientv3.WithPrefix(), clientv3.WithRev(rev+1), clientv3.WithProgressNotify())
+
+		log.Debug().Msgf("nase mirror: watching for changes after revision %d", rev)
+
+		for r := range c {
+			if err := r.Err(); err != nil {
+				log.Err(err).Msg("nase mirror: error watching for changes")
+				break
+			}
+
+			n.local.apply(r.Events, r.Header.Revision)
+			log.Debug().Msgf("nase mirror: applied %d changes, now at revision %d", len(r.Events), n.local.getRevision())
+		}
+
+		cancel()
+
+		for {
+			if ctx.Err() != nil {
+				return
+			}
+
+			var err error
+			rev, err = n.loadMirror()
+
+			if err == nil {
+				break
+			}
+
+			log.Err(err).Msg("nase mirror: could not reload mirror, retrying")
+			time.Sleep(resyncInterval)
+		}
+	}
+}
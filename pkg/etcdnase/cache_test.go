@@ -0,0 +1,75 @@
+package etcdnase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	"go.etcd.io/etcd/client/v3"
+)
+
+func put(key, val string, rev int64) *clientv3.Event {
+	return &clientv3.Event{
+		Type: clientv3.EventTypePut,
+		Kv:   &mvccpb.KeyValue{Key: []byte(key), Value: []byte(val), ModRevision: rev},
+	}
+}
+
+func del(key string, rev int64) *clientv3.Event {
+	return &clientv3.Event{
+		Type: clientv3.EventTypeDelete,
+		Kv:   &mvccpb.KeyValue{Key: []byte(key), ModRevision: rev},
+	}
+}
+
+func TestMirror(t *testing.T) {
+	m := newMirror()
+
+	m.load([]*mvccpb.KeyValue{
+		{Key: []byte("kg|a|node|X"), Value: []byte("ok")},
+		{Key: []byte("kg|a|status"), Value: []byte("created")},
+		{Key: []byte("kg|ab|node|X"), Value: []byte("ok")},
+	}, 10)
+
+	assert.Equal(t, int64(10), m.getRevision())
+	assert.Equal(t, map[string]string{"kg|a|node|X": "ok"}, m.getPrefix("kg|a|node|"))
+
+	m.apply([]*clientv3.Event{
+		put("kg|a|node|Y", "ok", 11),
+		put("kg|a|node|X", "removed", 12),
+		del("kg|ab|node|X", 13),
+	}, 20)
+
+	// the header revision of a watch response may be ahead of the events we have seen so far
+	assert.Equal(t, int64(13), m.getRevision())
+	assert.Equal(t, map[string]string{"kg|a|node|X": "removed", "kg|a|node|Y": "ok"}, m.getPrefix("kg|a|node|"))
+	assert.Len(t, m.getPrefix("kg|ab|"), 0)
+
+	v, ok := m.get("kg|a|status")
+	assert.True(t, ok)
+	assert.Equal(t, "created", v)
+
+	_, ok = m.get("kg|ab|node|X")
+	assert.False(t, ok)
+
+	// a progress notification without events moves the revision forward
+	m.apply(nil, 20)
+	assert.Equal(t, int64(20), m.getRevision())
+}
+
+func TestMirrorWaitFor(t *testing.T) {
+	m := newMirror()
+
+	assert.Error(t, m.waitFor(1, 10*time.Millisecond))
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		m.apply([]*clientv3.Event{put("node|X|address", "1.1.1.1:5555", 1)}, 1)
+	}()
+
+	assert.NoError(t, m.waitFor(1, time.Second))
+
+	v, _ := m.get("node|X|address")
+	assert.Equal(t, "1.1.1.1:5555", v)
+}
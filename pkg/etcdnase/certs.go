@@ -0,0 +1,23 @@
+package etcdnase
+
+import (
+	"strings"
+)
+
+// GetRevokedCertificates returns the serial numbers of all certificates that have been revoked in the NaSe.
+// A certificate is revoked by putting the key cert|revoked|[serial] with any value.
+func (n *NameService) GetRevokedCertificates() ([]string, error) {
+	res, err := n.getPrefix(revokedCertPrefixString)
+
+	if err != nil {
+		return nil, err
+	}
+
+	serials := make([]string, 0, len(res))
+
+	for k := range res {
+		serials = append(serials, strings.TrimPrefix(k, revokedCertPrefixString))
+	}
+
+	return serials, nil
+}
@@ -14,7 +14,7 @@ func (n *NameService) ReportFailedNode(nodeID fred.NodeID, kg fred.KeygroupName,
 	log.Warn().Msgf("Nase: ReportFailedNode: Reporting that nodeId %#v has missed kg %#v id %s", nodeID, kg, id)
 	prefix := fmt.Sprintf(fmtFailedNodeKgStringPrefix, nodeID, kg)
 	log.Debug().Msgf("NaSe.ReportFailedNode: Putting %s into NaSe", prefix)
-	err := n.put(prefix+id, "1", prefix)
+	err := n.put(prefix+id, "1")
 
 	if err != nil {
 		log.Err(err).Msgf("NaSe: ReportFailedNode: Node was not able to reach NaSe." +
@@ -36,7 +36,8 @@ func (n *NameService) RequestNodeStatus(nodeID fred.NodeID) (kgs []fred.Item) {
 	log.Debug().Msgf("Nase: RequestNodeStatus: found %d items that were missed", len(resp))
 
 	for k := range resp {
-		split := strings.Split(k, sep)
+		// IDs may contain the separator, so everything after the keygroup name is the ID
+		split := strings.SplitN(k, sep, 5)
 		kgname := split[3]
 		id := split[4]
 		log.Debug().Msgf("NaSe: RequestNodeStatus: missed item has kgname %s and id %s (from key in NaSe: %s)", kgname, id, k)
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"sync/atomic"
 
 	"github.com/go-errors/errors"
 	"github.com/rs/zerolog/log"
@@ -14,27 +15,12 @@ import (
 // getPrefix gets every key that starts(!) with the specified string
 // the keys are sorted ascending by key for easier debugging
 func (n *NameService) getPrefix(prefix string) (kv map[string]string, err error) {
-	// the hard part of caching isn't storing a key-value pair locally
-	// it's actually knowing when to remove an entry from the cache because it's outdated
-	// sure, you can set a timeout or other eviction policy but that's more or less arbitrary
-	// we remove an item from the cache if we delete it from the nase ourselves or nase informs us about deletion via watchers
-	// we update an item if we update it ourselves or nase informs us about an update via watchers
-	// prefixes are the hardest part about this
+	// if we keep a local mirror of the nase, we can answer this locally
+	// the mirror is kept up to date with a watch, so there is no need to ever ask etcd again
 	if n.cached {
-		// let's check the local cache first
-		// store prefix directly in cache
-		val, ok := n.local.Get(prefix)
-
-		// found something!
-		if ok {
-			log.Debug().Msgf("prefix: %s cache hit", prefix)
-			return val.(map[string]string), nil
-		}
+		return n.local.getPrefix(prefix), nil
 	}
 
-	log.Debug().Msgf("prefix: %s cache miss", prefix)
-
-	// didn't find anything? ask nameservice, cache, and be sure to invalidate on change
 	ctx, cncl := context.WithTimeout(context.Background(), timeout)
 
 	defer cncl()
@@ -45,55 +31,24 @@ func (n *NameService) getPrefix(prefix string) (kv map[string]string, err error)
 		return nil, errors.New(err)
 	}
 
+	atomic.StoreInt64(&n.revision, resp.Header.Revision)
+
 	kv = make(map[string]string)
 
 	for _, val := range resp.Kvs {
 		kv[string(val.Key)] = string(val.Value)
 	}
 
-	if n.cached {
-		n.local.Set(prefix, kv, 1)
-
-		// TODO: use prefix changes to change local cache
-		go func() {
-			watchCtx, watchCncl := context.WithCancel(context.Background())
-			c := n.watcher.Watch(watchCtx, prefix, clientv3.WithPrefix())
-			log.Debug().Msgf("nase cache: watching for changes to prefix %s", prefix)
-
-			defer watchCncl()
-			for r := range c {
-				if err := r.Err(); err != nil {
-					log.Err(err).Msgf("nase cache: error getting changes to prefix %s", prefix)
-				}
-				log.Debug().Msgf("nase cache: got %d changes to prefix %s", len(r.Events), prefix)
-				if len(r.Events) != 0 {
-					n.local.Del(prefix)
-					log.Debug().Msgf("prefix: %s remote cache invalidation", prefix)
-					return
-				}
-			}
-		}()
-	}
 	return kv, nil
 }
 
 // getExact gets the exact key
 func (n *NameService) getExact(key string) (v string, err error) {
-
 	if n.cached {
-		// let's check the local cache first
-		val, ok := n.local.Get(key)
-
-		// found something!
-		if ok {
-			log.Debug().Msgf("key: %s cache hit", key)
-			return val.(string), nil
-		}
+		v, _ = n.local.get(key)
+		return v, nil
 	}
 
-	log.Debug().Msgf("key: %s cache miss", key)
-
-	// didn't find anything? ask nameservice, cache, and be sure to invalidate on change
 	ctx, cncl := context.WithTimeout(context.Background(), timeout)
 
 	defer cncl()
@@ -104,35 +59,26 @@ func (n *NameService) getExact(key string) (v string, err error) {
 		return "", errors.New(err)
 	}
 
+	atomic.StoreInt64(&n.revision, resp.Header.Revision)
+
 	if len(resp.Kvs) != 0 {
 		v = string(resp.Kvs[0].Value)
 	}
 
-	if n.cached {
-		n.local.Set(key, v, 1)
-
-		go func() {
-			watchCtx, watchCncl := context.WithCancel(context.Background())
-			c := n.watcher.Watch(watchCtx, key)
-
-			defer watchCncl()
-			// TODO: use key changes to modify local cache directly
-			for r := range c {
-				if err := r.Err(); err != nil {
-					log.Err(err).Msgf("nase cache: error getting changes to key %s", key)
-				}
-				log.Debug().Msgf("nase cache: got %d changes to mey %s", len(r.Events), key)
-				if len(r.Events) != 0 {
-					n.local.Del(key)
-					log.Debug().Msgf("key: %s remote cache invalidation", key)
-					return
-				}
-			}
-		}()
+	return v, nil
+}
 
+// written makes sure that our own change at revision rev is visible to us before we continue.
+// Otherwise, a node could write something to the nase and not see it in the next request.
+func (n *NameService) written(rev int64) {
+	if !n.cached {
+		atomic.StoreInt64(&n.revision, rev)
+		return
 	}
 
-	return v, nil
+	if err := n.local.waitFor(rev, timeout); err != nil {
+		log.Warn().Msgf("nase mirror: %s", err.Error())
+	}
 }
 
 func (n *NameService) getKeygroupStatus(kg string) (string, error) {
@@ -157,65 +103,67 @@ func (n *NameService) getKeygroupExpiry(kg string, id string) (int, error) {
 }
 
 // put puts the value into etcd.
-func (n *NameService) put(key, value string, prefix ...string) (err error) {
+func (n *NameService) put(key, value string) (err error) {
 	ctx, cncl := context.WithTimeout(context.TODO(), timeout)
 
 	defer cncl()
 
-	if n.cached {
-		for _, p := range prefix {
-			n.local.Del(p)
-			log.Debug().Msgf("prefix: %s local cache invalidation", p)
-		}
-		n.local.Del(key)
-		log.Debug().Msgf("key: %s local cache invalidation", key)
-	}
-
-	_, err = n.cli.Put(ctx, key, value)
+	resp, err := n.cli.Put(ctx, key, value)
 
 	if err != nil {
 		return errors.New(err)
 	}
 
+	n.written(resp.Header.Revision)
+
 	return nil
 }
 
 // delete removes the value from etcd.
-func (n *NameService) delete(key string, prefix ...string) (err error) {
+func (n *NameService) delete(key string) (err error) {
 	ctx, cncl := context.WithTimeout(context.TODO(), timeout)
 
 	defer cncl()
 
-	if n.cached {
-		for _, p := range prefix {
-			n.local.Del(p)
-			log.Debug().Msgf("prefix: %s local cache invalidation", p)
-		}
-		n.local.Del(key)
-		log.Debug().Msgf("key: %s local cache invalidation", key)
+	resp, err := n.cli.Delete(ctx, key)
 
+	if err != nil {
+		return errors.New(err)
 	}
 
-	_, err = n.cli.Delete(ctx, key)
+	n.written(resp.Header.Revision)
+
+	return nil
+}
+
+// deletePrefix removes all values with the given prefix from etcd.
+func (n *NameService) deletePrefix(prefix string) (err error) {
+	ctx, cncl := context.WithTimeout(context.TODO(), timeout)
+
+	defer cncl()
+
+	resp, err := n.cli.Delete(ctx, prefix, clientv3.WithPrefix())
 
 	if err != nil {
 		return errors.New(err)
 	}
 
+	n.written(resp.Header.Revision)
+
 	return nil
 }
 
 // addOwnKgNodeEntry adds the entry for this node with a status.
 func (n *NameService) addOwnKgNodeEntry(kg string, status string) error {
 	prefix, id := n.fmtKgNode(kg)
-	return n.put(prefix+id, status, prefix)
+	return n.put(prefix+id, status)
 }
 
 // addOtherKgNodeEntry adds the entry for a remote node with a status.
 func (n *NameService) addOtherKgNodeEntry(node string, kg string, status string) error {
 	prefix := fmt.Sprintf(fmtKgNodeStringPrefix, kg)
 	key := prefix + node
-	return n.put(key, status, prefix)
+	return n.put(key, status)
 }
 
 // addKgStatusEntry adds the entry for a (new!) keygroup with a status.
@@ -239,7 +187,7 @@ func (n *NameService) addKgMutableEntry(kg string, mutable bool) error {
 // addKgExpiryEntry adds the expiry entry for a keygroup with a status.
 func (n *NameService) addKgExpiryEntry(kg string, id string, expiry int) error {
 	prefix := fmt.Sprintf(fmtKgExpiryStringPrefix, kg)
-	return n.put(prefix+id, strconv.Itoa(expiry), prefix)
+	return n.put(prefix+id, strconv.Itoa(expiry))
 }
 
 // fmtKgNode turns a keygroup name into the key that this node will save its state in
@@ -1,13 +1,11 @@
 package etcdnase
 
 import (
-	"context"
 	"fmt"
 
 	"git.tu-berlin.de/mcc-fred/fred/pkg/fred"
 	"github.com/go-errors/errors"
 	"github.com/rs/zerolog/log"
-	"go.etcd.io/etcd/client/v3"
 )
 
 // ExitOtherNodeFromKeygroup deletes the node from the NaSe
@@ -100,15 +98,12 @@ func (n *NameService) CreateKeygroup(kg fred.KeygroupName, mutable bool, expiry
 	// Why? Because all the nodes in this keygroup should be able to know that they are in a deleted keygroup
 	// This is only a problem if a node doesn't see the delete state and only the new state in which it is not a member
 	// But in this case it should just delete itself from the keygroup
-	ctx, cncl := context.WithTimeout(context.Background(), timeout)
-
-	defer cncl()
-
-	_, err = n.cli.Delete(ctx, fmt.Sprintf(fmtKgNodeStringPrefix, string(kg)), clientv3.WithPrefix())
+	err = n.deletePrefix(fmt.Sprintf(fmtKgNodeStringPrefix, string(kg)))
 
 	if err != nil {
-		return errors.New(err)
+		return err
 	}
+
 	return n.addOwnKgNodeEntry(string(kg), "ok")
 }
 
@@ -1,11 +1,12 @@
 package etcdnase
 
 import (
+	"context"
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	"git.tu-berlin.de/mcc-fred/fred/pkg/fred"
-	"github.com/dgraph-io/ristretto"
 	"github.com/go-errors/errors"
 	"github.com/rs/zerolog/log"
 	"go.etcd.io/etcd/client/pkg/v3/transport"
@@ -20,9 +21,19 @@ const (
 	fmtNodeAdressString           = "node|%s|address"
 	fmtNodeExternalAdressString   = "node|%s|extaddress"
 	fmtUserPermissionStringPrefix = "user|%s|kg|%s|method|"
+	fmtUserRoleStringPrefix       = "user|%s|kg|%s|role|"
+	fmtUserKgStringPrefix         = "user|%s|kg|"
+	fmtUserGroupStringPrefix      = "user|%s|group|"
+	fmtGroupRoleStringPrefix      = "group|%s|kg|%s|role|"
+	fmtGroupKgStringPrefix        = "group|%s|kg|"
+	fmtRoleMethodStringPrefix     = "role|%s|method|"
 	fmtFailedNodeKgStringPrefix   = "failnode|%s|kg|%s|" // Node, Keygroup, ID
 	fmtFailedNodePrefix           = "failnode|%s|"
 	nodePrefixString              = "node|"
+	userPrefixString              = "user|"
+	groupPrefixString             = "group|"
+	rolePrefixString              = "role|"
+	revokedCertPrefixString       = "cert|revoked|"
 	sep                           = "|"
 	timeout                       = 5 * time.Second
 )
@@ -30,11 +41,12 @@ const (
 // NameService is the interface to the etcd server that serves as NaSe
 // It is used by the replservice to sync updates to keygroups with other nodes and thereby makes sure that ReplicationStorage always has up to date information
 type NameService struct {
-	cli     *clientv3.Client
-	watcher clientv3.Watcher
-	local   *ristretto.Cache
-	cached  bool
-	NodeID  string
+	cli      *clientv3.Client
+	local    *mirror
+	stop     context.CancelFunc
+	cached   bool
+	revision int64
+	NodeID   string
 }
 
 // NewNameService creates a new NameService
@@ -62,28 +74,37 @@ func NewNameService(nodeID string, endpoints []string, certfFile string, keyFile
 		return nil, errors.Errorf("Error starting the etcd client: %v", err)
 	}
 
-	var cache *ristretto.Cache
-	var watcher clientv3.Watcher
+	n := &NameService{
+		cli:    cli,
+		NodeID: nodeID,
+		cached: cached,
+	}
+
 	if cached {
-		cache, err = ristretto.NewCache(&ristretto.Config{
-			NumCounters: 1e7,     // number of keys to track frequency of (10M).
-			MaxCost:     1 << 30, // maximum cost of cache (1GB).
-			BufferItems: 64,      // number of keys per Get buffer.
-		})
+		n.local = newMirror()
+
+		rev, err := n.loadMirror()
 
 		if err != nil {
-			return nil, errors.Errorf("error creating a local ristretto cache: %s", err.Error())
+			return nil, errors.Errorf("error creating a local nase mirror: %v", err)
 		}
-		watcher = clientv3.NewWatcher(cli)
+
+		ctx, cncl := context.WithCancel(context.Background())
+		n.stop = cncl
+
+		go n.watchMirror(ctx, rev)
 	}
 
-	return &NameService{
-		cli:     cli,
-		watcher: watcher,
-		local:   cache,
-		NodeID:  nodeID,
-		cached:  cached,
-	}, nil
+	return n, nil
+}
+
+// Close stops watching the NaSe and closes the connection to etcd.
+func (n *NameService) Close() error {
+	if n.stop != nil {
+		n.stop()
+	}
+
+	return n.cli.Close()
 }
 
 // RegisterSelf stores information about this node
@@ -105,3 +126,13 @@ func (n *NameService) RegisterSelf(host string, externalHost string) error {
 func (n *NameService) GetNodeID() fred.NodeID {
 	return fred.NodeID(n.NodeID)
 }
+
+// Revision returns the revision of the NaSe that this node's view reflects.
+// With caching enabled, this is the revision of the local mirror, otherwise the revision of the last response from etcd.
+func (n *NameService) Revision() int64 {
+	if n.cached {
+		return n.local.getRevision()
+	}
+
+	return atomic.LoadInt64(&n.revision)
+}
@@ -0,0 +1,837 @@
+// Package filestore is a storage adaptor that only uses the standard library, for devices that are too small for
+// BadgerDB. All changes are appended to a single log file as checksummed records, and an index of the log is kept in
+// memory. Values stay in the file and are read when they are needed. The log is compacted periodically to remove
+// records that were overwritten, deleted, or have expired.
+package filestore
+
+import (
+	"bufio"
+	"encoding/binary"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/rs/zerolog/log"
+)
+
+const logName = "fred.log"
+const compactName = "fred.log.compact"
+
+const compactInterval = 5 * time.Minute
+
+// the log is only compacted once it has at least compactMinGarbage bytes that are no longer needed, and they make up
+// at least compactGarbageRatio of the file
+const compactMinGarbage = 1 << 20
+const compactGarbageRatio = 0.5
+
+// magic starts every log file, followed by the version of the record format.
+const magic = "FREDLOG"
+const version = 1
+const fileHeaderSize = len(magic) + 1
+
+// A record is a checksum, the operation, when the item expires, the lengths of the keygroup name, ID, and value, and
+// then the keygroup name, ID, and value themselves. The checksum covers everything after it.
+const recordHeaderSize = 4 + 1 + 8 + 4 + 4 + 4
+
+// These are the operations that records can have.
+const (
+	opUpdate byte = iota + 1
+	opDelete
+	opCreateKeygroup
+	opDeleteKeygroup
+	opAddTrigger
+	opDeleteTrigger
+)
+
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+// record is a change to the store. For trigger nodes, the ID is the ID of the trigger node and the value its host.
+type record struct {
+	op        byte
+	expiresAt int64
+	kg        string
+	id        string
+	val       string
+}
+
+// encode returns a record as it is written to the log.
+func (r record) encode() []byte {
+	b := make([]byte, recordHeaderSize+len(r.kg)+len(r.id)+len(r.val))
+
+	b[4] = r.op
+	binary.BigEndian.PutUint64(b[5:13], uint64(r.expiresAt))
+	binary.BigEndian.PutUint32(b[13:17], uint32(len(r.kg)))
+	binary.BigEndian.PutUint32(b[17:21], uint32(len(r.id)))
+	binary.BigEndian.PutUint32(b[21:25], uint32(len(r.val)))
+
+	n := recordHeaderSize
+	n += copy(b[n:], r.kg)
+	n += copy(b[n:], r.id)
+	copy(b[n:], r.val)
+
+	binary.BigEndian.PutUint32(b[0:4], crc32.Checksum(b[4:], crcTable))
+
+	return b
+}
+
+// readRecord reads the next record from the log, of which remaining bytes are left. It returns io.EOF if the log ends
+// exactly before the record, any other error means that the record is incomplete or corrupt.
+func readRecord(r io.Reader, remaining int64) (record, int64, error) {
+	h := make([]byte, recordHeaderSize)
+
+	if _, err := io.ReadFull(r, h); err != nil {
+		return record{}, 0, err
+	}
+
+	kgLen := int64(binary.BigEndian.Uint32(h[13:17]))
+	idLen := int64(binary.BigEndian.Uint32(h[17:21]))
+	valLen := int64(binary.BigEndian.Uint32(h[21:25]))
+	size := recordHeaderSize + kgLen + idLen + valLen
+
+	// corrupt lengths must not make us allocate more than the file has
+	if size > remaining {
+		return record{}, 0, errors.Errorf("record of %d bytes is longer than the %d bytes left in the log", size, remaining)
+	}
+
+	b := make([]byte, kgLen+idLen+valLen)
+
+	if _, err := io.ReadFull(r, b); err != nil {
+		return record{}, 0, errors.Errorf("record is incomplete: %v", err)
+	}
+
+	crc := crc32.Update(crc32.Checksum(h[4:], crcTable), crcTable, b)
+
+	if crc != binary.BigEndian.Uint32(h[0:4]) {
+		return record{}, 0, errors.Errorf("record has a wrong checksum")
+	}
+
+	if h[4] < opUpdate || h[4] > opDeleteTrigger {
+		return record{}, 0, errors.Errorf("record has unknown operation %d", h[4])
+	}
+
+	return record{
+		op:        h[4],
+		expiresAt: int64(binary.BigEndian.Uint64(h[5:13])),
+		kg:        string(b[:kgLen]),
+		id:        string(b[kgLen : kgLen+idLen]),
+		val:       string(b[kgLen+idLen:]),
+	}, size, nil
+}
+
+// entry is where the value of an item is in the log. size is the size of the whole record, which becomes garbage once
+// the item is overwritten or deleted.
+type entry struct {
+	off       int64
+	len       int
+	expiresAt int64
+	size      int64
+}
+
+// expired checks whether an item has expired at the given Unix time.
+func (e entry) expired(now int64) bool {
+	return e.expiresAt != 0 && e.expiresAt <= now
+}
+
+// trigger is a trigger node of a keygroup and the size of its record.
+type trigger struct {
+	host string
+	size int64
+}
+
+// index is what the log contains. Keygroups and trigger nodes are kept in memory completely, items only with where
+// their values are. garbage is the number of bytes in the log that are no longer needed.
+type index struct {
+	items     map[string]map[string]entry
+	keygroups map[string]int64
+	triggers  map[string]map[string]trigger
+	garbage   int64
+}
+
+func newIndex() *index {
+	return &index{
+		items:     make(map[string]map[string]entry),
+		keygroups: make(map[string]int64),
+		triggers:  make(map[string]map[string]trigger),
+	}
+}
+
+// apply adds a record that was written to the log at off with size bytes to the index.
+func (x *index) apply(r record, off int64, size int64) {
+	switch r.op {
+	case opUpdate:
+		items, ok := x.items[r.kg]
+
+		if !ok {
+			items = make(map[string]entry)
+			x.items[r.kg] = items
+		}
+
+		if old, ok := items[r.id]; ok {
+			x.garbage += old.size
+		}
+
+		items[r.id] = entry{
+			off:       off + size - int64(len(r.val)),
+			len:       len(r.val),
+			expiresAt: r.expiresAt,
+			size:      size,
+		}
+	case opDelete:
+		if old, ok := x.items[r.kg][r.id]; ok {
+			x.garbage += old.size
+			delete(x.items[r.kg], r.id)
+		}
+
+		x.garbage += size
+	case opCreateKeygroup:
+		if old, ok := x.keygroups[r.kg]; ok {
+			x.garbage += old
+		}
+
+		x.keygroups[r.kg] = size
+	case opDeleteKeygroup:
+		x.garbage += x.keygroups[r.kg] + size
+
+		for _, e := range x.items[r.kg] {
+			x.garbage += e.size
+		}
+
+		for _, t := range x.triggers[r.kg] {
+			x.garbage += t.size
+		}
+
+		delete(x.keygroups, r.kg)
+		delete(x.items, r.kg)
+		delete(x.triggers, r.kg)
+	case opAddTrigger:
+		triggers, ok := x.triggers[r.kg]
+
+		if !ok {
+			triggers = make(map[string]trigger)
+			x.triggers[r.kg] = triggers
+		}
+
+		if old, ok := triggers[r.id]; ok {
+			x.garbage += old.size
+		}
+
+		triggers[r.id] = trigger{host: r.val, size: size}
+	case opDeleteTrigger:
+		if old, ok := x.triggers[r.kg][r.id]; ok {
+			x.garbage += old.size
+			delete(x.triggers[r.kg], r.id)
+		}
+
+		x.garbage += size
+	}
+}
+
+// Storage is a store in a log file. Writes are not synced to disk one by one, so the last writes before a power
+// failure may be lost, but the log stays readable: incomplete or corrupt records at its end are discarded when the
+// store is opened.
+type Storage struct {
+	path string
+	mu   sync.RWMutex
+	f    *os.File
+	size int64
+	idx  *index
+	done chan struct{}
+}
+
+// New opens the store in the directory at path, which is created if it does not exist.
+func New(path string) (*Storage, error) {
+	if err := os.MkdirAll(path, 0700); err != nil {
+		return nil, errors.New(err)
+	}
+
+	// a compaction that was interrupted leaves the old log intact
+	if err := os.Remove(filepath.Join(path, compactName)); err != nil && !os.IsNotExist(err) {
+		return nil, errors.New(err)
+	}
+
+	f, err := os.OpenFile(filepath.Join(path, logName), os.O_RDWR|os.O_CREATE, 0600)
+
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	s := &Storage{
+		path: path,
+		f:    f,
+		idx:  newIndex(),
+		done: make(chan struct{}),
+	}
+
+	if err := s.load(); err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+
+	go s.compaction()
+
+	return s, nil
+}
+
+// load reads the log into the index. The log is cut off at the first record that is incomplete or corrupt, as it was
+// the last one being written when the node stopped.
+func (s *Storage) load() error {
+	info, err := s.f.Stat()
+
+	if err != nil {
+		return errors.New(err)
+	}
+
+	if info.Size() == 0 {
+		h := append([]byte(magic), version)
+
+		if _, err := s.f.WriteAt(h, 0); err != nil {
+			return errors.New(err)
+		}
+
+		if err := s.f.Sync(); err != nil {
+			return errors.New(err)
+		}
+
+		s.size = int64(len(h))
+
+		return nil
+	}
+
+	r := bufio.NewReader(io.NewSectionReader(s.f, 0, info.Size()))
+	h := make([]byte, fileHeaderSize)
+
+	if _, err := io.ReadFull(r, h); err != nil || string(h[:len(magic)]) != magic {
+		return errors.Errorf("%s is not a FReD log file", s.f.Name())
+	}
+
+	if h[len(magic)] != version {
+		return errors.Errorf("log file %s has version %d but only version %d is supported, it was written by a newer version of FReD", s.f.Name(), h[len(magic)], version)
+	}
+
+	off := int64(fileHeaderSize)
+
+	for {
+		rec, size, err := readRecord(r, info.Size()-off)
+
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			log.Warn().Msgf("file store: discarding the last %d bytes of %s: %v", info.Size()-off, s.f.Name(), err)
+
+			if err := s.f.Truncate(off); err != nil {
+				return errors.New(err)
+			}
+
+			break
+		}
+
+		s.idx.apply(rec, off, size)
+		off += size
+	}
+
+	s.size = off
+
+	return nil
+}
+
+// write appends a record to the log and adds it to the index. Must be called with the write lock held.
+func (s *Storage) write(r record) error {
+	if s.f == nil {
+		return errors.Errorf("file store %s is closed", s.path)
+	}
+
+	b := r.encode()
+
+	if _, err := s.f.WriteAt(b, s.size); err != nil {
+		// a partial record would cut off all later records when the log is loaded again
+		_ = s.f.Truncate(s.size)
+		return errors.New(err)
+	}
+
+	s.idx.apply(r, s.size, int64(len(b)))
+	s.size += int64(len(b))
+
+	return nil
+}
+
+// value reads the value of an item from the log. Must be called with a lock held.
+func (s *Storage) value(e entry) (string, error) {
+	if s.f == nil {
+		return "", errors.Errorf("file store %s is closed", s.path)
+	}
+
+	b := make([]byte, e.len)
+
+	if _, err := s.f.ReadAt(b, e.off); err != nil {
+		return "", errors.New(err)
+	}
+
+	return string(b), nil
+}
+
+// lookup returns the entry of an item if it exists and has not expired. Must be called with a lock held.
+func (s *Storage) lookup(kg string, id string) (entry, bool) {
+	e, ok := s.idx.items[kg][id]
+
+	if !ok || e.expired(time.Now().Unix()) {
+		return entry{}, false
+	}
+
+	return e, true
+}
+
+// ids returns the sorted IDs of all items in a keygroup that have not expired. Must be called with a lock held.
+func (s *Storage) ids(kg string) []string {
+	var ids []string
+	now := time.Now().Unix()
+
+	for id, e := range s.idx.items[kg] {
+		if !e.expired(now) {
+			ids = append(ids, id)
+		}
+	}
+
+	sort.Strings(ids)
+
+	return ids
+}
+
+// expiresAt returns when an item with the given expiry in seconds expires, or 0 if it does not.
+func expiresAt(expiry int) int64 {
+	if expiry > 0 {
+		return time.Now().Unix() + int64(expiry)
+	}
+
+	return 0
+}
+
+// compaction compacts the log on a schedule until the store is closed.
+func (s *Storage) compaction() {
+	ticker := time.NewTicker(compactInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+			if err := s.compact(false); err != nil {
+				log.Error().Msgf("file store: compaction of %s failed: %s", s.path, err.(*errors.Error).ErrorStack())
+			}
+		}
+	}
+}
+
+// compact writes a new log with only the records that are still needed and replaces the old log with it, unless force
+// is false and there is not enough garbage in the log. Writes wait until compaction is done.
+func (s *Storage) compact(force bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.f == nil {
+		return nil
+	}
+
+	now := time.Now().Unix()
+	garbage := s.idx.garbage
+
+	for _, items := range s.idx.items {
+		for _, e := range items {
+			if e.expired(now) {
+				garbage += e.size
+			}
+		}
+	}
+
+	if !force && (garbage < compactMinGarbage || float64(garbage) < compactGarbageRatio*float64(s.size)) {
+		return nil
+	}
+
+	tmp := filepath.Join(s.path, compactName)
+	f, err := os.OpenFile(tmp, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+
+	if err != nil {
+		return errors.New(err)
+	}
+
+	idx, size, err := s.rewrite(f, now)
+
+	if err == nil {
+		err = f.Sync()
+	}
+
+	if err == nil {
+		err = os.Rename(tmp, filepath.Join(s.path, logName))
+	}
+
+	if err != nil {
+		_ = f.Close()
+		_ = os.Remove(tmp)
+		return errors.New(err)
+	}
+
+	if err := syncDir(s.path); err != nil {
+		log.Warn().Msgf("file store: could not sync %s after compaction: %v", s.path, err)
+	}
+
+	old := s.size
+	_ = s.f.Close()
+
+	s.f = f
+	s.size = size
+	s.idx = idx
+
+	log.Info().Msgf("file store: compacted %s from %d to %d bytes", s.path, old, size)
+
+	return nil
+}
+
+// rewrite writes all keygroups, trigger nodes, and items that have not expired at now to a new log file and returns
+// its index and size. Must be called with the write lock held.
+func (s *Storage) rewrite(f *os.File, now int64) (*index, int64, error) {
+	w := bufio.NewWriter(f)
+	idx := newIndex()
+
+	if _, err := w.Write(append([]byte(magic), version)); err != nil {
+		return nil, 0, err
+	}
+
+	size := int64(fileHeaderSize)
+
+	add := func(r record) error {
+		b := r.encode()
+
+		if _, err := w.Write(b); err != nil {
+			return err
+		}
+
+		idx.apply(r, size, int64(len(b)))
+		size += int64(len(b))
+
+		return nil
+	}
+
+	for kg := range s.idx.keygroups {
+		if err := add(record{op: opCreateKeygroup, kg: kg}); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	for kg, triggers := range s.idx.triggers {
+		for id, t := range triggers {
+			if err := add(record{op: opAddTrigger, kg: kg, id: id, val: t.host}); err != nil {
+				return nil, 0, err
+			}
+		}
+	}
+
+	for kg, items := range s.idx.items {
+		for id, e := range items {
+			if e.expired(now) {
+				continue
+			}
+
+			val, err := s.value(e)
+
+			if err != nil {
+				return nil, 0, err
+			}
+
+			if err := add(record{op: opUpdate, expiresAt: e.expiresAt, kg: kg, id: id, val: val}); err != nil {
+				return nil, 0, err
+			}
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return nil, 0, err
+	}
+
+	return idx, size, nil
+}
+
+// syncDir makes a rename in a directory durable.
+func syncDir(path string) error {
+	d, err := os.Open(path)
+
+	if err != nil {
+		return err
+	}
+
+	defer d.Close()
+
+	return d.Sync()
+}
+
+// Close syncs the log to disk and closes it.
+func (s *Storage) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.f == nil {
+		return errors.Errorf("file store %s is closed", s.path)
+	}
+
+	close(s.done)
+
+	err := s.f.Sync()
+
+	if cerr := s.f.Close(); err == nil {
+		err = cerr
+	}
+
+	s.f = nil
+
+	if err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+// Read returns an item with the specified id from the specified keygroup.
+func (s *Storage) Read(kg string, id string) (string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	e, ok := s.lookup(kg, id)
+
+	if !ok {
+		return "", errors.Errorf("key not found in database: %s in keygroup %s", id, kg)
+	}
+
+	return s.value(e)
+}
+
+// ReadSome returns count number of items in the specified keygroup starting at id.
+func (s *Storage) ReadSome(kg, id string, count uint64) (map[string]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	items := make(map[string]string)
+	ids := s.ids(kg)
+
+	for i := sort.SearchStrings(ids, id); i < len(ids) && uint64(len(items)) < count; i++ {
+		val, err := s.value(s.idx.items[kg][ids[i]])
+
+		if err != nil {
+			return nil, err
+		}
+
+		items[ids[i]] = val
+	}
+
+	return items, nil
+}
+
+// ReadAll returns all items in the specified keygroup.
+func (s *Storage) ReadAll(kg string) (map[string]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	items := make(map[string]string)
+
+	for _, id := range s.ids(kg) {
+		val, err := s.value(s.idx.items[kg][id])
+
+		if err != nil {
+			return nil, err
+		}
+
+		items[id] = val
+	}
+
+	return items, nil
+}
+
+// Export calls fn for every item in the specified keygroup with the time its expiry passes as a Unix timestamp, or 0
+// if it does not expire. All items are read before fn is called for the first one, so concurrent writes are either
+// fully part of the export or not at all.
+func (s *Storage) Export(kg string, fn func(id string, val string, expiresAt int64) error) error {
+	type item struct {
+		id        string
+		val       string
+		expiresAt int64
+	}
+
+	var items []item
+
+	err := func() error {
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+
+		for _, id := range s.ids(kg) {
+			e := s.idx.items[kg][id]
+			val, err := s.value(e)
+
+			if err != nil {
+				return err
+			}
+
+			items = append(items, item{id: id, val: val, expiresAt: e.expiresAt})
+		}
+
+		return nil
+	}()
+
+	if err != nil {
+		return err
+	}
+
+	for _, i := range items {
+		if err := fn(i.id, i.val, i.expiresAt); err != nil {
+			return errors.New(err)
+		}
+	}
+
+	return nil
+}
+
+// IDs returns the keys of all items in the specified keygroup.
+func (s *Storage) IDs(kg string) ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.f == nil {
+		return nil, errors.Errorf("file store %s is closed", s.path)
+	}
+
+	return s.ids(kg), nil
+}
+
+// Update updates the item with the specified id in the specified keygroup.
+func (s *Storage) Update(kg, id, val string, _ bool, expiry int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.write(record{op: opUpdate, expiresAt: expiresAt(expiry), kg: kg, id: id, val: val})
+}
+
+// Delete deletes the item with the specified id from the specified keygroup.
+func (s *Storage) Delete(kg string, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.idx.items[kg][id]; !ok {
+		return nil
+	}
+
+	return s.write(record{op: opDelete, kg: kg, id: id})
+}
+
+// Append adds a new item with the specified id to the specified keygroup. It fails if the item already exists.
+func (s *Storage) Append(kg, id, val string, expiry int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.lookup(kg, id); ok {
+		return errors.Errorf("item %s already exists in keygroup %s", id, kg)
+	}
+
+	return s.write(record{op: opUpdate, expiresAt: expiresAt(expiry), kg: kg, id: id, val: val})
+}
+
+// Exists checks if the given data item exists in the file store.
+func (s *Storage) Exists(kg string, id string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, ok := s.lookup(kg, id)
+
+	return ok
+}
+
+// ExistsKeygroup checks if the given keygroup exists in the file store.
+func (s *Storage) ExistsKeygroup(kg string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, ok := s.idx.keygroups[kg]
+
+	return ok
+}
+
+// Keygroups returns the names of all keygroups in the file store.
+func (s *Storage) Keygroups() ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var kgs []string
+
+	for kg := range s.idx.keygroups {
+		kgs = append(kgs, kg)
+	}
+
+	sort.Strings(kgs)
+
+	return kgs, nil
+}
+
+// CreateKeygroup creates the given keygroup in the file store.
+func (s *Storage) CreateKeygroup(kg string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.idx.keygroups[kg]; ok {
+		return nil
+	}
+
+	return s.write(record{op: opCreateKeygroup, kg: kg})
+}
+
+// DeleteKeygroup deletes the given keygroup with all its items and trigger nodes from the file store.
+func (s *Storage) DeleteKeygroup(kg string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, kgOk := s.idx.keygroups[kg]
+	_, itemsOk := s.idx.items[kg]
+	_, triggersOk := s.idx.triggers[kg]
+
+	if !kgOk && !itemsOk && !triggersOk {
+		return nil
+	}
+
+	return s.write(record{op: opDeleteKeygroup, kg: kg})
+}
+
+// AddKeygroupTrigger adds a trigger node to the given keygroup in the file store.
+func (s *Storage) AddKeygroupTrigger(kg string, id string, host string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.write(record{op: opAddTrigger, kg: kg, id: id, val: host})
+}
+
+// DeleteKeygroupTrigger removes a trigger node from the given keygroup in the file store.
+func (s *Storage) DeleteKeygroupTrigger(kg string, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.idx.triggers[kg][id]; !ok {
+		return nil
+	}
+
+	return s.write(record{op: opDeleteTrigger, kg: kg, id: id})
+}
+
+// GetKeygroupTrigger returns a list of all trigger nodes for the given keygroup in the file store.
+func (s *Storage) GetKeygroupTrigger(kg string) (map[string]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	items := make(map[string]string)
+
+	for id, t := range s.idx.triggers[kg] {
+		items[id] = t.host
+	}
+
+	return items, nil
+}
@@ -0,0 +1,923 @@
+package filestore
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/stretchr/testify/assert"
+)
+
+const testPath = "./test.db"
+
+var db *Storage
+
+func TestMain(m *testing.M) {
+	zerolog.SetGlobalLevel(zerolog.DebugLevel)
+
+	fInfo, err := os.Stat(testPath)
+
+	if err == nil {
+		if !fInfo.IsDir() {
+			panic(errors.Errorf("%s is not a directory!", testPath))
+		}
+
+		err = os.RemoveAll(testPath)
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	db, err = New(testPath)
+
+	if err != nil {
+		panic(err)
+	}
+
+	stat := m.Run()
+
+	fInfo, err = os.Stat(testPath)
+
+	if err == nil {
+		if !fInfo.IsDir() {
+			panic(errors.Errorf("%s is not a directory!", testPath))
+		}
+
+		err = os.RemoveAll(testPath)
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	os.Exit(stat)
+}
+
+func TestKeygroups(t *testing.T) {
+	kg := "test-kg"
+	err := db.CreateKeygroup(kg)
+
+	if err != nil {
+		log.Err(err).Msg(err.(*errors.Error).ErrorStack())
+	}
+
+	exists := db.ExistsKeygroup(kg)
+	if !exists {
+		t.Fatal("Keygroup does not exist after creation")
+	}
+
+	kgs, err := db.Keygroups()
+	assert.NoError(t, err)
+	assert.Contains(t, kgs, kg)
+
+	err = db.DeleteKeygroup(kg)
+
+	if err != nil {
+		log.Err(err).Msg(err.(*errors.Error).ErrorStack())
+	}
+
+	exists = db.ExistsKeygroup(kg)
+	if exists {
+		t.Fatal("Keygroup does still exist after deletion")
+	}
+
+	kgs, err = db.Keygroups()
+	assert.NoError(t, err)
+	assert.NotContains(t, kgs, kg)
+}
+
+func TestReadSome(t *testing.T) {
+	kg := "test-kg-scan"
+	updates := 10
+	scanStart := 3
+	scanRange := 5
+
+	err := db.CreateKeygroup(kg)
+
+	if err != nil {
+		log.Err(err).Msg(err.(*errors.Error).ErrorStack())
+		t.Error(err)
+	}
+
+	// 2. put in a bunch of items
+	ids := make([]string, updates)
+	vals := make([]string, updates)
+
+	for i := 0; i < updates; i++ {
+		ids[i] = "id" + strconv.Itoa(i)
+		vals[i] = "val" + strconv.Itoa(i)
+
+		err = db.Update(kg, ids[i], vals[i], false, 0)
+
+		if err != nil {
+			log.Err(err).Msg(err.(*errors.Error).ErrorStack())
+			t.Error(err)
+		}
+
+	}
+
+	res, err := db.ReadSome(kg, "id"+strconv.Itoa(scanStart), uint64(scanRange))
+
+	if err != nil {
+		log.Err(err).Msg(err.(*errors.Error).ErrorStack())
+		t.Error(err)
+	}
+
+	assert.Len(t, res, scanRange)
+
+	for i := scanStart; i < scanStart+scanRange; i++ {
+		assert.Contains(t, res, ids[i])
+		assert.Equal(t, res[ids[i]], vals[i])
+	}
+}
+
+func TestReadAll(t *testing.T) {
+	kg := "test-read-all"
+	err := db.CreateKeygroup(kg)
+
+	if err != nil {
+		log.Err(err).Msg(err.(*errors.Error).ErrorStack())
+		t.Error(err)
+	}
+
+	err = db.Update(kg, "id-1", "data-1", false, 0)
+
+	if err != nil {
+		log.Err(err).Msg(err.(*errors.Error).ErrorStack())
+		t.Error(err)
+	}
+
+	err = db.Update(kg, "id-2", "data-2", false, 0)
+
+	if err != nil {
+		log.Err(err).Msg(err.(*errors.Error).ErrorStack())
+		t.Error(err)
+	}
+
+	err = db.Update(kg, "id-3", "data-3", false, 0)
+
+	if err != nil {
+		log.Err(err).Msg(err.(*errors.Error).ErrorStack())
+		t.Error(err)
+	}
+
+	kg2 := "test-read-all-2"
+
+	err = db.CreateKeygroup(kg2)
+
+	if err != nil {
+		log.Err(err).Msg(err.(*errors.Error).ErrorStack())
+		t.Error(err)
+	}
+
+	err = db.Update(kg2, "id-1", "data-1", false, 0)
+
+	if err != nil {
+		log.Err(err).Msg(err.(*errors.Error).ErrorStack())
+		t.Error(err)
+	}
+
+	err = db.Update(kg2, "id-2", "data-2", false, 0)
+
+	if err != nil {
+		log.Err(err).Msg(err.(*errors.Error).ErrorStack())
+		t.Error(err)
+	}
+
+	err = db.Update(kg2, "id-3", "data-3", false, 0)
+
+	if err != nil {
+		log.Err(err).Msg(err.(*errors.Error).ErrorStack())
+		t.Error(err)
+	}
+
+	res, err := db.ReadAll(kg)
+
+	if err != nil {
+		log.Err(err).Msg(err.(*errors.Error).ErrorStack())
+		t.Error(err)
+	}
+
+	assert.Equal(t, "data-1", res["id-1"])
+	assert.Equal(t, "data-2", res["id-2"])
+	assert.Equal(t, "data-3", res["id-3"])
+
+}
+
+func TestIDs(t *testing.T) {
+	kg := "test-ids"
+	err := db.CreateKeygroup(kg)
+
+	if err != nil {
+		log.Err(err).Msg(err.(*errors.Error).ErrorStack())
+		t.Error(err)
+	}
+
+	err = db.Update(kg, "id-1", "data-1", false, 0)
+
+	if err != nil {
+		log.Err(err).Msg(err.(*errors.Error).ErrorStack())
+		t.Error(err)
+	}
+
+	err = db.Update(kg, "id-2", "data-2", false, 0)
+
+	if err != nil {
+		log.Err(err).Msg(err.(*errors.Error).ErrorStack())
+		t.Error(err)
+	}
+
+	err = db.Update(kg, "id-3", "data-3", false, 0)
+
+	if err != nil {
+		log.Err(err).Msg(err.(*errors.Error).ErrorStack())
+		t.Error(err)
+	}
+
+	kg2 := "test-read-all-2"
+
+	err = db.CreateKeygroup(kg2)
+
+	if err != nil {
+		log.Err(err).Msg(err.(*errors.Error).ErrorStack())
+		t.Error(err)
+	}
+
+	err = db.Update(kg2, "id-1", "data-1", false, 0)
+
+	if err != nil {
+		log.Err(err).Msg(err.(*errors.Error).ErrorStack())
+		t.Error(err)
+	}
+
+	err = db.Update(kg2, "id-2", "data-2", false, 0)
+
+	if err != nil {
+		log.Err(err).Msg(err.(*errors.Error).ErrorStack())
+		t.Error(err)
+	}
+
+	err = db.Update(kg2, "id-3", "data-3", false, 0)
+
+	if err != nil {
+		log.Err(err).Msg(err.(*errors.Error).ErrorStack())
+		t.Error(err)
+	}
+
+	res, err := db.IDs(kg)
+
+	if err != nil {
+		log.Err(err).Msg(err.(*errors.Error).ErrorStack())
+		t.Error(err)
+	}
+
+	assert.Equal(t, []string{"id-1", "id-2", "id-3"}, res)
+
+}
+
+func TestItemExists(t *testing.T) {
+	kg := "test-kg-item"
+	id := "name"
+	id2 := "name2"
+	value := "value"
+
+	err := db.CreateKeygroup(kg)
+
+	if err != nil {
+		log.Err(err).Msg(err.(*errors.Error).ErrorStack())
+		t.Error(err)
+	}
+
+	err = db.Update(kg, id, value, false, 0)
+
+	if err != nil {
+		log.Err(err).Msg(err.(*errors.Error).ErrorStack())
+		t.Error(err)
+	}
+
+	ex := db.Exists(kg, id)
+	if !ex {
+		t.Error("exists says existing item doesn't exist")
+	}
+
+	ex = db.Exists(kg, id2)
+	if ex {
+		t.Error("exists says non-existent item exists")
+	}
+
+}
+
+func TestItemGet(t *testing.T) {
+	kg := "test-kg-item"
+	id := "name"
+	value := "value"
+
+	err := db.CreateKeygroup(kg)
+
+	if err != nil {
+		log.Err(err).Msg(err.(*errors.Error).ErrorStack())
+		t.Error(err)
+	}
+
+	err = db.Update(kg, id, value, false, 0)
+
+	if err != nil {
+		log.Err(err).Msg(err.(*errors.Error).ErrorStack())
+		t.Error(err)
+	}
+
+	retr, err := db.Read(kg, id)
+	if err != nil {
+		t.Error(err)
+	}
+	if retr != value {
+		t.Errorf("Expected to get %s but got %s", value, retr)
+	}
+}
+
+func TestItemDelete(t *testing.T) {
+	kg := "test-kg-item-delete"
+	id := "name"
+	id2 := "name2"
+	value := "value"
+
+	err := db.CreateKeygroup(kg)
+
+	if err != nil {
+		log.Err(err).Msg(err.(*errors.Error).ErrorStack())
+		t.Error(err)
+	}
+
+	err = db.Update(kg, id, value, false, 0)
+
+	if err != nil {
+		log.Err(err).Msg(err.(*errors.Error).ErrorStack())
+		t.Error(err)
+	}
+
+	retr, err := db.Read(kg, id)
+	if err != nil {
+		t.Error(err)
+	}
+	if retr != value {
+		t.Errorf("Expected to get %s but got %s", value, retr)
+	}
+
+	err = db.Delete(kg, id)
+
+	if err != nil {
+		log.Err(err).Msg(err.(*errors.Error).ErrorStack())
+		t.Error(err)
+	}
+
+	retr, err = db.Read(kg, id)
+	if err == nil {
+		t.Errorf("read a deleted item: %s", retr)
+	}
+
+	err = db.Delete(kg, id2)
+
+	if err != nil {
+		t.Error(err, "deleting non-existent keys should be allowed")
+	}
+
+}
+
+func TestItemAfterDeleteKeygroup(t *testing.T) {
+	kg := "test-kg-item-delete"
+	id := "ndel"
+	value := "vdel"
+
+	err := db.CreateKeygroup(kg)
+
+	if err != nil {
+		log.Err(err).Msg(err.(*errors.Error).ErrorStack())
+		t.Error(err)
+	}
+
+	err = db.Update(kg, id, value, false, 0)
+
+	if err != nil {
+		log.Err(err).Msg(err.(*errors.Error).ErrorStack())
+		t.Error(err)
+	}
+
+	err = db.DeleteKeygroup(kg)
+
+	if err != nil {
+		log.Err(err).Msg(err.(*errors.Error).ErrorStack())
+		t.Error(err)
+	}
+
+	retr, err := db.Read(kg, id)
+	if err == nil {
+		t.Errorf("Expected an error, but got %s", retr)
+	}
+}
+
+func TestExpiry(t *testing.T) {
+	kg := "test-kg-item"
+	id := "name"
+	value := "value"
+
+	err := db.CreateKeygroup(kg)
+
+	if err != nil {
+		log.Err(err).Msg(err.(*errors.Error).ErrorStack())
+		t.Error(err)
+	}
+
+	err = db.Update(kg, id, value, false, 10)
+
+	if err != nil {
+		log.Err(err).Msg(err.(*errors.Error).ErrorStack())
+		t.Error(err)
+	}
+
+	retr, err := db.Read(kg, id)
+	if err != nil {
+		t.Error(err)
+	}
+	if retr != value {
+		t.Errorf("Expected to get %s but got %s", value, retr)
+	}
+
+	time.Sleep(10 * time.Second)
+
+	_, err = db.Read(kg, id)
+	if err == nil {
+		t.Error(err)
+	}
+}
+
+func TestAppend(t *testing.T) {
+	kg := "log"
+
+	err := db.CreateKeygroup(kg)
+
+	if err != nil {
+		log.Err(err).Msg(err.(*errors.Error).ErrorStack())
+		t.Error(err)
+	}
+
+	for i := 0; i < 100; i++ {
+		id := fmt.Sprintf("%020d-nodeA", i)
+		v := "value-" + strconv.Itoa(i)
+
+		err := db.Append(kg, id, v, 0)
+
+		if err != nil {
+			t.Error(err)
+		}
+
+		val, err := db.Read(kg, id)
+		assert.NoError(t, err)
+		assert.Equal(t, v, val)
+	}
+
+	// appended items cannot be overwritten by another append
+	err = db.Append(kg, fmt.Sprintf("%020d-nodeA", 0), "value-new", 0)
+	assert.Error(t, err)
+
+	val, err := db.Read(kg, fmt.Sprintf("%020d-nodeA", 0))
+	assert.NoError(t, err)
+	assert.Equal(t, "value-0", val)
+}
+
+func TestConcurrentAppend(t *testing.T) {
+	kg := "logconcurrent"
+	concurrent := 4
+	items := 100
+
+	err := db.CreateKeygroup(kg)
+
+	if err != nil {
+		log.Err(err).Msg(err.(*errors.Error).ErrorStack())
+		t.Error(err)
+	}
+
+	// all goroutines try to append the same ids, but each id may only be appended once
+	succeeded := make([]int, concurrent)
+	done := make(chan struct{})
+
+	for i := 0; i < concurrent; i++ {
+		go func(id int) {
+			for j := 0; j < items; j++ {
+				v := fmt.Sprintf("value-%d-%d", id, j)
+
+				if err := db.Append(kg, strconv.Itoa(j), v, 0); err == nil {
+					succeeded[id]++
+				}
+			}
+			done <- struct{}{}
+		}(i)
+	}
+
+	for i := 0; i < concurrent; i++ {
+		<-done
+	}
+
+	total := 0
+
+	for _, n := range succeeded {
+		total += n
+	}
+
+	assert.Equal(t, items, total)
+}
+
+func TestTriggerNodes(t *testing.T) {
+	kg := "kg1"
+
+	err := db.CreateKeygroup(kg)
+
+	if err != nil {
+		t.Error(err)
+	}
+
+	t1 := "t1"
+	t1host := "1.1.1.1:3000"
+
+	t2 := "t2"
+	t2host := "2.2.2.2:3000"
+
+	t3 := "t3"
+	t3host := "3.3.3.3:3000"
+
+	err = db.AddKeygroupTrigger(kg, t1, t1host)
+
+	if err != nil {
+		t.Error(err)
+	}
+
+	err = db.AddKeygroupTrigger(kg, t1, t1host)
+
+	if err != nil {
+		t.Error(err)
+	}
+
+	err = db.AddKeygroupTrigger(kg, t2, t2host)
+
+	if err != nil {
+		t.Error(err)
+	}
+
+	tList, err := db.GetKeygroupTrigger(kg)
+
+	if err != nil {
+		t.Error(err)
+	}
+
+	log.Debug().Msgf("List of keygroup triggers: %#v", tList)
+
+	if len(tList) != 2 {
+		t.Error("not the right number of triggers for this keygroup")
+	}
+
+	if _, ok := tList[t1]; !ok {
+		t.Error("t1 not in list of triggers for this keygroup")
+	}
+
+	if _, ok := tList[t2]; !ok {
+		t.Error("t2 not in list of triggers for this keygroup")
+	}
+
+	if host := tList[t1]; host != t1host {
+		t.Error("t1host not correct")
+	}
+
+	if host := tList[t2]; host != t2host {
+		t.Error("t1host not correct")
+	}
+
+	err = db.DeleteKeygroupTrigger(kg, t1)
+
+	if err != nil {
+		t.Error(err)
+	}
+
+	tList, err = db.GetKeygroupTrigger(kg)
+
+	if err != nil {
+		t.Error(err)
+	}
+
+	log.Debug().Msgf("List of keygroup triggers: %#v", tList)
+
+	if len(tList) != 1 {
+		t.Error("not the right number of triggers for this keygroup")
+	}
+
+	if _, ok := tList[t2]; !ok {
+		t.Error("t2 not in list of triggers for this keygroup")
+	}
+
+	if host := tList[t2]; host != t2host {
+		t.Error("t1host not correct")
+	}
+
+	err = db.AddKeygroupTrigger(kg, t3, t3host)
+
+	if err != nil {
+		t.Error(err)
+	}
+
+	err = db.DeleteKeygroup(kg)
+
+	if err != nil {
+		t.Error(err)
+	}
+
+	tList, _ = db.GetKeygroupTrigger(kg)
+
+	log.Debug().Msgf("List of keygroup triggers: %#v", tList)
+
+	if len(tList) != 0 {
+		t.Error("got keygroup triggers for nonexistent keygroup")
+	}
+
+}
+
+func TestArbitraryIDs(t *testing.T) {
+	kg := "test-kg|ids"
+	other := "test-kg"
+
+	assert.NoError(t, db.CreateKeygroup(kg))
+	assert.NoError(t, db.CreateKeygroup(other))
+
+	ids := []string{"a|b", "alice@example.com", "/home/alice", "123e4567-e89b-12d3-a456-426614174000", "ünïcödé ✓", "|"}
+
+	for _, id := range ids {
+		assert.NoError(t, db.Update(kg, id, "val "+id, false, 0))
+	}
+
+	assert.NoError(t, db.Update(other, "ids|a|b", "other", false, 0))
+	assert.NoError(t, db.AddKeygroupTrigger(kg, "t|1", "host"))
+
+	for _, id := range ids {
+		assert.True(t, db.Exists(kg, id))
+
+		val, err := db.Read(kg, id)
+		assert.NoError(t, err)
+		assert.Equal(t, "val "+id, val)
+	}
+
+	all, err := db.ReadAll(kg)
+	assert.NoError(t, err)
+	assert.Len(t, all, len(ids))
+
+	some, err := db.ReadSome(kg, "a", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"a|b": "val a|b", "alice@example.com": "val alice@example.com"}, some)
+
+	triggers, err := db.GetKeygroupTrigger(kg)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"t|1": "host"}, triggers)
+
+	// deleting one keygroup leaves the other alone
+	assert.NoError(t, db.DeleteKeygroup(kg))
+	assert.False(t, db.Exists(kg, "a|b"))
+	assert.True(t, db.Exists(other, "ids|a|b"))
+}
+
+func TestExport(t *testing.T) {
+	kg := "test-export"
+
+	assert.NoError(t, db.CreateKeygroup(kg))
+	assert.NoError(t, db.Update(kg, "forever", "1", false, 0))
+	assert.NoError(t, db.Update(kg, "expiring", "2", false, 100))
+
+	exported := make(map[string]int64)
+
+	err := db.Export(kg, func(id string, val string, expiresAt int64) error {
+		exported[id] = expiresAt
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, exported, 2)
+	assert.Equal(t, int64(0), exported["forever"])
+	assert.InDelta(t, time.Now().Unix()+100, exported["expiring"], 5)
+
+	// errors stop the export
+	err = db.Export(kg, func(id string, val string, expiresAt int64) error {
+		return errors.New("stop")
+	})
+
+	assert.Error(t, err)
+}
+
+func TestClose(t *testing.T) {
+	kg := "test-kg-item"
+	id := "name"
+	value := "value"
+
+	err := db.CreateKeygroup(kg)
+
+	if err != nil {
+		log.Err(err).Msg(err.(*errors.Error).ErrorStack())
+		t.Error(err)
+	}
+
+	err = db.Update(kg, id, value, false, 0)
+
+	if err != nil {
+		log.Err(err).Msg(err.(*errors.Error).ErrorStack())
+		t.Error(err)
+	}
+
+	retr, err := db.Read(kg, id)
+	if err != nil {
+		t.Error(err)
+	}
+	if retr != value {
+		t.Errorf("Expected to get %s but got %s", value, retr)
+	}
+
+	err = db.Close()
+
+	if err != nil {
+		t.Error(err)
+	}
+
+	_, err = db.Read(kg, id)
+	assert.Error(t, err)
+
+}
+
+func TestReopen(t *testing.T) {
+	path := t.TempDir()
+
+	s, err := New(path)
+	assert.NoError(t, err)
+
+	assert.NoError(t, s.CreateKeygroup("kg"))
+	assert.NoError(t, s.CreateKeygroup("gone"))
+	assert.NoError(t, s.Update("kg", "id1", "val1", false, 0))
+	assert.NoError(t, s.Update("kg", "id2", "val2", false, 0))
+	assert.NoError(t, s.Update("kg", "id2", "val2-new", false, 0))
+	assert.NoError(t, s.Update("kg", "expires", "val3", false, 100))
+	assert.NoError(t, s.Update("kg", "deleted", "val4", false, 0))
+	assert.NoError(t, s.Delete("kg", "deleted"))
+	assert.NoError(t, s.AddKeygroupTrigger("kg", "t1", "host1"))
+	assert.NoError(t, s.DeleteKeygroup("gone"))
+	assert.NoError(t, s.Close())
+
+	s, err = New(path)
+	assert.NoError(t, err)
+
+	kgs, err := s.Keygroups()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"kg"}, kgs)
+
+	all, err := s.ReadAll("kg")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"id1": "val1", "id2": "val2-new", "expires": "val3"}, all)
+
+	exported := make(map[string]int64)
+	assert.NoError(t, s.Export("kg", func(id string, val string, expiresAt int64) error {
+		exported[id] = expiresAt
+		return nil
+	}))
+	assert.InDelta(t, time.Now().Unix()+100, exported["expires"], 5)
+
+	triggers, err := s.GetKeygroupTrigger("kg")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"t1": "host1"}, triggers)
+
+	assert.NoError(t, s.Close())
+}
+
+func TestRecovery(t *testing.T) {
+	path := t.TempDir()
+	file := filepath.Join(path, logName)
+
+	s, err := New(path)
+	assert.NoError(t, err)
+
+	assert.NoError(t, s.CreateKeygroup("kg"))
+	assert.NoError(t, s.Update("kg", "id1", "val1", false, 0))
+	assert.NoError(t, s.Close())
+
+	info, err := os.Stat(file)
+	assert.NoError(t, err)
+	good := info.Size()
+
+	// a record that was only partly written when the node stopped is discarded
+	s, err = New(path)
+	assert.NoError(t, err)
+	assert.NoError(t, s.Update("kg", "id2", "val2", false, 0))
+	assert.NoError(t, s.Close())
+
+	assert.NoError(t, os.Truncate(file, good+10))
+
+	s, err = New(path)
+	assert.NoError(t, err)
+
+	all, err := s.ReadAll("kg")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"id1": "val1"}, all)
+
+	// so that later writes are not lost behind it
+	assert.NoError(t, s.Update("kg", "id3", "val3", false, 0))
+	assert.NoError(t, s.Close())
+
+	s, err = New(path)
+	assert.NoError(t, err)
+
+	all, err = s.ReadAll("kg")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"id1": "val1", "id3": "val3"}, all)
+
+	assert.NoError(t, s.Close())
+
+	// a record whose checksum does not match is discarded as well
+	b, err := ioutil.ReadFile(file)
+	assert.NoError(t, err)
+	b[len(b)-1] ^= 0xff
+	assert.NoError(t, ioutil.WriteFile(file, b, 0600))
+
+	s, err = New(path)
+	assert.NoError(t, err)
+
+	all, err = s.ReadAll("kg")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"id1": "val1"}, all)
+
+	assert.NoError(t, s.Close())
+
+	// files that are not a log are not touched
+	assert.NoError(t, ioutil.WriteFile(file, []byte("something else"), 0600))
+
+	_, err = New(path)
+	assert.Error(t, err)
+}
+
+func TestCompaction(t *testing.T) {
+	path := t.TempDir()
+	file := filepath.Join(path, logName)
+
+	s, err := New(path)
+	assert.NoError(t, err)
+
+	assert.NoError(t, s.CreateKeygroup("kg"))
+	assert.NoError(t, s.AddKeygroupTrigger("kg", "t1", "host1"))
+
+	for i := 0; i < 100; i++ {
+		assert.NoError(t, s.Update("kg", "id", "val-"+strconv.Itoa(i), false, 0))
+	}
+
+	assert.NoError(t, s.Update("kg", "expires", "soon", false, 1))
+	assert.NoError(t, s.Update("kg", "deleted", "val", false, 0))
+	assert.NoError(t, s.Delete("kg", "deleted"))
+
+	// there is not enough garbage yet
+	info, err := os.Stat(file)
+	assert.NoError(t, err)
+	before := info.Size()
+
+	assert.NoError(t, s.compact(false))
+
+	info, err = os.Stat(file)
+	assert.NoError(t, err)
+	assert.Equal(t, before, info.Size())
+
+	time.Sleep(2 * time.Second)
+
+	assert.NoError(t, s.compact(true))
+
+	info, err = os.Stat(file)
+	assert.NoError(t, err)
+	assert.Less(t, info.Size(), before)
+	assert.Equal(t, int64(0), s.idx.garbage)
+
+	// the store can still be used and opened again after compaction
+	assert.NoError(t, s.Update("kg", "id2", "val2", false, 0))
+
+	all, err := s.ReadAll("kg")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"id": "val-99", "id2": "val2"}, all)
+
+	assert.NoError(t, s.Close())
+
+	s, err = New(path)
+	assert.NoError(t, err)
+
+	assert.True(t, s.ExistsKeygroup("kg"))
+
+	all, err = s.ReadAll("kg")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"id": "val-99", "id2": "val2"}, all)
+
+	triggers, err := s.GetKeygroupTrigger("kg")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"t1": "host1"}, triggers)
+
+	assert.NoError(t, s.Close())
+}
@@ -0,0 +1,292 @@
+package fred
+
+import (
+	"io"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// AuditEntry is a record of a single client operation.
+// ID is what the operation was about within the keygroup, e.g., the item, replica node, trigger node, or user.
+type AuditEntry struct {
+	Time     time.Time    `json:"time"`
+	Node     NodeID       `json:"node"`
+	User     string       `json:"user"`
+	Method   Method       `json:"method"`
+	Keygroup KeygroupName `json:"keygroup"`
+	ID       string       `json:"id,omitempty"`
+	Success  bool         `json:"success"`
+	Error    string       `json:"error,omitempty"`
+}
+
+// AuditQuery selects audit entries for a keygroup. User and Method are optional filters.
+// Limit is the maximum number of entries to return, starting with the most recent one.
+type AuditQuery struct {
+	Keygroup KeygroupName
+	User     string
+	Method   Method
+	Limit    int
+}
+
+// Matches checks whether an entry is selected by the query.
+func (q AuditQuery) Matches(e AuditEntry) bool {
+	if e.Keygroup != q.Keygroup {
+		return false
+	}
+
+	if q.User != "" && e.User != q.User {
+		return false
+	}
+
+	if q.Method != "" && e.Method != q.Method {
+		return false
+	}
+
+	return true
+}
+
+// AuditLog is a sink for audit entries, such as a file or a keygroup.
+type AuditLog interface {
+	// Needs: entry
+	Write(e AuditEntry) error
+	// Needs: query; Returns: matching entries, most recent first
+	Query(q AuditQuery) ([]AuditEntry, error)
+}
+
+// auditedExthandler records every client operation of an ExtHandler in an audit log.
+type auditedExthandler struct {
+	h    ExtHandler
+	l    AuditLog
+	node NodeID
+}
+
+// newAuditedExthandler wraps an ExtHandler so that all client operations are written to an audit log.
+func newAuditedExthandler(h ExtHandler, l AuditLog, node NodeID) *auditedExthandler {
+	return &auditedExthandler{
+		h:    h,
+		l:    l,
+		node: node,
+	}
+}
+
+func (a *auditedExthandler) record(user string, m Method, k KeygroupName, id string, err error) {
+	e := AuditEntry{
+		Time:     time.Now(),
+		Node:     a.node,
+		User:     user,
+		Method:   m,
+		Keygroup: k,
+		ID:       id,
+		Success:  err == nil,
+	}
+
+	if err != nil {
+		e.Error = err.Error()
+	}
+
+	// a failing audit log should not fail the request, but we want to know about it
+	if err := a.l.Write(e); err != nil {
+		log.Err(err).Msgf("could not write audit entry %#v", e)
+	}
+}
+
+func (a *auditedExthandler) WithGroups(groups []string) ExtHandler {
+	return newAuditedExthandler(a.h.WithGroups(groups), a.l, a.node)
+}
+
+// Revision is not audited as it does not touch any data.
+func (a *auditedExthandler) Revision() int64 {
+	return a.h.Revision()
+}
+
+func (a *auditedExthandler) HandleCreateKeygroup(user string, k Keygroup) error {
+	err := a.h.HandleCreateKeygroup(user, k)
+	a.record(user, CreateKeygroup, k.Name, "", err)
+	return err
+}
+
+func (a *auditedExthandler) HandleDeleteKeygroup(user string, k Keygroup) error {
+	err := a.h.HandleDeleteKeygroup(user, k)
+	a.record(user, DeleteKeygroup, k.Name, "", err)
+	return err
+}
+
+func (a *auditedExthandler) HandleRead(user string, i Item) (Item, error) {
+	res, err := a.h.HandleRead(user, i)
+	a.record(user, Read, i.Keygroup, i.ID, err)
+	return res, err
+}
+
+func (a *auditedExthandler) HandleScan(user string, i Item, count uint64) ([]Item, error) {
+	res, err := a.h.HandleScan(user, i, count)
+	a.record(user, Scan, i.Keygroup, i.ID, err)
+	return res, err
+}
+
+func (a *auditedExthandler) HandleUpdate(user string, i Item) error {
+	err := a.h.HandleUpdate(user, i)
+	a.record(user, Update, i.Keygroup, i.ID, err)
+	return err
+}
+
+func (a *auditedExthandler) HandleDelete(user string, i Item) error {
+	err := a.h.HandleDelete(user, i)
+	a.record(user, Delete, i.Keygroup, i.ID, err)
+	return err
+}
+
+func (a *auditedExthandler) HandleAppend(user string, i Item) (Item, error) {
+	res, err := a.h.HandleAppend(user, i)
+	a.record(user, Append, i.Keygroup, res.ID, err)
+	return res, err
+}
+
+func (a *auditedExthandler) HandleAddReplica(user string, k Keygroup, n Node) error {
+	err := a.h.HandleAddReplica(user, k, n)
+	a.record(user, AddReplica, k.Name, string(n.ID), err)
+	return err
+}
+
+func (a *auditedExthandler) HandleGetKeygroupReplica(user string, k Keygroup) ([]Node, map[NodeID]int, error) {
+	nodes, expiries, err := a.h.HandleGetKeygroupReplica(user, k)
+	a.record(user, GetKeygroupReplica, k.Name, "", err)
+	return nodes, expiries, err
+}
+
+func (a *auditedExthandler) HandleRemoveReplica(user string, k Keygroup, n Node) error {
+	err := a.h.HandleRemoveReplica(user, k, n)
+	a.record(user, RemoveReplica, k.Name, string(n.ID), err)
+	return err
+}
+
+// HandleGetReplica is not audited as it is not about a keygroup.
+func (a *auditedExthandler) HandleGetReplica(user string, n Node) (Node, error) {
+	return a.h.HandleGetReplica(user, n)
+}
+
+// HandleGetAllReplica is not audited as it is not about a keygroup.
+func (a *auditedExthandler) HandleGetAllReplica(user string) ([]Node, error) {
+	return a.h.HandleGetAllReplica(user)
+}
+
+func (a *auditedExthandler) HandleGetKeygroupTriggers(user string, k Keygroup) ([]Trigger, error) {
+	res, err := a.h.HandleGetKeygroupTriggers(user, k)
+	a.record(user, GetTrigger, k.Name, "", err)
+	return res, err
+}
+
+func (a *auditedExthandler) HandleAddTrigger(user string, k Keygroup, t Trigger) error {
+	err := a.h.HandleAddTrigger(user, k, t)
+	a.record(user, AddTrigger, k.Name, t.ID, err)
+	return err
+}
+
+func (a *auditedExthandler) HandleGetKeygroupUsage(user string, k Keygroup) (KeygroupUsage, error) {
+	u, err := a.h.HandleGetKeygroupUsage(user, k)
+	a.record(user, GetUsage, k.Name, "", err)
+	return u, err
+}
+
+func (a *auditedExthandler) HandleRemoveTrigger(user string, k Keygroup, t Trigger) error {
+	err := a.h.HandleRemoveTrigger(user, k, t)
+	a.record(user, RemoveTrigger, k.Name, t.ID, err)
+	return err
+}
+
+func (a *auditedExthandler) HandleGetFailedTriggerEvents(user string, k Keygroup, triggerID string) ([]TriggerEvent, error) {
+	res, err := a.h.HandleGetFailedTriggerEvents(user, k, triggerID)
+	a.record(user, GetTriggerEvents, k.Name, triggerID, err)
+	return res, err
+}
+
+func (a *auditedExthandler) HandleReplayTriggerEvents(user string, k Keygroup, triggerID string, seqs []uint64) error {
+	err := a.h.HandleReplayTriggerEvents(user, k, triggerID, seqs)
+	a.record(user, ReplayTriggerEvents, k.Name, triggerID, err)
+	return err
+}
+
+func (a *auditedExthandler) HandleBackup(user string, k Keygroup, w io.Writer) error {
+	err := a.h.HandleBackup(user, k, w)
+	a.record(user, Backup, k.Name, "", err)
+	return err
+}
+
+func (a *auditedExthandler) HandleRestore(user string, r io.Reader) (Keygroup, error) {
+	k, err := a.h.HandleRestore(user, r)
+	a.record(user, Restore, k.Name, "", err)
+	return k, err
+}
+
+func (a *auditedExthandler) HandleAddUser(user string, newuser string, k Keygroup, r Role) error {
+	err := a.h.HandleAddUser(user, newuser, k, r)
+	a.record(user, AddUser, k.Name, newuser, err)
+	return err
+}
+
+func (a *auditedExthandler) HandleRemoveUser(user string, newuser string, k Keygroup, r Role) error {
+	err := a.h.HandleRemoveUser(user, newuser, k, r)
+	a.record(user, RemoveUser, k.Name, newuser, err)
+	return err
+}
+
+func (a *auditedExthandler) HandleAddGroupRole(user string, group string, k Keygroup, r Role) error {
+	err := a.h.HandleAddGroupRole(user, group, k, r)
+	a.record(user, AddUser, k.Name, group, err)
+	return err
+}
+
+func (a *auditedExthandler) HandleRemoveGroupRole(user string, group string, k Keygroup, r Role) error {
+	err := a.h.HandleRemoveGroupRole(user, group, k, r)
+	a.record(user, RemoveUser, k.Name, group, err)
+	return err
+}
+
+func (a *auditedExthandler) HandleGetKeygroupPermissions(user string, k Keygroup) (map[Subject]map[Method]struct{}, error) {
+	res, err := a.h.HandleGetKeygroupPermissions(user, k)
+	a.record(user, GetPermissions, k.Name, "", err)
+	return res, err
+}
+
+// Roles and groups are not specific to a keygroup, so we record them for the AllKeygroups pattern.
+
+func (a *auditedExthandler) HandleAddRole(user string, r Role, m []Method) error {
+	err := a.h.HandleAddRole(user, r, m)
+	a.record(user, ConfigureRoles, AllKeygroups, string(r), err)
+	return err
+}
+
+func (a *auditedExthandler) HandleRemoveRole(user string, r Role) error {
+	err := a.h.HandleRemoveRole(user, r)
+	a.record(user, ConfigureRoles, AllKeygroups, string(r), err)
+	return err
+}
+
+// HandleGetRoles is not audited as everyone may read the roles.
+func (a *auditedExthandler) HandleGetRoles(user string) (map[Role]map[Method]struct{}, error) {
+	return a.h.HandleGetRoles(user)
+}
+
+func (a *auditedExthandler) HandleAddGroupMember(user string, group string, member string) error {
+	err := a.h.HandleAddGroupMember(user, group, member)
+	a.record(user, ConfigureGroups, AllKeygroups, group+"/"+member, err)
+	return err
+}
+
+func (a *auditedExthandler) HandleRemoveGroupMember(user string, group string, member string) error {
+	err := a.h.HandleRemoveGroupMember(user, group, member)
+	a.record(user, ConfigureGroups, AllKeygroups, group+"/"+member, err)
+	return err
+}
+
+func (a *auditedExthandler) HandleGetGroupMembers(user string, group string) ([]string, error) {
+	res, err := a.h.HandleGetGroupMembers(user, group)
+	a.record(user, ConfigureGroups, AllKeygroups, group, err)
+	return res, err
+}
+
+func (a *auditedExthandler) HandleGetAuditLog(user string, q AuditQuery) ([]AuditEntry, error) {
+	res, err := a.h.HandleGetAuditLog(user, q)
+	a.record(user, GetAuditLog, q.Keygroup, "", err)
+	return res, err
+}
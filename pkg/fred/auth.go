@@ -7,37 +7,179 @@ import (
 )
 
 type authService struct {
-	n NameService
+	n              NameService
+	admins         map[string]struct{}
+	restrictCreate bool
+	groups         []string
 }
 
-// newAuthService creates a new authorization service.
-func newAuthService(n NameService) *authService {
-	return &authService{
-		n: n,
+// newAuthService creates a new authorization service. Admins are allowed to perform every method on every keygroup.
+// If restrictCreate is set, users need the CreateKeygroup permission on a keygroup name to create that keygroup,
+// otherwise everyone may create keygroups.
+func newAuthService(n NameService, admins []string, restrictCreate bool) *authService {
+	a := &authService{
+		n:              n,
+		admins:         make(map[string]struct{}),
+		restrictCreate: restrictCreate,
 	}
+
+	for _, u := range admins {
+		a.admins[u] = struct{}{}
+	}
+
+	return a
+}
+
+// withGroups returns an authorization service that treats all users as members of the given groups in addition to
+// the groups in the NameService.
+func (a *authService) withGroups(groups []string) *authService {
+	c := *a
+	c.groups = groups
+
+	return &c
+}
+
+// getRoles returns both the built-in and the custom roles with their methods.
+func (a *authService) getRoles() (map[Role]map[Method]struct{}, error) {
+	custom, err := a.n.GetRoles()
+
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	roles := make(map[Role]map[Method]struct{}, len(permissions)+len(custom))
+
+	for r, m := range custom {
+		roles[r] = m
+	}
+
+	// built-in roles cannot be overwritten
+	for r, m := range permissions {
+		roles[r] = m
+	}
+
+	return roles, nil
+}
+
+func (a *authService) addRole(r Role, m []Method) error {
+	if err := checkName(string(r)); err != nil {
+		return err
+	}
+
+	if _, ok := permissions[r]; ok {
+		return errors.Errorf("cannot change built-in role %s", r)
+	}
+
+	if len(m) == 0 {
+		return errors.Errorf("role %s needs at least one method", r)
+	}
+
+	for _, method := range m {
+		if _, ok := methods[method]; !ok {
+			return errors.Errorf("unknown method %s", method)
+		}
+	}
+
+	log.Debug().Msgf("adding methods %#v to role %s", m, r)
+
+	return a.n.AddRole(r, m)
+}
+
+func (a *authService) removeRole(r Role) error {
+	if _, ok := permissions[r]; ok {
+		return errors.Errorf("cannot remove built-in role %s", r)
+	}
+
+	log.Debug().Msgf("removing role %s", r)
+
+	// remove all bindings for that role first so that a new role with the same name does not inherit them
+	bindings, err := a.n.GetAllRoleBindings()
+
+	if err != nil {
+		return errors.New(err)
+	}
+
+	for _, b := range bindings {
+		if b.Role != r {
+			continue
+		}
+
+		if err := a.n.RevokeRoleBinding(b); err != nil {
+			return errors.New(err)
+		}
+	}
+
+	return a.n.RemoveRole(r)
+}
+
+func (a *authService) addGroupMember(g string, u string) error {
+	if err := checkName(g); err != nil {
+		return err
+	}
+
+	log.Debug().Msgf("adding user %s to group %s", u, g)
+
+	return a.n.AddGroupMember(g, u)
+}
+
+func (a *authService) removeGroupMember(g string, u string) error {
+	log.Debug().Msgf("removing user %s from group %s", u, g)
+
+	return a.n.RemoveGroupMember(g, u)
 }
 
-func (a *authService) addRoles(u string, r []Role, k KeygroupName) error {
-	log.Debug().Msgf("adding roles %#v for user %s for keygroup %s", r, u, k)
+func (a *authService) addRoles(s Subject, r []Role, k KeygroupName) error {
+	log.Debug().Msgf("adding roles %#v for subject %#v for keygroup %s", r, s, k)
+
+	if err := checkKeygroupPattern(k); err != nil {
+		return err
+	}
+
+	roles, err := a.getRoles()
+
+	if err != nil {
+		return err
+	}
+
 	for _, role := range r {
-		for m := range permissions[role] {
+		if _, ok := roles[role]; !ok {
+			return errors.Errorf("unknown role %s", role)
+		}
 
-			err := a.n.AddUserPermissions(u, m, k)
+		err := a.n.AddRoleBinding(RoleBinding{
+			Subject:  s,
+			Role:     role,
+			Keygroup: k,
+		})
 
-			if err != nil {
-				return errors.New(err)
-			}
+		if err != nil {
+			return errors.New(err)
 		}
 	}
 
 	return nil
 }
 
-func (a *authService) revokeRoles(u string, r []Role, k KeygroupName) error {
-	log.Debug().Msgf("removing roles %#v from user %s for keygroup %s", r, u, k)
+func (a *authService) revokeRoles(s Subject, r []Role, k KeygroupName) error {
+	log.Debug().Msgf("removing roles %#v from subject %#v for keygroup %s", r, s, k)
 	for _, role := range r {
+		err := a.n.RevokeRoleBinding(RoleBinding{
+			Subject:  s,
+			Role:     role,
+			Keygroup: k,
+		})
+
+		if err != nil {
+			return errors.New(err)
+		}
+
+		if s.Group {
+			continue
+		}
+
+		// users might still have single permissions for a built-in role from before roles were stored as a whole
 		for m := range permissions[role] {
-			err := a.n.RevokeUserPermissions(u, m, k)
+			err := a.n.RevokeUserPermissions(s.Name, m, k)
 
 			if err != nil {
 				return errors.New(err)
@@ -48,16 +190,66 @@ func (a *authService) revokeRoles(u string, r []Role, k KeygroupName) error {
 	return nil
 }
 
+// getPermissions returns all subjects with the methods they may perform on a keygroup.
+func (a *authService) getPermissions(k KeygroupName) (map[Subject]map[Method]struct{}, error) {
+	p := make(map[Subject]map[Method]struct{})
+
+	legacy, err := a.n.GetAllUserPermissions(k)
+
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	for u, m := range legacy {
+		p[Subject{Name: u}] = m
+	}
+
+	roles, err := a.getRoles()
+
+	if err != nil {
+		return nil, err
+	}
+
+	bindings, err := a.n.GetAllRoleBindings()
+
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	for _, b := range bindings {
+		if !matchKeygroup(b.Keygroup, k) {
+			continue
+		}
+
+		if _, ok := p[b.Subject]; !ok {
+			p[b.Subject] = make(map[Method]struct{})
+		}
+
+		for m := range roles[b.Role] {
+			p[b.Subject][m] = struct{}{}
+		}
+	}
+
+	return p, nil
+}
+
+func (a *authService) isAllowedToCreate(u string, k KeygroupName) (bool, error) {
+	if !a.restrictCreate {
+		return true, nil
+	}
+
+	return a.isAllowed(u, CreateKeygroup, k)
+}
+
 func (a *authService) isAllowed(u string, m Method, k KeygroupName) (bool, error) {
 	log.Debug().Msgf("checking if user %s is allowed to perform %s on keygroup %s...", u, m, k)
-	p, err := a.n.GetUserPermissions(u, k)
+
+	ok, err := a.check(u, m, k)
 
 	if err != nil {
-		return false, errors.New(err)
+		return false, err
 	}
 
-	_, ok := p[m]
-
 	// Only compute the string if log level is debug
 	if zerolog.GlobalLevel() == zerolog.DebugLevel {
 		var res string
@@ -72,3 +264,71 @@ func (a *authService) isAllowed(u string, m Method, k KeygroupName) (bool, error
 	return ok, nil
 
 }
+
+func (a *authService) check(u string, m Method, k KeygroupName) (bool, error) {
+	if _, ok := a.admins[u]; ok {
+		return true, nil
+	}
+
+	// permissions that were granted method by method
+	p, err := a.n.GetUserPermissions(u, k)
+
+	if err != nil {
+		return false, errors.New(err)
+	}
+
+	if _, ok := p[m]; ok {
+		return true, nil
+	}
+
+	if l, ok := legacyMethods[m]; ok {
+		if _, ok := p[l]; ok {
+			return true, nil
+		}
+	}
+
+	// permissions that were granted as roles, either to the user directly or to one of their groups
+	groups, err := a.n.GetUserGroups(u)
+
+	if err != nil {
+		return false, errors.New(err)
+	}
+
+	subjects := make([]Subject, 0, len(groups)+len(a.groups)+1)
+	subjects = append(subjects, Subject{Name: u})
+
+	for _, g := range append(groups, a.groups...) {
+		subjects = append(subjects, Subject{Name: g, Group: true})
+	}
+
+	var roles map[Role]map[Method]struct{}
+
+	for _, s := range subjects {
+		bindings, err := a.n.GetRoleBindings(s)
+
+		if err != nil {
+			return false, errors.New(err)
+		}
+
+		for _, b := range bindings {
+			if !matchKeygroup(b.Keygroup, k) {
+				continue
+			}
+
+			// only get the custom roles once we know we need them
+			if roles == nil {
+				roles, err = a.getRoles()
+
+				if err != nil {
+					return false, err
+				}
+			}
+
+			if _, ok := roles[b.Role][m]; ok {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,176 @@
+package fred
+
+import (
+	"bufio"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"hash"
+	"io"
+	"time"
+
+	"github.com/go-errors/errors"
+)
+
+// backupVersion is the version of the backup format. Backups with a newer version cannot be restored.
+const backupVersion = 1
+
+// A backup is a gzip-compressed stream of JSON records, one per line: a header with the configuration of the keygroup,
+// then one record per item, and finally an end record with the number of items and the SHA-256 checksum of all lines
+// before it.
+type backupRecord struct {
+	Header *backupHeader `json:"header,omitempty"`
+	Item   *backupItem   `json:"item,omitempty"`
+	End    *backupEnd    `json:"end,omitempty"`
+}
+
+// backupHeader is the configuration of a keygroup at the time of the backup. Expiry is the expiry on the node that
+// made the backup, Replicas are all members of the keygroup with their expiries.
+type backupHeader struct {
+	Version  int            `json:"version"`
+	Keygroup KeygroupName   `json:"keygroup"`
+	Mutable  bool           `json:"mutable"`
+	Expiry   int            `json:"expiry"`
+	Node     NodeID         `json:"node"`
+	Time     time.Time      `json:"time"`
+	Replicas map[NodeID]int `json:"replicas"`
+	Triggers []Trigger      `json:"triggers"`
+}
+
+// backupItem is an item of the keygroup. ExpiresAt is a Unix timestamp or 0 if the item does not expire.
+type backupItem struct {
+	ID        string `json:"id"`
+	Val       string `json:"val"`
+	ExpiresAt int64  `json:"expiresAt,omitempty"`
+}
+
+type backupEnd struct {
+	Items    uint64 `json:"items"`
+	Checksum string `json:"checksum"`
+}
+
+// backupWriter writes a backup to an underlying writer.
+type backupWriter struct {
+	gz    *gzip.Writer
+	h     hash.Hash
+	enc   *json.Encoder
+	items uint64
+}
+
+func newBackupWriter(w io.Writer, h backupHeader) (*backupWriter, error) {
+	b := &backupWriter{
+		gz: gzip.NewWriter(w),
+		h:  sha256.New(),
+	}
+
+	b.enc = json.NewEncoder(io.MultiWriter(b.gz, b.h))
+
+	h.Version = backupVersion
+
+	if err := b.enc.Encode(backupRecord{Header: &h}); err != nil {
+		return nil, errors.New(err)
+	}
+
+	return b, nil
+}
+
+func (b *backupWriter) write(i backupItem) error {
+	if err := b.enc.Encode(backupRecord{Item: &i}); err != nil {
+		return errors.New(err)
+	}
+
+	b.items++
+
+	return nil
+}
+
+// close writes the end record and flushes the backup. It does not close the underlying writer.
+func (b *backupWriter) close() error {
+	end := backupEnd{
+		Items:    b.items,
+		Checksum: hex.EncodeToString(b.h.Sum(nil)),
+	}
+
+	if err := json.NewEncoder(b.gz).Encode(backupRecord{End: &end}); err != nil {
+		return errors.New(err)
+	}
+
+	if err := b.gz.Close(); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+// readBackup reads a complete backup. Nothing is returned unless the backup is complete and its checksum matches.
+func readBackup(r io.Reader) (backupHeader, []backupItem, error) {
+	gz, err := gzip.NewReader(r)
+
+	if err != nil {
+		return backupHeader{}, nil, errors.Errorf("backup is not compressed with gzip: %v", err)
+	}
+
+	defer gz.Close()
+
+	br := bufio.NewReader(gz)
+	h := sha256.New()
+
+	var header *backupHeader
+	var items []backupItem
+
+	for {
+		line, err := br.ReadBytes('\n')
+
+		if err == io.EOF {
+			return backupHeader{}, nil, errors.Errorf("backup is truncated")
+		}
+
+		if err != nil {
+			return backupHeader{}, nil, errors.New(err)
+		}
+
+		var rec backupRecord
+
+		if err := json.Unmarshal(line, &rec); err != nil {
+			return backupHeader{}, nil, errors.Errorf("backup has a malformed record: %v", err)
+		}
+
+		switch {
+		case rec.End != nil:
+			if header == nil {
+				return backupHeader{}, nil, errors.Errorf("backup has no header")
+			}
+
+			if rec.End.Items != uint64(len(items)) {
+				return backupHeader{}, nil, errors.Errorf("backup should have %d items but has %d", rec.End.Items, len(items))
+			}
+
+			if rec.End.Checksum != hex.EncodeToString(h.Sum(nil)) {
+				return backupHeader{}, nil, errors.Errorf("backup checksum does not match")
+			}
+
+			return *header, items, nil
+		case rec.Header != nil:
+			if header != nil {
+				return backupHeader{}, nil, errors.Errorf("backup has more than one header")
+			}
+
+			if rec.Header.Version > backupVersion {
+				return backupHeader{}, nil, errors.Errorf("backup has version %d but only version %d is supported", rec.Header.Version, backupVersion)
+			}
+
+			header = rec.Header
+		case rec.Item != nil:
+			if header == nil {
+				return backupHeader{}, nil, errors.Errorf("backup has no header")
+			}
+
+			items = append(items, *rec.Item)
+		default:
+			return backupHeader{}, nil, errors.Errorf("backup has an empty record")
+		}
+
+		h.Write(line)
+	}
+}
@@ -0,0 +1,420 @@
+package fred
+
+import (
+	"container/list"
+	"hash/fnv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// cacheEntryOverhead is roughly how much memory a cache entry takes up in addition to its keygroup, ID, and value.
+const cacheEntryOverhead = 128
+
+// CacheRule sets how long items of keygroups that match a pattern are cached. A TTL of 0 keeps them out of the cache.
+type CacheRule struct {
+	Pattern KeygroupName
+	TTL     time.Duration
+}
+
+// CacheStats shows how well the cache works. Hits and Misses count reads per keygroup, Evictions counts items that
+// were removed to make room for others.
+type CacheStats struct {
+	Items     int
+	Size      int64
+	Evictions uint64
+	Hits      map[KeygroupName]uint64
+	Misses    map[KeygroupName]uint64
+}
+
+type cacheKey struct {
+	kg string
+	id string
+}
+
+// cacheVersions counts writes to items, spread over a fixed number of counters, so that a read does not cache a value
+// that was overwritten while it was read, while writes to other items do not keep it from being cached.
+type cacheVersions [256]uint64
+
+// get returns the counter of an item.
+func (v *cacheVersions) get(k cacheKey) *uint64 {
+	h := fnv.New32a()
+	h.Write([]byte(k.kg))
+	h.Write([]byte{0})
+	h.Write([]byte(k.id))
+
+	return &v[h.Sum32()%uint32(len(v))]
+}
+
+// cacheEntry is a cached item. An entry without a valid value only remembers when the item expires in the store, which
+// is known after it has been written through the cache. A zero expiresAt means that the item does not expire.
+type cacheEntry struct {
+	key       cacheKey
+	val       string
+	valid     bool
+	expiresAt time.Time
+	until     time.Time
+	size      int64
+}
+
+// cachedStore is a Store with a read-through LRU cache for items. Every write goes through it, so entries are
+// invalidated on local and replicated writes. Items are never cached for longer than they exist in the store: their
+// expiry is known if they were written through the cache or if their keygroup does not expire on this node.
+type cachedStore struct {
+	Store
+	mu        sync.Mutex
+	maxSize   int64
+	size      int64
+	ll        *list.List
+	items     map[cacheKey]*list.Element
+	keygroups map[string]time.Time
+	rules     []CacheRule
+	expiry    func(kg KeygroupName) (int, error)
+	now       func() time.Time
+	versions  cacheVersions
+	// drops counts deleted keygroups, so that nothing of a keygroup is cached that was deleted while it was read
+	drops     uint64
+	evictions uint64
+	hits      map[KeygroupName]uint64
+	misses    map[KeygroupName]uint64
+}
+
+func newCachedStore(s Store, maxSize int64, rules []CacheRule, expiry func(kg KeygroupName) (int, error)) *cachedStore {
+	return &cachedStore{
+		Store:     s,
+		maxSize:   maxSize,
+		ll:        list.New(),
+		items:     make(map[cacheKey]*list.Element),
+		keygroups: make(map[string]time.Time),
+		rules:     rules,
+		expiry:    expiry,
+		now:       time.Now,
+		hits:      make(map[KeygroupName]uint64),
+		misses:    make(map[KeygroupName]uint64),
+	}
+}
+
+// ttl returns how long items of a keygroup are cached, which is set by the first rule that matches the keygroup.
+// Internal keygroups, such as the trigger queue, are never cached, as they would only push out the items of clients.
+func (c *cachedStore) ttl(kg string) time.Duration {
+	if strings.HasPrefix(kg, "_") {
+		return 0
+	}
+
+	for _, r := range c.rules {
+		if matchKeygroup(r.Pattern, KeygroupName(kg)) {
+			return r.TTL
+		}
+	}
+
+	return 0
+}
+
+// get returns a cached value. Must be called with the lock held.
+func (c *cachedStore) get(k cacheKey) (string, bool) {
+	el, ok := c.items[k]
+
+	if !ok {
+		return "", false
+	}
+
+	e := el.Value.(*cacheEntry)
+
+	if !e.valid || !c.now().Before(e.until) {
+		return "", false
+	}
+
+	c.ll.MoveToFront(el)
+
+	return e.val, true
+}
+
+// put adds an entry or replaces it and evicts the least recently used entries until the cache is small enough. Must be
+// called with the lock held.
+func (c *cachedStore) put(e *cacheEntry) {
+	c.remove(e.key)
+
+	e.size = int64(len(e.key.kg)+len(e.key.id)+len(e.val)) + cacheEntryOverhead
+
+	if e.size > c.maxSize {
+		return
+	}
+
+	c.items[e.key] = c.ll.PushFront(e)
+	c.size += e.size
+
+	for c.size > c.maxSize {
+		c.remove(c.ll.Back().Value.(*cacheEntry).key)
+		c.evictions++
+	}
+}
+
+// remove removes an entry. Must be called with the lock held.
+func (c *cachedStore) remove(k cacheKey) {
+	el, ok := c.items[k]
+
+	if !ok {
+		return
+	}
+
+	c.ll.Remove(el)
+	delete(c.items, k)
+	c.size -= el.Value.(*cacheEntry).size
+}
+
+// invalidate removes an item from the cache after a write that may have changed it.
+func (c *cachedStore) invalidate(kg, id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	k := cacheKey{kg, id}
+
+	*c.versions.get(k)++
+	c.remove(k)
+}
+
+// written invalidates an item after it was written. If the write started at start, the item expires in the store no
+// earlier than start plus expiry, which is remembered for later reads.
+func (c *cachedStore) written(kg, id string, start time.Time, expiry int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	k := cacheKey{kg, id}
+
+	*c.versions.get(k)++
+
+	ttl := c.ttl(kg)
+
+	if ttl <= 0 {
+		return
+	}
+
+	e := &cacheEntry{
+		key:   k,
+		until: c.now().Add(ttl),
+	}
+
+	if expiry > 0 {
+		e.expiresAt = start.Add(time.Duration(expiry) * time.Second)
+		// an entry that only remembers the expiry is useless once the item has expired
+		if e.expiresAt.Before(e.until) {
+			e.until = e.expiresAt
+		}
+	}
+
+	c.put(e)
+}
+
+// Read returns an item from the cache or reads it from the store and caches it.
+func (c *cachedStore) Read(kg string, id string) (string, error) {
+	ttl := c.ttl(kg)
+
+	if ttl <= 0 {
+		return c.Store.Read(kg, id)
+	}
+
+	k := cacheKey{kg, id}
+
+	c.mu.Lock()
+
+	if val, ok := c.get(k); ok {
+		c.hits[KeygroupName(kg)]++
+		c.mu.Unlock()
+		return val, nil
+	}
+
+	c.misses[KeygroupName(kg)]++
+	version := *c.versions.get(k)
+	drops := c.drops
+
+	var expiresAt time.Time
+	known := false
+
+	if el, ok := c.items[k]; ok {
+		expiresAt = el.Value.(*cacheEntry).expiresAt
+		known = true
+	}
+
+	c.mu.Unlock()
+
+	val, err := c.Store.Read(kg, id)
+
+	if err != nil {
+		return "", err
+	}
+
+	if !known {
+		// items of keygroups that do not expire on this node never expire, for all others we cannot tell when they do
+		expiry, err := c.expiry(KeygroupName(kg))
+
+		if err != nil || expiry != 0 {
+			return val, nil
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if *c.versions.get(k) != version || c.drops != drops {
+		return val, nil
+	}
+
+	e := &cacheEntry{
+		key:       k,
+		val:       val,
+		valid:     true,
+		expiresAt: expiresAt,
+		until:     c.now().Add(ttl),
+	}
+
+	if !expiresAt.IsZero() && expiresAt.Before(e.until) {
+		e.until = expiresAt
+	}
+
+	c.put(e)
+
+	return val, nil
+}
+
+// Exists checks the cache before it asks the store.
+func (c *cachedStore) Exists(kg string, id string) bool {
+	c.mu.Lock()
+	_, ok := c.get(cacheKey{kg, id})
+	c.mu.Unlock()
+
+	if ok {
+		return true
+	}
+
+	return c.Store.Exists(kg, id)
+}
+
+// ExistsKeygroup remembers keygroups that exist for as long as their items are cached.
+func (c *cachedStore) ExistsKeygroup(kg string) bool {
+	ttl := c.ttl(kg)
+
+	if ttl <= 0 {
+		return c.Store.ExistsKeygroup(kg)
+	}
+
+	c.mu.Lock()
+	until, ok := c.keygroups[kg]
+	drops := c.drops
+	c.mu.Unlock()
+
+	if ok && c.now().Before(until) {
+		return true
+	}
+
+	if !c.Store.ExistsKeygroup(kg) {
+		return false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.drops == drops {
+		c.keygroups[kg] = c.now().Add(ttl)
+	}
+
+	return true
+}
+
+func (c *cachedStore) Update(kg, id, val string, append bool, expiry int) error {
+	start := c.now()
+
+	if err := c.Store.Update(kg, id, val, append, expiry); err != nil {
+		c.invalidate(kg, id)
+		return err
+	}
+
+	c.written(kg, id, start, expiry)
+
+	return nil
+}
+
+func (c *cachedStore) Append(kg, id, val string, expiry int) error {
+	start := c.now()
+
+	if err := c.Store.Append(kg, id, val, expiry); err != nil {
+		c.invalidate(kg, id)
+		return err
+	}
+
+	c.written(kg, id, start, expiry)
+
+	return nil
+}
+
+func (c *cachedStore) UpdateBulk(items []BulkItem) error {
+	start := c.now()
+
+	if err := updateBulk(c.Store, items); err != nil {
+		for _, i := range items {
+			c.invalidate(i.Keygroup, i.ID)
+		}
+
+		return err
+	}
+
+	for _, i := range items {
+		c.written(i.Keygroup, i.ID, start, i.Expiry)
+	}
+
+	return nil
+}
+
+func (c *cachedStore) Delete(kg, id string) error {
+	err := c.Store.Delete(kg, id)
+
+	c.invalidate(kg, id)
+
+	return err
+}
+
+func (c *cachedStore) DeleteKeygroup(kg string) error {
+	err := c.Store.DeleteKeygroup(kg)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.drops++
+	delete(c.keygroups, kg)
+
+	for k := range c.items {
+		if k.kg == kg {
+			c.remove(k)
+		}
+	}
+
+	return err
+}
+
+// Export keeps the expiry of items if the underlying store is an Exporter.
+func (c *cachedStore) Export(kg string, fn func(id string, val string, expiresAt int64) error) error {
+	return exportStore(c.Store, kg, fn)
+}
+
+// stats returns a copy of the statistics of the cache.
+func (c *cachedStore) stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s := CacheStats{
+		Items:     len(c.items),
+		Size:      c.size,
+		Evictions: c.evictions,
+		Hits:      make(map[KeygroupName]uint64, len(c.hits)),
+		Misses:    make(map[KeygroupName]uint64, len(c.misses)),
+	}
+
+	for kg, n := range c.hits {
+		s.Hits[kg] = n
+	}
+
+	for kg, n := range c.misses {
+		s.Misses[kg] = n
+	}
+
+	return s
+}
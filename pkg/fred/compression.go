@@ -0,0 +1,304 @@
+package fred
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"sync"
+
+	"github.com/go-errors/errors"
+	"github.com/golang/snappy"
+)
+
+// These are the algorithms that values can be compressed with.
+const (
+	CompressionNone   = ""
+	CompressionSnappy = "snappy"
+	CompressionGzip   = "gzip"
+)
+
+// Compressed values start with a header byte for their algorithm. Values that arrive through the APIs are valid UTF-8,
+// which never contains these bytes, so values that are not compressed are stored as they are and cannot be mistaken
+// for compressed ones. Values can thus be read no matter which algorithm they were written with, or whether
+// compression was turned on at all.
+const (
+	headerSnappy byte = 0xff
+	headerGzip   byte = 0xfe
+)
+
+// CompressionRule sets how values of keygroups that match a pattern are compressed. Values smaller than MinSize bytes
+// are not compressed.
+type CompressionRule struct {
+	Pattern   KeygroupName
+	Algorithm string
+	MinSize   int
+}
+
+// CompressionStats counts the values written to a keygroup and how many bytes they take up before and after compression.
+// Values that are not compressed are included with their original size.
+type CompressionStats struct {
+	Values      uint64
+	Compressed  uint64
+	RawBytes    uint64
+	StoredBytes uint64
+}
+
+// Ratio is how many times smaller the values are in the store.
+func (s CompressionStats) Ratio() float64 {
+	if s.StoredBytes == 0 {
+		return 1
+	}
+
+	return float64(s.RawBytes) / float64(s.StoredBytes)
+}
+
+// CheckCompressionAlgorithm checks whether values can be compressed with an algorithm.
+func CheckCompressionAlgorithm(algorithm string) error {
+	switch algorithm {
+	case CompressionNone, CompressionSnappy, CompressionGzip:
+		return nil
+	default:
+		return errors.Errorf("unknown compression algorithm %s", algorithm)
+	}
+}
+
+// compressedStore is a Store that compresses the values of some keygroups before they are stored and decompresses all
+// compressed values when they are read.
+type compressedStore struct {
+	Store
+	rules []CompressionRule
+	mu    sync.Mutex
+	stats map[KeygroupName]*CompressionStats
+}
+
+func newCompressedStore(s Store, rules []CompressionRule) (*compressedStore, error) {
+	for _, r := range rules {
+		if err := CheckCompressionAlgorithm(r.Algorithm); err != nil {
+			return nil, err
+		}
+	}
+
+	return &compressedStore{
+		Store: s,
+		rules: rules,
+		stats: make(map[KeygroupName]*CompressionStats),
+	}, nil
+}
+
+// rule returns the first rule that matches a keygroup.
+func (c *compressedStore) rule(kg string) CompressionRule {
+	for _, r := range c.rules {
+		if matchKeygroup(r.Pattern, KeygroupName(kg)) {
+			return r
+		}
+	}
+
+	return CompressionRule{}
+}
+
+// compress returns the value that is stored for a value of a keygroup and counts it in the statistics.
+func (c *compressedStore) compress(kg string, val string) (string, error) {
+	r := c.rule(kg)
+
+	stored := val
+
+	if r.Algorithm != CompressionNone && len(val) >= r.MinSize {
+		compressed, err := compress(r.Algorithm, val)
+
+		if err != nil {
+			return "", err
+		}
+
+		// incompressible values are better off as they are
+		if len(compressed) < len(val) {
+			stored = compressed
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s, ok := c.stats[KeygroupName(kg)]
+
+	if !ok {
+		s = &CompressionStats{}
+		c.stats[KeygroupName(kg)] = s
+	}
+
+	s.Values++
+	s.RawBytes += uint64(len(val))
+	s.StoredBytes += uint64(len(stored))
+
+	if len(stored) < len(val) {
+		s.Compressed++
+	}
+
+	return stored, nil
+}
+
+func compress(algorithm string, val string) (string, error) {
+	switch algorithm {
+	case CompressionSnappy:
+		return string(append([]byte{headerSnappy}, snappy.Encode(nil, []byte(val))...)), nil
+	case CompressionGzip:
+		var b bytes.Buffer
+		b.WriteByte(headerGzip)
+
+		w := gzip.NewWriter(&b)
+
+		if _, err := w.Write([]byte(val)); err != nil {
+			return "", errors.New(err)
+		}
+
+		if err := w.Close(); err != nil {
+			return "", errors.New(err)
+		}
+
+		return b.String(), nil
+	default:
+		return "", errors.Errorf("unknown compression algorithm %s", algorithm)
+	}
+}
+
+// decompress returns the original value of a stored value.
+func decompress(stored string) (string, error) {
+	if stored == "" {
+		return stored, nil
+	}
+
+	switch stored[0] {
+	case headerSnappy:
+		val, err := snappy.Decode(nil, []byte(stored[1:]))
+
+		if err != nil {
+			return "", errors.Errorf("could not decompress value: %v", err)
+		}
+
+		return string(val), nil
+	case headerGzip:
+		r, err := gzip.NewReader(bytes.NewReader([]byte(stored[1:])))
+
+		if err != nil {
+			return "", errors.Errorf("could not decompress value: %v", err)
+		}
+
+		val, err := ioutil.ReadAll(r)
+
+		if err != nil {
+			return "", errors.Errorf("could not decompress value: %v", err)
+		}
+
+		return string(val), nil
+	default:
+		return stored, nil
+	}
+}
+
+// decompressAll decompresses all values of a map in place.
+func decompressAll(items map[string]string) (map[string]string, error) {
+	for id, stored := range items {
+		val, err := decompress(stored)
+
+		if err != nil {
+			return nil, err
+		}
+
+		items[id] = val
+	}
+
+	return items, nil
+}
+
+func (c *compressedStore) Update(kg, id, val string, append bool, expiry int) error {
+	stored, err := c.compress(kg, val)
+
+	if err != nil {
+		return err
+	}
+
+	return c.Store.Update(kg, id, stored, append, expiry)
+}
+
+func (c *compressedStore) Append(kg, id, val string, expiry int) error {
+	stored, err := c.compress(kg, val)
+
+	if err != nil {
+		return err
+	}
+
+	return c.Store.Append(kg, id, stored, expiry)
+}
+
+func (c *compressedStore) UpdateBulk(items []BulkItem) error {
+	stored := make([]BulkItem, len(items))
+
+	for j, i := range items {
+		val, err := c.compress(i.Keygroup, i.Val)
+
+		if err != nil {
+			return err
+		}
+
+		i.Val = val
+		stored[j] = i
+	}
+
+	return updateBulk(c.Store, stored)
+}
+
+func (c *compressedStore) Read(kg, id string) (string, error) {
+	stored, err := c.Store.Read(kg, id)
+
+	if err != nil {
+		return "", err
+	}
+
+	return decompress(stored)
+}
+
+func (c *compressedStore) ReadSome(kg, id string, count uint64) (map[string]string, error) {
+	items, err := c.Store.ReadSome(kg, id, count)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return decompressAll(items)
+}
+
+func (c *compressedStore) ReadAll(kg string) (map[string]string, error) {
+	items, err := c.Store.ReadAll(kg)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return decompressAll(items)
+}
+
+// Export decompresses values and keeps the expiry of items if the underlying store is an Exporter.
+func (c *compressedStore) Export(kg string, fn func(id string, val string, expiresAt int64) error) error {
+	return exportStore(c.Store, kg, func(id string, stored string, expiresAt int64) error {
+		val, err := decompress(stored)
+
+		if err != nil {
+			return err
+		}
+
+		return fn(id, val, expiresAt)
+	})
+}
+
+// statistics returns a copy of the compression statistics of all keygroups.
+func (c *compressedStore) statistics() map[KeygroupName]CompressionStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := make(map[KeygroupName]CompressionStats, len(c.stats))
+
+	for kg, s := range c.stats {
+		stats[kg] = *s
+	}
+
+	return stats
+}
@@ -0,0 +1,710 @@
+package fred
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// dataKeysKeygroup keeps the data keys of all keygroups, wrapped with the master key.
+	dataKeysKeygroup = "_datakeys"
+	// EncryptionKeySize is the size of master keys and data keys, which are AES-256 keys.
+	EncryptionKeySize = 32
+)
+
+// Encrypted values start with a header byte that never appears in UTF-8, followed by the version of the data key they
+// are encrypted with, the nonce, and the ciphertext. Values without the header are not encrypted.
+const (
+	headerEncrypted   byte = 0xfd
+	encryptedOverhead      = 1 + 4
+)
+
+// dataKeys are the data keys of a keygroup. Values are encrypted with the current key, older keys are kept until all
+// values have been re-encrypted with the current key.
+type dataKeys struct {
+	Current uint32    `json:"current"`
+	Keys    []dataKey `json:"keys"`
+}
+
+type dataKey struct {
+	Version uint32    `json:"version"`
+	Created time.Time `json:"created"`
+	Wrapped []byte    `json:"wrapped"`
+}
+
+// keyring holds the unwrapped data keys of a keygroup.
+type keyring struct {
+	stored  dataKeys
+	aeads   map[uint32]cipher.AEAD
+	created time.Time
+}
+
+// encryptedStore is a Store that encrypts the values of some keygroups with AES-GCM before they are stored. Every
+// keygroup has its own data keys, which are wrapped with a master key and kept in the store as well.
+type encryptedStore struct {
+	Store
+	master    cipher.AEAD
+	old       cipher.AEAD
+	keygroups []KeygroupName
+	mu        sync.Mutex
+	keyrings  map[string]*keyring
+	locks     itemLocks
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	if len(key) != EncryptionKeySize {
+		return nil, errors.Errorf("encryption keys must be %d bytes but key has %d bytes", EncryptionKeySize, len(key))
+	}
+
+	b, err := aes.NewCipher(key)
+
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	g, err := cipher.NewGCM(b)
+
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	return g, nil
+}
+
+// newEncryptedStore creates a Store that encrypts the values of keygroups that match a pattern. Without a master key,
+// nothing is encrypted, and reading encrypted values fails. The old master key is only used to unwrap data keys, which
+// are then wrapped with the new master key.
+func newEncryptedStore(s Store, master []byte, old []byte, keygroups []KeygroupName) (*encryptedStore, error) {
+	e := &encryptedStore{
+		Store:     s,
+		keygroups: keygroups,
+		keyrings:  make(map[string]*keyring),
+	}
+
+	if master == nil {
+		return e, nil
+	}
+
+	var err error
+
+	if e.master, err = newGCM(master); err != nil {
+		return nil, err
+	}
+
+	if old != nil {
+		if e.old, err = newGCM(old); err != nil {
+			return nil, err
+		}
+	}
+
+	if !s.ExistsKeygroup(dataKeysKeygroup) {
+		if err := s.CreateKeygroup(dataKeysKeygroup); err != nil {
+			return nil, errors.New(err)
+		}
+	}
+
+	return e, nil
+}
+
+// encrypts checks whether new values of a keygroup are encrypted.
+func (e *encryptedStore) encrypts(kg string) bool {
+	if e.master == nil || kg == dataKeysKeygroup {
+		return false
+	}
+
+	for _, p := range e.keygroups {
+		if matchKeygroup(p, KeygroupName(kg)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// wrapAAD binds a wrapped data key to its keygroup and version.
+func wrapAAD(kg string, version uint32) []byte {
+	aad := make([]byte, 4, 4+len(kg))
+	binary.BigEndian.PutUint32(aad, version)
+	return append(aad, kg...)
+}
+
+// valueAAD binds an encrypted value to its item, so that values cannot be swapped between items.
+func valueAAD(kg, id string) []byte {
+	return []byte(kg + "\x00" + id)
+}
+
+func seal(g cipher.AEAD, plaintext []byte, aad []byte, prefix []byte) ([]byte, error) {
+	nonce := make([]byte, g.NonceSize())
+
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, errors.New(err)
+	}
+
+	return g.Seal(append(prefix, nonce...), nonce, plaintext, aad), nil
+}
+
+func open(g cipher.AEAD, sealed []byte, aad []byte) ([]byte, error) {
+	if len(sealed) < g.NonceSize() {
+		return nil, errors.Errorf("ciphertext is too short")
+	}
+
+	return g.Open(nil, sealed[:g.NonceSize()], sealed[g.NonceSize():], aad)
+}
+
+// unwrap returns the data keys of a keygroup. It reports whether they are wrapped with the old master key.
+func (e *encryptedStore) unwrap(kg string, stored dataKeys) (map[uint32]cipher.AEAD, bool, error) {
+	aeads := make(map[uint32]cipher.AEAD, len(stored.Keys))
+	rewrap := false
+
+	for _, k := range stored.Keys {
+		key, err := open(e.master, k.Wrapped, wrapAAD(kg, k.Version))
+
+		if err != nil && e.old != nil {
+			key, err = open(e.old, k.Wrapped, wrapAAD(kg, k.Version))
+			rewrap = true
+		}
+
+		if err != nil {
+			return nil, false, errors.Errorf("could not unwrap data key %d of keygroup %s, the master key may be wrong", k.Version, kg)
+		}
+
+		g, err := newGCM(key)
+
+		if err != nil {
+			return nil, false, err
+		}
+
+		aeads[k.Version] = g
+	}
+
+	return aeads, rewrap, nil
+}
+
+// save wraps the data keys of a keygroup with the master key and stores them. Must be called with the lock held.
+func (e *encryptedStore) save(kg string, kr *keyring, keys map[uint32][]byte) error {
+	for i, k := range kr.stored.Keys {
+		key, ok := keys[k.Version]
+
+		if !ok {
+			continue
+		}
+
+		wrapped, err := seal(e.master, key, wrapAAD(kg, k.Version), nil)
+
+		if err != nil {
+			return err
+		}
+
+		kr.stored.Keys[i].Wrapped = wrapped
+	}
+
+	b, err := json.Marshal(kr.stored)
+
+	if err != nil {
+		return errors.New(err)
+	}
+
+	if err := e.Store.Update(dataKeysKeygroup, kg, string(b), false, 0); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+// newKey adds a new current data key to a keyring and stores it. Must be called with the lock held.
+func (e *encryptedStore) newKey(kg string, kr *keyring) error {
+	key := make([]byte, EncryptionKeySize)
+
+	if _, err := rand.Read(key); err != nil {
+		return errors.New(err)
+	}
+
+	g, err := newGCM(key)
+
+	if err != nil {
+		return err
+	}
+
+	previous := kr.stored.Current
+	version := previous + 1
+	now := time.Now()
+
+	kr.stored.Current = version
+	kr.stored.Keys = append(kr.stored.Keys, dataKey{Version: version, Created: now})
+
+	if err := e.save(kg, kr, map[uint32][]byte{version: key}); err != nil {
+		kr.stored.Current = previous
+		kr.stored.Keys = kr.stored.Keys[:len(kr.stored.Keys)-1]
+		return err
+	}
+
+	kr.aeads[version] = g
+	kr.created = now
+
+	return nil
+}
+
+// keyring returns the data keys of a keygroup. If create is set, a keygroup without data keys gets a new one.
+func (e *encryptedStore) keyring(kg string, create bool) (*keyring, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if kr, ok := e.keyrings[kg]; ok {
+		return kr, nil
+	}
+
+	if e.master == nil {
+		return nil, errors.Errorf("values of keygroup %s are encrypted but no encryption key is configured", kg)
+	}
+
+	kr := &keyring{aeads: make(map[uint32]cipher.AEAD)}
+
+	if e.Store.Exists(dataKeysKeygroup, kg) {
+		val, err := e.Store.Read(dataKeysKeygroup, kg)
+
+		if err != nil {
+			return nil, errors.New(err)
+		}
+
+		if err := json.Unmarshal([]byte(val), &kr.stored); err != nil {
+			return nil, errors.Errorf("malformed data keys of keygroup %s: %v", kg, err)
+		}
+
+		aeads, rewrap, err := e.unwrap(kg, kr.stored)
+
+		if err != nil {
+			return nil, err
+		}
+
+		kr.aeads = aeads
+
+		for _, k := range kr.stored.Keys {
+			if k.Version == kr.stored.Current {
+				kr.created = k.Created
+			}
+		}
+
+		if rewrap {
+			if err := e.rewrap(kg, kr); err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		if !create {
+			return nil, errors.Errorf("keygroup %s has no data keys", kg)
+		}
+
+		if err := e.newKey(kg, kr); err != nil {
+			return nil, err
+		}
+	}
+
+	e.keyrings[kg] = kr
+
+	return kr, nil
+}
+
+// rewrap wraps data keys that were wrapped with the old master key with the new one. The data keys themselves may have
+// been exposed with the old master key, so they are replaced by a new data key as well. Must be called with the lock
+// held.
+func (e *encryptedStore) rewrap(kg string, kr *keyring) error {
+	keys := make(map[uint32][]byte, len(kr.stored.Keys))
+
+	for _, k := range kr.stored.Keys {
+		key, err := open(e.old, k.Wrapped, wrapAAD(kg, k.Version))
+
+		if err != nil {
+			// already wrapped with the new master key
+			if key, err = open(e.master, k.Wrapped, wrapAAD(kg, k.Version)); err != nil {
+				return errors.Errorf("could not unwrap data key %d of keygroup %s", k.Version, kg)
+			}
+		}
+
+		keys[k.Version] = key
+	}
+
+	if err := e.save(kg, kr, keys); err != nil {
+		return err
+	}
+
+	log.Info().Msgf("data keys of keygroup %s are now wrapped with the new master key", kg)
+
+	return e.newKey(kg, kr)
+}
+
+// encrypt returns the stored value for a value of an item.
+func (e *encryptedStore) encrypt(kg, id, val string) (string, error) {
+	if !e.encrypts(kg) {
+		return val, nil
+	}
+
+	kr, err := e.keyring(kg, true)
+
+	if err != nil {
+		return "", err
+	}
+
+	e.mu.Lock()
+	version := kr.stored.Current
+	g := kr.aeads[version]
+	e.mu.Unlock()
+
+	prefix := make([]byte, encryptedOverhead)
+	prefix[0] = headerEncrypted
+	binary.BigEndian.PutUint32(prefix[1:], version)
+
+	sealed, err := seal(g, []byte(val), valueAAD(kg, id), prefix)
+
+	if err != nil {
+		return "", err
+	}
+
+	return string(sealed), nil
+}
+
+// version returns the version of the data key that a stored value is encrypted with, or false if it is not encrypted.
+func version(stored string) (uint32, bool) {
+	if len(stored) < encryptedOverhead || stored[0] != headerEncrypted {
+		return 0, false
+	}
+
+	return binary.BigEndian.Uint32([]byte(stored[1:encryptedOverhead])), true
+}
+
+// decrypt returns the original value of a stored value.
+func (e *encryptedStore) decrypt(kg, id, stored string) (string, error) {
+	v, ok := version(stored)
+
+	if !ok {
+		return stored, nil
+	}
+
+	kr, err := e.keyring(kg, false)
+
+	if err != nil {
+		return "", err
+	}
+
+	e.mu.Lock()
+	g, ok := kr.aeads[v]
+	e.mu.Unlock()
+
+	if !ok {
+		return "", errors.Errorf("item %s in keygroup %s is encrypted with unknown data key %d", id, kg, v)
+	}
+
+	val, err := open(g, []byte(stored[encryptedOverhead:]), valueAAD(kg, id))
+
+	if err != nil {
+		return "", errors.Errorf("could not decrypt item %s in keygroup %s", id, kg)
+	}
+
+	return string(val), nil
+}
+
+func (e *encryptedStore) decryptAll(kg string, items map[string]string) (map[string]string, error) {
+	for id, stored := range items {
+		val, err := e.decrypt(kg, id, stored)
+
+		if err != nil {
+			return nil, err
+		}
+
+		items[id] = val
+	}
+
+	return items, nil
+}
+
+func (e *encryptedStore) Update(kg, id, val string, append bool, expiry int) error {
+	// values are encrypted under the lock, so that a sweep cannot drop their data key before they are stored
+	l := e.locks.get(kg, id)
+	l.Lock()
+	defer l.Unlock()
+
+	stored, err := e.encrypt(kg, id, val)
+
+	if err != nil {
+		return err
+	}
+
+	return e.Store.Update(kg, id, stored, append, expiry)
+}
+
+func (e *encryptedStore) Append(kg, id, val string, expiry int) error {
+	l := e.locks.get(kg, id)
+	l.Lock()
+	defer l.Unlock()
+
+	stored, err := e.encrypt(kg, id, val)
+
+	if err != nil {
+		return err
+	}
+
+	return e.Store.Append(kg, id, stored, expiry)
+}
+
+// UpdateBulk writes the items of encrypted keygroups one by one, as each of them is encrypted under its lock.
+func (e *encryptedStore) UpdateBulk(items []BulkItem) error {
+	var plain []BulkItem
+
+	for _, i := range items {
+		if !e.encrypts(i.Keygroup) {
+			plain = append(plain, i)
+			continue
+		}
+
+		if err := e.Update(i.Keygroup, i.ID, i.Val, i.Append, i.Expiry); err != nil {
+			return err
+		}
+	}
+
+	return updateBulk(e.Store, plain)
+}
+
+func (e *encryptedStore) Delete(kg, id string) error {
+	l := e.locks.get(kg, id)
+	l.Lock()
+	defer l.Unlock()
+
+	return e.Store.Delete(kg, id)
+}
+
+// DeleteKeygroup also deletes the data keys of the keygroup, so that copies of its values can no longer be decrypted.
+func (e *encryptedStore) DeleteKeygroup(kg string) error {
+	if err := e.Store.DeleteKeygroup(kg); err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	delete(e.keyrings, kg)
+
+	if e.master != nil && e.Store.Exists(dataKeysKeygroup, kg) {
+		return e.Store.Delete(dataKeysKeygroup, kg)
+	}
+
+	return nil
+}
+
+func (e *encryptedStore) Read(kg, id string) (string, error) {
+	stored, err := e.Store.Read(kg, id)
+
+	if err != nil {
+		return "", err
+	}
+
+	return e.decrypt(kg, id, stored)
+}
+
+func (e *encryptedStore) ReadSome(kg, id string, count uint64) (map[string]string, error) {
+	items, err := e.Store.ReadSome(kg, id, count)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return e.decryptAll(kg, items)
+}
+
+func (e *encryptedStore) ReadAll(kg string) (map[string]string, error) {
+	items, err := e.Store.ReadAll(kg)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return e.decryptAll(kg, items)
+}
+
+// Export decrypts values and keeps the expiry of items if the underlying store is an Exporter.
+func (e *encryptedStore) Export(kg string, fn func(id string, val string, expiresAt int64) error) error {
+	return exportStore(e.Store, kg, func(id string, stored string, expiresAt int64) error {
+		val, err := e.decrypt(kg, id, stored)
+
+		if err != nil {
+			return err
+		}
+
+		return fn(id, val, expiresAt)
+	})
+}
+
+// sweep encrypts all values of a keygroup that are not encrypted with its current data key, e.g., because they were
+// stored before encryption was turned on or before the data key was replaced. Older data keys are dropped once no
+// value needs them anymore.
+func (e *encryptedStore) sweep(kg string) error {
+	kr, err := e.keyring(kg, true)
+
+	if err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	current := kr.stored.Current
+	e.mu.Unlock()
+
+	swept := 0
+
+	err = exportStore(e.Store, kg, func(id string, stored string, expiresAt int64) error {
+		if v, ok := version(stored); ok && v == current {
+			return nil
+		}
+
+		expiry := 0
+
+		if expiresAt != 0 {
+			left := time.Until(time.Unix(expiresAt, 0))
+
+			if left <= 0 {
+				return nil
+			}
+
+			expiry = int((left + time.Second - 1) / time.Second)
+		}
+
+		l := e.locks.get(kg, id)
+		l.Lock()
+		defer l.Unlock()
+
+		// the item may have been written since it was exported, in which case it is already encrypted with a current key
+		now, err := e.Store.Read(kg, id)
+
+		if err != nil || now != stored {
+			return nil
+		}
+
+		val, err := e.decrypt(kg, id, stored)
+
+		if err != nil {
+			return err
+		}
+
+		sealed, err := e.encrypt(kg, id, val)
+
+		if err != nil {
+			return err
+		}
+
+		swept++
+
+		return e.Store.Update(kg, id, sealed, false, expiry)
+	})
+
+	if err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if len(kr.stored.Keys) > 1 && kr.stored.Current == current {
+		kr.stored.Keys = []dataKey{kr.stored.Keys[len(kr.stored.Keys)-1]}
+		kr.aeads = map[uint32]cipher.AEAD{current: kr.aeads[current]}
+
+		if err := e.save(kg, kr, nil); err != nil {
+			return err
+		}
+	}
+
+	if swept > 0 {
+		log.Info().Msgf("encrypted %d values of keygroup %s with data key %d", swept, kg, current)
+	}
+
+	return nil
+}
+
+// rotate replaces the data key of a keygroup and re-encrypts its values with the new key.
+func (e *encryptedStore) rotate(kg string) error {
+	kr, err := e.keyring(kg, true)
+
+	if err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	err = e.newKey(kg, kr)
+	e.mu.Unlock()
+
+	if err != nil {
+		return err
+	}
+
+	return e.sweep(kg)
+}
+
+// run encrypts all values that need to be encrypted and then replaces data keys that are older than the rotation
+// interval. A rotation interval of 0 keeps data keys forever.
+func (e *encryptedStore) run(rotation time.Duration) {
+	if e.master == nil {
+		return
+	}
+
+	e.each(e.sweep)
+
+	if rotation <= 0 {
+		return
+	}
+
+	check := time.Hour
+
+	if rotation < check {
+		check = rotation
+	}
+
+	for range time.Tick(check) {
+		e.each(func(kg string) error {
+			kr, err := e.keyring(kg, true)
+
+			if err != nil {
+				return err
+			}
+
+			e.mu.Lock()
+			due := time.Since(kr.created) >= rotation
+			e.mu.Unlock()
+
+			if !due {
+				return nil
+			}
+
+			return e.rotate(kg)
+		})
+	}
+}
+
+// each calls fn for every keygroup in the store that is encrypted. Internal keygroups are only included once values
+// have been encrypted in them, as some are written to the store directly and would not be readable if encrypted.
+func (e *encryptedStore) each(fn func(kg string) error) {
+	kgs, err := e.Store.Keygroups()
+
+	if err != nil {
+		log.Err(err).Msg("could not list keygroups to encrypt")
+		return
+	}
+
+	for _, kg := range kgs {
+		if !e.encrypts(kg) {
+			continue
+		}
+
+		if strings.HasPrefix(kg, "_") && !e.Store.Exists(dataKeysKeygroup, kg) {
+			continue
+		}
+
+		if err := fn(kg); err != nil {
+			log.Err(err).Msgf("could not encrypt values of keygroup %s", kg)
+		}
+	}
+}
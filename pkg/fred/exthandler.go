@@ -1,6 +1,9 @@
 package fred
 
 import (
+	"io"
+	"time"
+
 	"github.com/go-errors/errors"
 	"github.com/rs/zerolog/log"
 )
@@ -10,22 +13,49 @@ type exthandler struct {
 	r *replicationService
 	t *triggerService
 	a *authService
+	l AuditLog
 	n NameService
 }
 
-// newExthandler creates a new handler for client request (i.e. from clients).
-func newExthandler(s *storeService, r *replicationService, t *triggerService, a *authService, n NameService) *exthandler {
+// newExthandler creates a new handler for client request (i.e. from clients). The audit log may be nil if auditing is
+// disabled.
+func newExthandler(s *storeService, r *replicationService, t *triggerService, a *authService, l AuditLog, n NameService) *exthandler {
 	return &exthandler{
 		s: s,
 		r: r,
 		t: t,
 		a: a,
+		l: l,
 		n: n,
 	}
 }
 
+// WithGroups returns a handler that treats users as members of the given groups in addition to the groups stored in
+// the NameService, e.g., because their token says so.
+func (h *exthandler) WithGroups(groups []string) ExtHandler {
+	if len(groups) == 0 {
+		return h
+	}
+
+	c := *h
+	c.a = h.a.withGroups(groups)
+
+	return &c
+}
+
+// Revision returns the NaSe revision that this node's view of keygroups, replicas and permissions reflects. Answers
+// that are based on that view reflect at least the revision returned before they were requested.
+func (h *exthandler) Revision() int64 {
+	return h.n.Revision()
+}
+
 // HandleCreateKeygroup handles requests to the CreateKeygroup endpoint of the client interface.
 func (h *exthandler) HandleCreateKeygroup(user string, k Keygroup) error {
+	allowed, err := h.a.isAllowedToCreate(user, k.Name)
+
+	if err != nil || !allowed {
+		return errors.Errorf("user %s cannot create keygroup %s", user, k.Name)
+	}
 
 	if err := h.r.createKeygroup(k); err != nil {
 		log.Debug().Msg(err.(*errors.Error).ErrorStack())
@@ -40,7 +70,7 @@ func (h *exthandler) HandleCreateKeygroup(user string, k Keygroup) error {
 	}
 
 	// when a user creates a keygroup, they should have all rights for that keygroup
-	err := h.a.addRoles(user, []Role{ReadKeygroup, WriteKeygroup, ConfigureReplica, ConfigureTrigger, ConfigureKeygroups}, k.Name)
+	err = h.a.addRoles(Subject{Name: user}, []Role{ReadKeygroup, WriteKeygroup, ConfigureReplica, ConfigureTrigger, ConfigureKeygroups}, k.Name)
 
 	if err != nil {
 		return err
@@ -57,6 +87,11 @@ func (h *exthandler) HandleDeleteKeygroup(user string, k Keygroup) error {
 		return errors.Errorf("user %s cannot delete keygroup %s", user, k.Name)
 	}
 
+	// this has to happen before the keygroup and its trigger nodes are gone
+	if err := h.t.triggerDeleteKeygroup(k.Name, h.n.GetNodeID()); err != nil {
+		log.Err(err).Msgf("could not queue trigger events for deleting keygroup %s", k.Name)
+	}
+
 	if err := h.s.deleteKeygroup(k.Name); err != nil {
 		log.Err(err).Msg(err.(*errors.Error).ErrorStack())
 
@@ -98,10 +133,10 @@ func (h *exthandler) HandleRead(user string, i Item) (Item, error) {
 
 // HandleScan handles requests to the Scan endpoint of the client interface.
 func (h *exthandler) HandleScan(user string, i Item, count uint64) ([]Item, error) {
-	allowed, err := h.a.isAllowed(user, Read, i.Keygroup)
+	allowed, err := h.a.isAllowed(user, Scan, i.Keygroup)
 
 	if err != nil || !allowed {
-		return nil, errors.Errorf("user %s cannot read from keygroup %s", user, i.Keygroup)
+		return nil, errors.Errorf("user %s cannot scan keygroup %s", user, i.Keygroup)
 	}
 
 	if count <= 0 {
@@ -122,10 +157,10 @@ func (h *exthandler) HandleScan(user string, i Item, count uint64) ([]Item, erro
 
 // HandleAppend handles requests to the Append endpoint of the client interface.
 func (h *exthandler) HandleAppend(user string, i Item) (Item, error) {
-	allowed, err := h.a.isAllowed(user, Update, i.Keygroup)
+	allowed, err := h.a.isAllowed(user, Append, i.Keygroup)
 
 	if err != nil || !allowed {
-		return i, errors.Errorf("user %s cannot update in keygroup %s", user, i.Keygroup)
+		return i, errors.Errorf("user %s cannot append to keygroup %s", user, i.Keygroup)
 	}
 
 	log.Debug().Msgf("checking if keygroup %s is mutable...", i.Keygroup)
@@ -147,7 +182,13 @@ func (h *exthandler) HandleAppend(user string, i Item) (Item, error) {
 		return i, err
 	}
 
-	result, err := h.s.append(i, expiry)
+	r, err := h.s.reserveAppendQuota(i, expiry)
+
+	if err != nil {
+		return i, err
+	}
+
+	result, err := h.s.append(i, expiry, r)
 
 	if err != nil {
 		log.Printf("%#v", err)
@@ -160,9 +201,9 @@ func (h *exthandler) HandleAppend(user string, i Item) (Item, error) {
 		return result, errors.Errorf("error updating item")
 	}
 
-	if err := h.t.triggerUpdate(result); err != nil {
-		log.Err(err).Msg(err.(*errors.Error).ErrorStack())
-		return result, errors.Errorf("error updating item")
+	// trigger nodes are informed in the background, a failure here should not fail the write
+	if err := h.t.triggerAppend(result, expiry, h.n.GetNodeID()); err != nil {
+		log.Err(err).Msgf("could not queue trigger events for item %s in keygroup %s", result.ID, result.Keygroup)
 	}
 
 	return result, nil
@@ -195,20 +236,30 @@ func (h *exthandler) HandleUpdate(user string, i Item) error {
 		return err
 	}
 
+	// quotas are checked before the update is sent to other replicas, so that a refused update is refused everywhere,
+	// and what the update needs is reserved, so that concurrent updates cannot exceed the quota together
+	r, err := h.s.reserveQuota(i, expiry)
+
+	if err != nil {
+		return err
+	}
+
+	defer h.s.q.release(r)
+
 	if err := h.r.relayUpdate(i); err != nil {
 		log.Err(err).Msg(err.(*errors.Error).ErrorStack())
 		return errors.Errorf("error updating item")
 	}
 
-	if err := h.s.update(i, false, expiry); err != nil {
+	if err := h.s.updateReserved(i, false, expiry, r); err != nil {
 		log.Printf("%#v", err)
 		log.Err(err).Msg(err.(*errors.Error).ErrorStack())
 		return errors.Errorf("error updating item")
 	}
 
-	if err := h.t.triggerUpdate(i); err != nil {
-		log.Err(err).Msg(err.(*errors.Error).ErrorStack())
-		return errors.Errorf("error updating item")
+	// trigger nodes are informed in the background, a failure here should not fail the write
+	if err := h.t.triggerUpdate(i, expiry, h.n.GetNodeID()); err != nil {
+		log.Err(err).Msgf("could not queue trigger events for item %s in keygroup %s", i.ID, i.Keygroup)
 	}
 
 	return nil
@@ -246,9 +297,9 @@ func (h *exthandler) HandleDelete(user string, i Item) error {
 		return errors.Errorf("error deleting item")
 	}
 
-	if err := h.t.triggerDelete(i); err != nil {
-		log.Err(err).Msg(err.(*errors.Error).ErrorStack())
-		return errors.Errorf("error deleting item")
+	// trigger nodes are informed in the background, a failure here should not fail the write
+	if err := h.t.triggerDelete(i, h.n.GetNodeID()); err != nil {
+		log.Err(err).Msgf("could not queue trigger events for item %s in keygroup %s", i.ID, i.Keygroup)
 	}
 
 	return nil
@@ -262,6 +313,10 @@ func (h *exthandler) HandleAddReplica(user string, k Keygroup, n Node) error {
 		return errors.Errorf("user %s cannot add replica to keygroup %s", user, k.Name)
 	}
 
+	if err := h.r.checkCapacity(k, n); err != nil {
+		return err
+	}
+
 	if err := h.r.addReplica(k, n, true); err != nil {
 		log.Error().Msgf("Error in AddReplica is: %#v", err)
 		log.Err(err).Msg(err.(*errors.Error).ErrorStack())
@@ -273,7 +328,7 @@ func (h *exthandler) HandleAddReplica(user string, k Keygroup, n Node) error {
 
 // HandleGetKeygroupReplica handles requests to the GetKeygroupReplica endpoint of the client interface.
 func (h *exthandler) HandleGetKeygroupReplica(user string, k Keygroup) ([]Node, map[NodeID]int, error) {
-	allowed, err := h.a.isAllowed(user, GetReplica, k.Name)
+	allowed, err := h.a.isAllowed(user, GetKeygroupReplica, k.Name)
 
 	if err != nil || !allowed {
 		return nil, nil, errors.Errorf("user %s cannot get replica for keygroup %s", user, k.Name)
@@ -282,6 +337,24 @@ func (h *exthandler) HandleGetKeygroupReplica(user string, k Keygroup) ([]Node,
 	return h.r.getReplica(k)
 }
 
+// HandleGetKeygroupUsage handles requests to the GetKeygroupUsage endpoint of the client interface.
+func (h *exthandler) HandleGetKeygroupUsage(user string, k Keygroup) (KeygroupUsage, error) {
+	allowed, err := h.a.isAllowed(user, GetUsage, k.Name)
+
+	if err != nil || !allowed {
+		return KeygroupUsage{}, errors.Errorf("user %s cannot get usage of keygroup %s", user, k.Name)
+	}
+
+	u, err := h.s.usage(k.Name)
+
+	if err != nil {
+		log.Err(err).Msg(err.(*errors.Error).ErrorStack())
+		return KeygroupUsage{}, errors.Errorf("error getting usage of keygroup")
+	}
+
+	return u, nil
+}
+
 // HandleRemoveReplica handles requests to the RemoveKeygroupReplica endpoint of the client interface.
 func (h *exthandler) HandleRemoveReplica(user string, k Keygroup, n Node) error {
 	allowed, err := h.a.isAllowed(user, RemoveReplica, k.Name)
@@ -306,6 +379,10 @@ func (h *exthandler) HandleAddTrigger(user string, k Keygroup, t Trigger) error
 		return errors.Errorf("user %s cannot add trigger to keygroup %s", user, k.Name)
 	}
 
+	if err := h.checkTrigger(user, k, t); err != nil {
+		return err
+	}
+
 	if err := h.t.addTrigger(k, t); err != nil {
 		log.Error().Msgf("Error in AddTrigger is: %#v", err)
 		log.Err(err).Msg(err.(*errors.Error).ErrorStack())
@@ -314,6 +391,51 @@ func (h *exthandler) HandleAddTrigger(user string, k Keygroup, t Trigger) error
 	return nil
 }
 
+// checkTrigger makes sure that a trigger is valid and that a user may add it to a keygroup.
+func (h *exthandler) checkTrigger(user string, k Keygroup, t Trigger) error {
+	if _, err := t.Filter.compile(); err != nil {
+		return err
+	}
+
+	if t.Webhook.URL != "" {
+		if err := checkWebhook(t.Webhook); err != nil {
+			return err
+		}
+	}
+
+	if t.Compute.Target != "" {
+		if err := h.checkCompute(user, k, t); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkCompute makes sure that a user may let a trigger compute its target keygroup. Users can only compute keygroups
+// that they could write themselves.
+func (h *exthandler) checkCompute(user string, k Keygroup, t Trigger) error {
+	for _, m := range []Method{Update, Delete} {
+		allowed, err := h.a.isAllowed(user, m, t.Compute.Target)
+
+		if err != nil || !allowed {
+			return errors.Errorf("user %s cannot compute keygroup %s", user, t.Compute.Target)
+		}
+	}
+
+	mutable, err := h.n.IsMutable(t.Compute.Target)
+
+	if err != nil {
+		return err
+	}
+
+	if !mutable {
+		return errors.Errorf("cannot compute keygroup %s because it is immutable", t.Compute.Target)
+	}
+
+	return h.t.checkCompute(k.Name, t)
+}
+
 // HandleGetKeygroupTriggers handles requests to the GetKeygroupTrigger endpoint of the client interface.
 func (h *exthandler) HandleGetKeygroupTriggers(user string, k Keygroup) ([]Trigger, error) {
 	allowed, err := h.a.isAllowed(user, GetTrigger, k.Name)
@@ -341,6 +463,40 @@ func (h *exthandler) HandleRemoveTrigger(user string, k Keygroup, t Trigger) err
 	return nil
 }
 
+// HandleGetFailedTriggerEvents handles requests to the GetFailedTriggerEvents endpoint of the client interface.
+func (h *exthandler) HandleGetFailedTriggerEvents(user string, k Keygroup, triggerID string) ([]TriggerEvent, error) {
+	allowed, err := h.a.isAllowed(user, GetTriggerEvents, k.Name)
+
+	if err != nil || !allowed {
+		return nil, errors.Errorf("user %s cannot get trigger events for keygroup %s", user, k.Name)
+	}
+
+	res, err := h.t.getFailedEvents(k, triggerID)
+
+	if err != nil {
+		log.Err(err).Msg(err.(*errors.Error).ErrorStack())
+		return nil, errors.Errorf("error getting trigger events")
+	}
+
+	return res, nil
+}
+
+// HandleReplayTriggerEvents handles requests to the ReplayTriggerEvents endpoint of the client interface.
+func (h *exthandler) HandleReplayTriggerEvents(user string, k Keygroup, triggerID string, seqs []uint64) error {
+	allowed, err := h.a.isAllowed(user, ReplayTriggerEvents, k.Name)
+
+	if err != nil || !allowed {
+		return errors.Errorf("user %s cannot replay trigger events for keygroup %s", user, k.Name)
+	}
+
+	if err := h.t.replay(k, triggerID, seqs); err != nil {
+		log.Err(err).Msg(err.(*errors.Error).ErrorStack())
+		return errors.Errorf("error replaying trigger events: %v", err)
+	}
+
+	return nil
+}
+
 // HandleGetReplica handles requests to the GetReplica endpoint of the client interface.
 func (h *exthandler) HandleGetReplica(user string, n Node) (Node, error) {
 
@@ -361,7 +517,7 @@ func (h *exthandler) HandleAddUser(user string, newuser string, k Keygroup, r Ro
 		return errors.Errorf("user %s cannot add user permissions to keygroup %s", user, k.Name)
 	}
 
-	return h.a.addRoles(newuser, []Role{r}, k.Name)
+	return h.a.addRoles(Subject{Name: newuser}, []Role{r}, k.Name)
 }
 
 // RemoveUser removes permissions to a keygroup to a user.
@@ -372,5 +528,305 @@ func (h *exthandler) HandleRemoveUser(user string, newuser string, k Keygroup, r
 		return errors.Errorf("user %s cannot remove user permissions to keygroup %s", user, k.Name)
 	}
 
-	return h.a.revokeRoles(newuser, []Role{r}, k.Name)
+	return h.a.revokeRoles(Subject{Name: newuser}, []Role{r}, k.Name)
+}
+
+// HandleAddGroupRole grants a role on a keygroup to all members of a group.
+func (h *exthandler) HandleAddGroupRole(user string, group string, k Keygroup, r Role) error {
+	allowed, err := h.a.isAllowed(user, AddUser, k.Name)
+
+	if err != nil || !allowed {
+		return errors.Errorf("user %s cannot add group permissions to keygroup %s", user, k.Name)
+	}
+
+	if err := checkName(group); err != nil {
+		return err
+	}
+
+	return h.a.addRoles(Subject{Name: group, Group: true}, []Role{r}, k.Name)
+}
+
+// HandleRemoveGroupRole revokes a role on a keygroup from a group.
+func (h *exthandler) HandleRemoveGroupRole(user string, group string, k Keygroup, r Role) error {
+	allowed, err := h.a.isAllowed(user, RemoveUser, k.Name)
+
+	if err != nil || !allowed {
+		return errors.Errorf("user %s cannot remove group permissions to keygroup %s", user, k.Name)
+	}
+
+	return h.a.revokeRoles(Subject{Name: group, Group: true}, []Role{r}, k.Name)
+}
+
+// HandleGetKeygroupPermissions lists all users and groups with the methods they may perform on a keygroup.
+func (h *exthandler) HandleGetKeygroupPermissions(user string, k Keygroup) (map[Subject]map[Method]struct{}, error) {
+	allowed, err := h.a.isAllowed(user, GetPermissions, k.Name)
+
+	if err != nil || !allowed {
+		return nil, errors.Errorf("user %s cannot get permissions for keygroup %s", user, k.Name)
+	}
+
+	return h.a.getPermissions(k.Name)
+}
+
+// HandleAddRole adds methods to a custom role, creating the role if it does not exist yet.
+func (h *exthandler) HandleAddRole(user string, r Role, m []Method) error {
+	allowed, err := h.a.isAllowed(user, ConfigureRoles, AllKeygroups)
+
+	if err != nil || !allowed {
+		return errors.Errorf("user %s cannot configure roles", user)
+	}
+
+	return h.a.addRole(r, m)
+}
+
+// HandleRemoveRole removes a custom role and all of its bindings.
+func (h *exthandler) HandleRemoveRole(user string, r Role) error {
+	allowed, err := h.a.isAllowed(user, ConfigureRoles, AllKeygroups)
+
+	if err != nil || !allowed {
+		return errors.Errorf("user %s cannot configure roles", user)
+	}
+
+	return h.a.removeRole(r)
+}
+
+// HandleGetRoles lists all built-in and custom roles with their methods.
+func (h *exthandler) HandleGetRoles(user string) (map[Role]map[Method]struct{}, error) {
+
+	return h.a.getRoles()
+}
+
+// HandleAddGroupMember adds a user to a group.
+func (h *exthandler) HandleAddGroupMember(user string, group string, member string) error {
+	allowed, err := h.a.isAllowed(user, ConfigureGroups, AllKeygroups)
+
+	if err != nil || !allowed {
+		return errors.Errorf("user %s cannot configure groups", user)
+	}
+
+	return h.a.addGroupMember(group, member)
+}
+
+// HandleRemoveGroupMember removes a user from a group.
+func (h *exthandler) HandleRemoveGroupMember(user string, group string, member string) error {
+	allowed, err := h.a.isAllowed(user, ConfigureGroups, AllKeygroups)
+
+	if err != nil || !allowed {
+		return errors.Errorf("user %s cannot configure groups", user)
+	}
+
+	return h.a.removeGroupMember(group, member)
+}
+
+// HandleGetGroupMembers lists all members of a group.
+func (h *exthandler) HandleGetGroupMembers(user string, group string) ([]string, error) {
+	allowed, err := h.a.isAllowed(user, ConfigureGroups, AllKeygroups)
+
+	if err != nil || !allowed {
+		return nil, errors.Errorf("user %s cannot configure groups", user)
+	}
+
+	return h.n.GetGroupMembers(group)
+}
+
+// HandleGetAuditLog returns the most recent audit entries for a keygroup on this node.
+func (h *exthandler) HandleGetAuditLog(user string, q AuditQuery) ([]AuditEntry, error) {
+	allowed, err := h.a.isAllowed(user, GetAuditLog, q.Keygroup)
+
+	if err != nil || !allowed {
+		return nil, errors.Errorf("user %s cannot get audit log for keygroup %s", user, q.Keygroup)
+	}
+
+	if h.l == nil {
+		return nil, errors.Errorf("audit log is not enabled on this node")
+	}
+
+	if q.Limit <= 0 {
+		return nil, errors.Errorf("limit must be at least 1, got %d", q.Limit)
+	}
+
+	res, err := h.l.Query(q)
+
+	if err != nil {
+		log.Err(err).Msg(err.(*errors.Error).ErrorStack())
+		return nil, errors.Errorf("error reading audit log")
+	}
+
+	return res, nil
+}
+
+// HandleBackup handles requests to the Backup endpoint of the client interface. It writes the configuration of a
+// keygroup and all its items on this node to w.
+func (h *exthandler) HandleBackup(user string, k Keygroup, w io.Writer) error {
+	allowed, err := h.a.isAllowed(user, Backup, k.Name)
+
+	if err != nil || !allowed {
+		return errors.Errorf("user %s cannot back up keygroup %s", user, k.Name)
+	}
+
+	mutable, err := h.n.IsMutable(k.Name)
+
+	if err != nil {
+		return err
+	}
+
+	expiry, err := h.n.GetExpiry(k.Name)
+
+	if err != nil {
+		return err
+	}
+
+	_, replicas, err := h.r.getReplica(k)
+
+	if err != nil {
+		return err
+	}
+
+	triggers, err := h.s.getKeygroupTrigger(k.Name)
+
+	if err != nil {
+		return err
+	}
+
+	b, err := newBackupWriter(w, backupHeader{
+		Keygroup: k.Name,
+		Mutable:  mutable,
+		Expiry:   expiry,
+		Node:     h.n.GetNodeID(),
+		Time:     time.Now(),
+		Replicas: replicas,
+		Triggers: triggers,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	err = h.s.export(k.Name, func(i Item, expiresAt int64) error {
+		return b.write(backupItem{
+			ID:        i.ID,
+			Val:       i.Val,
+			ExpiresAt: expiresAt,
+		})
+	})
+
+	if err != nil {
+		log.Err(err).Msg(err.(*errors.Error).ErrorStack())
+		return errors.Errorf("error backing up keygroup %s", k.Name)
+	}
+
+	return b.close()
+}
+
+// HandleRestore handles requests to the Restore endpoint of the client interface. It recreates a keygroup from a
+// backup on this node, with its items and trigger nodes, and then adds the other replicas of the backup again, which
+// get the restored items from this node. Items that have expired since the backup are left out.
+func (h *exthandler) HandleRestore(user string, r io.Reader) (Keygroup, error) {
+	// only touch anything once we know that the backup is complete
+	header, items, err := readBackup(r)
+
+	if err != nil {
+		return Keygroup{}, err
+	}
+
+	k := Keygroup{
+		Name:    header.Keygroup,
+		Mutable: header.Mutable,
+		Expiry:  header.Expiry,
+	}
+
+	allowed, err := h.a.isAllowed(user, Restore, k.Name)
+
+	if err != nil || !allowed {
+		return k, errors.Errorf("user %s cannot restore keygroup %s", user, k.Name)
+	}
+
+	exists, err := h.n.ExistsKeygroup(k.Name)
+
+	if err != nil {
+		return k, err
+	}
+
+	if exists {
+		return k, errors.Errorf("cannot restore keygroup %s because it already exists", k.Name)
+	}
+
+	// anyone can write a backup, so its trigger nodes are checked just like trigger nodes that the user adds
+	for _, t := range header.Triggers {
+		if err := h.checkTrigger(user, k, t); err != nil {
+			return k, errors.Errorf("cannot restore trigger node %s of keygroup %s: %v", t.ID, k.Name, err)
+		}
+	}
+
+	if err := h.r.createKeygroup(k); err != nil {
+		log.Debug().Msg(err.(*errors.Error).ErrorStack())
+
+		return k, errors.Errorf("error restoring keygroup")
+	}
+
+	if err := h.s.createKeygroup(k.Name); err != nil {
+		log.Err(err).Msg(err.(*errors.Error).ErrorStack())
+
+		return k, errors.Errorf("error restoring keygroup")
+	}
+
+	now := time.Now().Unix()
+
+	for _, i := range items {
+		expiry := k.Expiry
+
+		if i.ExpiresAt > 0 {
+			if i.ExpiresAt <= now {
+				continue
+			}
+
+			expiry = int(i.ExpiresAt - now)
+		}
+
+		item := Item{Keygroup: k.Name, ID: i.ID, Val: i.Val}
+
+		r, err := h.s.reserveQuota(item, expiry)
+
+		if err != nil {
+			return k, err
+		}
+
+		if err := h.s.updateReserved(item, !k.Mutable, expiry, r); err != nil {
+			log.Err(err).Msg(err.(*errors.Error).ErrorStack())
+			return k, errors.Errorf("error restoring item %s", i.ID)
+		}
+	}
+
+	for _, t := range header.Triggers {
+		if err := h.t.addTrigger(k, t); err != nil {
+			log.Err(err).Msg(err.(*errors.Error).ErrorStack())
+			return k, errors.Errorf("error restoring trigger node %s", t.ID)
+		}
+	}
+
+	// a keygroup without its replicas is still better than none, so this only logs errors
+
+	for id, expiry := range header.Replicas {
+		if id == h.n.GetNodeID() {
+			continue
+		}
+
+		if err := h.r.checkCapacity(k, Node{ID: id}); err != nil {
+			log.Err(err).Msgf("could not restore replica %s of keygroup %s", id, k.Name)
+			continue
+		}
+
+		if err := h.r.addReplica(Keygroup{Name: k.Name, Expiry: expiry}, Node{ID: id}, true); err != nil {
+			log.Err(err).Msgf("could not restore replica %s of keygroup %s", id, k.Name)
+		}
+	}
+
+	// just like when creating a keygroup, the user gets all rights for it
+	err = h.a.addRoles(Subject{Name: user}, []Role{ReadKeygroup, WriteKeygroup, ConfigureReplica, ConfigureTrigger, ConfigureKeygroups}, k.Name)
+
+	if err != nil {
+		return k, err
+	}
+
+	return k, nil
 }
@@ -1,6 +1,10 @@
 package fred
 
 import (
+	"crypto/tls"
+	"io"
+	"time"
+
 	"github.com/go-errors/errors"
 	"github.com/rs/zerolog/log"
 )
@@ -15,28 +19,69 @@ type Config struct {
 	ExternalHost      string
 	ExternalHostProxy string
 	NodeID            string
-	TriggerCert       string
-	TriggerKey        string
-	TriggerCA         []string
+	TriggerTLS        *tls.Config
+	// TriggerMaxAttempts is how often delivering an event to a trigger node is tried before it becomes a dead letter.
+	TriggerMaxAttempts int
+	// TriggerMaxBackoff is the longest time to wait between two attempts to deliver an event to a trigger node.
+	TriggerMaxBackoff time.Duration
+	// TriggerBatchSize is the largest number of events that are sent to a trigger node at once.
+	TriggerBatchSize int
+	// TriggerBatchLatency is how long to wait for more events before sending a batch that is not full.
+	TriggerBatchLatency time.Duration
+	// TriggerIdleTimeout is how long a connection to a trigger node is kept open when it is not used.
+	TriggerIdleTimeout time.Duration
+	Admins             []string
+	RestrictCreate     bool
+	AuditLog           AuditLog
+	// NewAuditLog creates an audit log that is kept in the store of this node and replaces AuditLog. It gets the store
+	// with all of its decorators, so that the log is encrypted and compressed like everything else and cannot be written
+	// to while the store is migrated.
+	NewAuditLog func(store Store) (AuditLog, error)
+	// CacheSize is how many bytes the cache for reads from the store may take up. 0 disables the cache.
+	CacheSize int64
+	// CacheRules set which keygroups are cached and for how long. The first rule that matches a keygroup applies.
+	CacheRules []CacheRule
+	// CompressionRules set how the values of keygroups are compressed. The first rule that matches a keygroup applies.
+	CompressionRules []CompressionRule
+	// EncryptionKey is the master key that the data keys of keygroups are wrapped with. Values are not encrypted if it
+	// is nil.
+	EncryptionKey []byte
+	// EncryptionOldKey is a previous master key. Data keys that are wrapped with it are wrapped with the new one and
+	// replaced.
+	EncryptionOldKey []byte
+	// EncryptionKeygroups are patterns of keygroups whose values are encrypted.
+	EncryptionKeygroups []KeygroupName
+	// EncryptionRotation is how old data keys may get before values are re-encrypted with new ones. 0 keeps them.
+	EncryptionRotation time.Duration
+	// QuotaRules limit what keygroups may store on this node. The first rule that matches a keygroup applies.
+	QuotaRules []QuotaRule
+	// NodeMaxItems and NodeMaxBytes limit what all keygroups together may store on this node. 0 means no limit.
+	NodeMaxItems int64
+	NodeMaxBytes int64
 }
 
 // Fred is an instance of FReD.
 type Fred struct {
-	E ExtHandler
-	I IntHandler
+	E     ExtHandler
+	I     IntHandler
+	store *readOnlyStore
+	cache *cachedStore
+	comp  *compressedStore
+	enc   *encryptedStore
 }
 
 // IntHandler is an interface that abstracts the methods of the handler that handles internal requests.
 type IntHandler interface {
 	HandleCreateKeygroup(k Keygroup) error
-	HandleDeleteKeygroup(k Keygroup) error
-	HandleUpdate(i Item) error
-	HandleAppend(i Item) error
-	HandleDelete(i Item) error
+	HandleDeleteKeygroup(k Keygroup, origin NodeID) error
+	HandleUpdate(i Item, origin NodeID) error
+	HandleAppend(i Item, origin NodeID) error
+	HandleDelete(i Item, origin NodeID) error
 	HandleAddReplica(k Keygroup, n Node) error
 	HandleRemoveReplica(k Keygroup, n Node) error
 	HandleGet(i Item) (Item, error)
 	HandleGetAllItems(k Keygroup) ([]Item, error)
+	HandleGetKeygroupUsage(k Keygroup) (KeygroupUsage, error)
 }
 
 // ExtHandler is an interface that abstracts the methods of the handler that handles client requests.
@@ -51,6 +96,7 @@ type ExtHandler interface {
 	HandleAddReplica(user string, k Keygroup, n Node) error
 	HandleGetKeygroupReplica(user string, k Keygroup) ([]Node, map[NodeID]int, error)
 	HandleRemoveReplica(user string, k Keygroup, n Node) error
+	HandleGetKeygroupUsage(user string, k Keygroup) (KeygroupUsage, error)
 	HandleGetReplica(user string, n Node) (Node, error)
 	HandleGetAllReplica(user string) ([]Node, error)
 	HandleGetKeygroupTriggers(user string, keygroup Keygroup) ([]Trigger, error)
@@ -58,6 +104,22 @@ type ExtHandler interface {
 	HandleRemoveTrigger(user string, keygroup Keygroup, t Trigger) error
 	HandleAddUser(user string, newuser string, keygroup Keygroup, role Role) error
 	HandleRemoveUser(user string, newuser string, keygroup Keygroup, role Role) error
+	HandleAddGroupRole(user string, group string, keygroup Keygroup, role Role) error
+	HandleRemoveGroupRole(user string, group string, keygroup Keygroup, role Role) error
+	HandleGetKeygroupPermissions(user string, keygroup Keygroup) (map[Subject]map[Method]struct{}, error)
+	HandleAddRole(user string, role Role, methods []Method) error
+	HandleRemoveRole(user string, role Role) error
+	HandleGetRoles(user string) (map[Role]map[Method]struct{}, error)
+	HandleAddGroupMember(user string, group string, member string) error
+	HandleRemoveGroupMember(user string, group string, member string) error
+	HandleGetGroupMembers(user string, group string) ([]string, error)
+	HandleGetAuditLog(user string, q AuditQuery) ([]AuditEntry, error)
+	HandleGetFailedTriggerEvents(user string, keygroup Keygroup, triggerID string) ([]TriggerEvent, error)
+	HandleReplayTriggerEvents(user string, keygroup Keygroup, triggerID string, seqs []uint64) error
+	HandleBackup(user string, keygroup Keygroup, w io.Writer) error
+	HandleRestore(user string, r io.Reader) (Keygroup, error)
+	WithGroups(groups []string) ExtHandler
+	Revision() int64
 }
 
 // New creates a new FReD instance.
@@ -81,13 +143,48 @@ func New(config *Config) (f Fred) {
 		}
 	}
 
-	s := newStoreService(config.Store)
+	// the store is made read-only below everything else, so that nothing can write to it while it is migrated, not even
+	// the re-encryption of values
+	st := newReadOnlyStore(config.Store)
+
+	// values are compressed before they are encrypted, as encrypted values cannot be compressed
+	enc, err := newEncryptedStore(st, config.EncryptionKey, config.EncryptionOldKey, config.EncryptionKeygroups)
+
+	if err != nil {
+		log.Err(err).Msg("could not set up encryption")
+		panic(err)
+	}
+
+	go enc.run(config.EncryptionRotation)
+
+	// values are always decompressed, so that they can be read even if compression has been turned off
+	comp, err := newCompressedStore(enc, config.CompressionRules)
+
+	if err != nil {
+		log.Err(err).Msg("could not set up compression")
+		panic(err)
+	}
+
+	var c *cachedStore
+	var store Store = comp
+
+	if config.CacheSize > 0 {
+		c = newCachedStore(store, config.CacheSize, config.CacheRules, config.NaSe.GetExpiry)
+		store = c
+	}
+
+	s := newStoreService(store, config.NaSe.GetNodeID(), newQuotaTracker(store, config.QuotaRules, config.NodeMaxItems, config.NodeMaxBytes))
 
-	r := newReplicationService(s, config.Client, config.NaSe)
+	t, err := newTriggerService(s, config.NaSe.GetNodeID(), config)
 
-	t := newTriggerService(s, config.TriggerCert, config.TriggerKey, config.TriggerCA)
+	if err != nil {
+		log.Err(err).Msg("could not start trigger service")
+		panic(err)
+	}
 
-	a := newAuthService(config.NaSe)
+	r := newReplicationService(s, t, config.Client, config.NaSe)
+
+	a := newAuthService(config.NaSe, config.Admins, config.RestrictCreate)
 
 	// TODO this code should live somewhere where it is called every n seconds, but for testing purposes the easiest way
 	// TODO to simulate an internet shutdown is via killing a node, so testing once at startup should be enough
@@ -115,8 +212,69 @@ func New(config *Config) (f Fred) {
 		log.Debug().Msg("NodeStatus: No updates were missed by this node.")
 	}
 
+	audit := config.AuditLog
+
+	if config.NewAuditLog != nil {
+		audit, err = config.NewAuditLog(store)
+
+		if err != nil {
+			log.Err(err).Msg("could not create audit log")
+			panic(err)
+		}
+	}
+
+	var e ExtHandler = newExthandler(s, r, t, a, audit, config.NaSe)
+
+	if audit != nil {
+		e = newAuditedExthandler(e, audit, config.NaSe.GetNodeID())
+	}
+
+	// computed keygroups are written through the same handler as client writes
+	t.setComputeHandler(e, a)
+
 	return Fred{
-		E: newExthandler(s, r, t, a, config.NaSe),
-		I: newInthandler(s, r, t, config.NaSe),
+		E:     e,
+		I:     newInthandler(s, r, t, config.NaSe),
+		store: st,
+		cache: c,
+		comp:  comp,
+		enc:   enc,
+	}
+}
+
+// CacheStats returns the statistics of the cache for reads from the store, or false if there is no cache.
+func (f Fred) CacheStats() (CacheStats, bool) {
+	if f.cache == nil {
+		return CacheStats{}, false
+	}
+
+	return f.cache.stats(), true
+}
+
+// CompressionStats returns the compression statistics of all keygroups that values have been written to.
+func (f Fred) CompressionStats() map[KeygroupName]CompressionStats {
+	if f.comp == nil {
+		return nil
+	}
+
+	return f.comp.statistics()
+}
+
+// RotateKeys replaces the data keys of all encrypted keygroups and re-encrypts their values with the new keys.
+func (f Fred) RotateKeys() error {
+	if f.enc == nil || f.enc.master == nil {
+		return errors.Errorf("encryption is not configured")
 	}
+
+	var err error
+
+	f.enc.each(func(kg string) error {
+		e := f.enc.rotate(kg)
+		if e != nil {
+			err = e
+		}
+		return e
+	})
+
+	return err
 }
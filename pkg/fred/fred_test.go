@@ -1,21 +1,45 @@
 package fred_test
 
 import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"os"
 	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"git.tu-berlin.de/mcc-fred/fred/pkg/auditlog"
 	"git.tu-berlin.de/mcc-fred/fred/pkg/badgerdb"
+	"git.tu-berlin.de/mcc-fred/fred/pkg/certs"
 	"git.tu-berlin.de/mcc-fred/fred/pkg/etcdnase"
 	"git.tu-berlin.de/mcc-fred/fred/pkg/fred"
 	"git.tu-berlin.de/mcc-fred/fred/pkg/peering"
+	"git.tu-berlin.de/mcc-fred/fred/pkg/triggernode"
+	"git.tu-berlin.de/mcc-fred/fred/proto/trigger"
 	"github.com/go-errors/errors"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	"github.com/stretchr/testify/assert"
 	"go.etcd.io/etcd/client/pkg/v3/transport"
 	"go.etcd.io/etcd/server/v3/embed"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
 )
 
 const (
@@ -24,6 +48,7 @@ const (
 )
 
 var f fred.Fred
+var tlsProvider *certs.Provider
 
 func TestMain(m *testing.M) {
 	nodeID := "X"
@@ -80,18 +105,31 @@ func TestMain(m *testing.M) {
 		panic(err)
 	}
 
+	store := badgerdb.NewMemory()
+
+	tlsProvider, err = certs.NewProvider(certBasePath+"nodeA.crt", certBasePath+"nodeA.key", []string{certBasePath + "ca.crt"}, nil)
+
+	if err != nil {
+		panic(err)
+	}
+
 	config := fred.Config{
-		Store:             badgerdb.NewMemory(),
-		Client:            peering.NewClient(certBasePath+"nodeA.crt", certBasePath+"nodeA.key", certBasePath+"ca.crt"),
+		Store:             store,
+		Client:            peering.NewClient(fred.NodeID(nodeID), tlsProvider, false),
 		NaSe:              n,
 		PeeringHost:       "127.0.0.1:8000",
 		PeeringHostProxy:  "",
 		ExternalHost:      "127.0.0.1:9000",
 		ExternalHostProxy: "",
 		NodeID:            nodeID,
-		TriggerCert:       certBasePath + "nodeA.crt",
-		TriggerKey:        certBasePath + "nodeA.key",
-		TriggerCA:         []string{certBasePath + "ca.crt"},
+		TriggerTLS:        tlsProvider.ClientConfig(true),
+		// fail quickly so that tests do not have to wait for trigger events
+		TriggerMaxAttempts: 2,
+		TriggerMaxBackoff:  10 * time.Millisecond,
+		Admins:             []string{"admin"},
+		NewAuditLog: func(store fred.Store) (fred.AuditLog, error) {
+			return auditlog.NewKeygroup(store, 0)
+		},
 	}
 
 	f = fred.New(&config)
@@ -151,6 +189,23 @@ func TestPut(t *testing.T) {
 	testPut(t, "user", "testkg5", "id", "value")
 }
 
+func TestRevision(t *testing.T) {
+	rev := f.E.Revision()
+	assert.Greater(t, rev, int64(0))
+
+	err := f.E.HandleCreateKeygroup("user", fred.Keygroup{
+		Name:    "revisionkg",
+		Mutable: true,
+	})
+
+	assert.NoError(t, err)
+
+	// the local view of the NaSe has to catch up with the new keygroup
+	assert.Eventually(t, func() bool {
+		return f.E.Revision() > rev
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
 func testDelete(t *testing.T, user, kg, id, value string) {
 	err := f.E.HandleCreateKeygroup(user, fred.Keygroup{
 		Name:    fred.KeygroupName(kg),
@@ -210,8 +265,50 @@ func testMisformedKVInput(t *testing.T, user, kg, id, value string) {
 }
 
 func TestMisformedKVInput(t *testing.T) {
-	testMisformedKVInput(t, "user", "misformed", "id(", "value")
-	testMisformedKVInput(t, "user", "misformed2", "id(", "value=")
+	testMisformedKVInput(t, "user", "misformed", "", "value")
+	testMisformedKVInput(t, "user", "misformed2", "id\xff", "value")
+	testMisformedKVInput(t, "user", "misformed3", strings.Repeat("a", 1025), "value")
+}
+
+func TestNaturalKeys(t *testing.T) {
+	kg := "sensor-data.v2"
+	user := "user"
+
+	err := f.E.HandleCreateKeygroup(user, fred.Keygroup{
+		Name:    fred.KeygroupName(kg),
+		Mutable: true,
+		Expiry:  0,
+	})
+
+	assert.NoError(t, err)
+
+	ids := []string{"alice@example.com", "/home/alice/notes.txt", "123e4567-e89b-12d3-a456-426614174000", "a|b", "ünïcödé ✓"}
+
+	for _, id := range ids {
+		err = f.E.HandleUpdate(user, fred.Item{
+			Keygroup: fred.KeygroupName(kg),
+			ID:       id,
+			Val:      "val " + id,
+		})
+
+		assert.NoError(t, err)
+	}
+
+	for _, id := range ids {
+		i, err := f.E.HandleRead(user, fred.Item{
+			Keygroup: fred.KeygroupName(kg),
+			ID:       id,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "val "+id, i.Val)
+	}
+
+	err = f.E.HandleDeleteKeygroup(user, fred.Keygroup{
+		Name: fred.KeygroupName(kg),
+	})
+
+	assert.NoError(t, err)
 }
 
 func testMisformedKeygroupInput(t *testing.T, user, kg, id, value string) {
@@ -283,8 +380,10 @@ func testScan(t *testing.T, user string, kg string, mutable bool, updates int, s
 	var startKey string
 	if mutable {
 		startKey = "id" + strconv.Itoa(scanStart)
+	} else if scanStart >= 0 && scanStart < updates {
+		startKey = ids[scanStart]
 	} else {
-		startKey = strconv.Itoa(scanStart)
+		startKey = "missing"
 	}
 
 	items, err := f.E.HandleScan(user, fred.Item{
@@ -495,6 +594,74 @@ func BenchmarkGet(b *testing.B) {
 	assert.NoError(b, err)
 }
 
+func TestAppendIDs(t *testing.T) {
+	user := "user"
+	kg := "appendids"
+
+	err := f.E.HandleCreateKeygroup(user, fred.Keygroup{
+		Name:    fred.KeygroupName(kg),
+		Mutable: false,
+		Expiry:  0,
+	})
+
+	assert.NoError(t, err)
+
+	// concurrent appends on one node never get the same ID
+	concurrent := 8
+	items := 50
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	ids := make(map[string]struct{})
+
+	for i := 0; i < concurrent; i++ {
+		wg.Add(1)
+		go func(c int) {
+			defer wg.Done()
+			for j := 0; j < items; j++ {
+				item, err := f.E.HandleAppend(user, fred.Item{
+					Keygroup: fred.KeygroupName(kg),
+					Val:      fmt.Sprintf("val-%d-%d", c, j),
+				})
+
+				if !assert.NoError(t, err) {
+					continue
+				}
+
+				assert.True(t, strings.HasSuffix(item.ID, "-X"), item.ID)
+
+				mu.Lock()
+				ids[item.ID] = struct{}{}
+				mu.Unlock()
+			}
+		}(i)
+	}
+
+	wg.Wait()
+	assert.Len(t, ids, concurrent*items)
+
+	// an append from another node with a clock that is ahead is not overwritten and our next append comes after it
+	assert.NoError(t, f.I.HandleAppend(fred.Item{Keygroup: fred.KeygroupName(kg), ID: "09999999999999999999-Y", Val: "remote"}, "Y"))
+
+	i, err := f.E.HandleAppend(user, fred.Item{
+		Keygroup: fred.KeygroupName(kg),
+		Val:      "local",
+	})
+
+	assert.NoError(t, err)
+	assert.Greater(t, i.ID, "09999999999999999999-Y")
+
+	r, err := f.E.HandleRead(user, fred.Item{Keygroup: fred.KeygroupName(kg), ID: "09999999999999999999-Y"})
+	assert.NoError(t, err)
+	assert.Equal(t, "remote", r.Val)
+
+	err = f.E.HandleDeleteKeygroup(user, fred.Keygroup{
+		Name: fred.KeygroupName(kg),
+	})
+
+	assert.NoError(t, err)
+}
+
 func BenchmarkAppend(b *testing.B) {
 	user := "user"
 	kg := "benchmarkAppend"
@@ -523,3 +690,1805 @@ func BenchmarkAppend(b *testing.B) {
 
 	assert.NoError(b, err)
 }
+
+func TestRolesAndGroups(t *testing.T) {
+	admin := "admin"
+	owner := "roleowner"
+	member := "rolemember"
+	group := "team-x"
+	kg1 := "teamxdata1"
+	kg2 := "teamxdata2"
+
+	testPut(t, owner, kg1, "id", "value")
+	testPut(t, owner, kg2, "id", "value")
+
+	// only admins can define roles
+	err := f.E.HandleAddRole(owner, "reader", []fred.Method{fred.Read, fred.Scan})
+	assert.Error(t, err)
+
+	err = f.E.HandleAddRole(admin, "reader", []fred.Method{"NotAMethod"})
+	assert.Error(t, err)
+
+	err = f.E.HandleAddRole(admin, fred.ReadKeygroup, []fred.Method{fred.Update})
+	assert.Error(t, err)
+
+	err = f.E.HandleAddRole(admin, "reader", []fred.Method{fred.Read, fred.Scan})
+	assert.NoError(t, err)
+
+	roles, err := f.E.HandleGetRoles(member)
+	assert.NoError(t, err)
+	assert.Equal(t, map[fred.Method]struct{}{fred.Read: {}, fred.Scan: {}}, roles["reader"])
+
+	err = f.E.HandleAddGroupMember(owner, group, member)
+	assert.Error(t, err)
+
+	err = f.E.HandleAddGroupMember(admin, group, member)
+	assert.NoError(t, err)
+
+	members, err := f.E.HandleGetGroupMembers(admin, group)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{member}, members)
+
+	_, err = f.E.HandleRead(member, fred.Item{Keygroup: fred.KeygroupName(kg1), ID: "id"})
+	assert.Error(t, err)
+
+	// the owner can only grant roles on their own keygroups, not on a pattern
+	err = f.E.HandleAddGroupRole(owner, group, fred.Keygroup{Name: "teamx*"}, "reader")
+	assert.Error(t, err)
+
+	err = f.E.HandleAddGroupRole(admin, group, fred.Keygroup{Name: "teamx*"}, "reader")
+	assert.NoError(t, err)
+
+	for _, kg := range []string{kg1, kg2} {
+		i, err := f.E.HandleRead(member, fred.Item{Keygroup: fred.KeygroupName(kg), ID: "id"})
+		assert.NoError(t, err)
+		assert.Equal(t, "value", i.Val)
+
+		items, err := f.E.HandleScan(member, fred.Item{Keygroup: fred.KeygroupName(kg), ID: "id"}, 1)
+		assert.NoError(t, err)
+		assert.Len(t, items, 1)
+
+		err = f.E.HandleUpdate(member, fred.Item{Keygroup: fred.KeygroupName(kg), ID: "id", Val: "value2"})
+		assert.Error(t, err)
+	}
+
+	// the pattern does not match other keygroups
+	_, err = f.E.HandleRead(member, fred.Item{Keygroup: "permissiontest", ID: "id"})
+	assert.Error(t, err)
+
+	p, err := f.E.HandleGetKeygroupPermissions(owner, fred.Keygroup{Name: fred.KeygroupName(kg1)})
+	assert.NoError(t, err)
+	assert.Equal(t, map[fred.Method]struct{}{fred.Read: {}, fred.Scan: {}}, p[fred.Subject{Name: group, Group: true}])
+	assert.Contains(t, p[fred.Subject{Name: owner}], fred.AddUser)
+	assert.NotContains(t, p, fred.Subject{Name: member})
+
+	_, err = f.E.HandleGetKeygroupPermissions(member, fred.Keygroup{Name: fred.KeygroupName(kg1)})
+	assert.Error(t, err)
+
+	err = f.E.HandleRemoveGroupMember(admin, group, member)
+	assert.NoError(t, err)
+
+	_, err = f.E.HandleRead(member, fred.Item{Keygroup: fred.KeygroupName(kg1), ID: "id"})
+	assert.Error(t, err)
+
+	// removing a role also removes everything that was granted with it
+	err = f.E.HandleAddUser(admin, member, fred.Keygroup{Name: fred.KeygroupName(kg2)}, "reader")
+	assert.NoError(t, err)
+
+	_, err = f.E.HandleRead(member, fred.Item{Keygroup: fred.KeygroupName(kg2), ID: "id"})
+	assert.NoError(t, err)
+
+	err = f.E.HandleRemoveRole(admin, "reader")
+	assert.NoError(t, err)
+
+	_, err = f.E.HandleRead(member, fred.Item{Keygroup: fred.KeygroupName(kg2), ID: "id"})
+	assert.Error(t, err)
+
+	err = f.E.HandleAddUser(admin, member, fred.Keygroup{Name: fred.KeygroupName(kg2)}, "reader")
+	assert.Error(t, err)
+}
+
+func TestAuditLog(t *testing.T) {
+	owner := "auditowner"
+	other := "auditother"
+	kg := "audittest"
+
+	testPut(t, owner, kg, "id", "value")
+
+	_, err := f.E.HandleRead(other, fred.Item{Keygroup: fred.KeygroupName(kg), ID: "id"})
+	assert.Error(t, err)
+
+	_, err = f.E.HandleGetAuditLog(other, fred.AuditQuery{Keygroup: fred.KeygroupName(kg), Limit: 10})
+	assert.Error(t, err)
+
+	entries, err := f.E.HandleGetAuditLog(owner, fred.AuditQuery{Keygroup: fred.KeygroupName(kg), Limit: 10})
+	assert.NoError(t, err)
+
+	// the failed query of the other user is recorded as well
+	assert.Len(t, entries, 5)
+	assert.Equal(t, fred.GetAuditLog, entries[0].Method)
+	assert.Equal(t, other, entries[0].User)
+	assert.False(t, entries[0].Success)
+	assert.Equal(t, fred.Read, entries[1].Method)
+	assert.Equal(t, other, entries[1].User)
+	assert.False(t, entries[1].Success)
+	assert.NotEmpty(t, entries[1].Error)
+	assert.Equal(t, fred.Read, entries[2].Method)
+	assert.True(t, entries[2].Success)
+	assert.Equal(t, fred.Update, entries[3].Method)
+	assert.Equal(t, "id", entries[3].ID)
+	assert.Equal(t, fred.CreateKeygroup, entries[4].Method)
+	assert.Equal(t, fred.NodeID("X"), entries[4].Node)
+
+	entries, err = f.E.HandleGetAuditLog(owner, fred.AuditQuery{Keygroup: fred.KeygroupName(kg), User: other, Method: fred.Read, Limit: 10})
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	entries, err = f.E.HandleGetAuditLog(owner, fred.AuditQuery{Keygroup: fred.KeygroupName(kg), Limit: 2})
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+	assert.Equal(t, fred.GetAuditLog, entries[0].Method)
+	assert.Equal(t, owner, entries[0].User)
+}
+
+// testTriggerNode records the IDs of all events it receives, with a "-" in front for deletes and a "~" for expiries.
+// Deleted keygroups are recorded as their name with a "!" in front. If ackAhead is set, the node acknowledges that
+// many events more than it has received with its next response.
+// Only if batch is set, the node implements BatchTrigger. It then records the size of every batch, and if failBatch is
+// set, it only processes the first event of the next batch with more than one event and fails the rest. Batches wait
+// for the gate to be closed, if there is one.
+type testTriggerNode struct {
+	addr string
+	sync.Mutex
+	puts      []string
+	origins   []string
+	seqs      []uint64
+	keys      []string
+	ackAhead  uint64
+	batch     bool
+	batches   []int
+	failBatch bool
+	gate      chan struct{}
+	conns     int
+}
+
+func (n *testTriggerNode) record(event string, origin string, seq uint64, key string) (*trigger.TriggerResponse, error) {
+	n.Lock()
+	defer n.Unlock()
+
+	n.puts = append(n.puts, event)
+	n.origins = append(n.origins, origin)
+	n.seqs = append(n.seqs, seq)
+	n.keys = append(n.keys, key)
+
+	res := &trigger.TriggerResponse{Status: trigger.EnumTriggerStatus_TRIGGER_OK}
+
+	if n.ackAhead > 0 {
+		res.AckedSeq = seq + n.ackAhead
+		n.ackAhead = 0
+	}
+
+	return res, nil
+}
+
+func (n *testTriggerNode) PutItemTrigger(_ context.Context, req *trigger.PutItemTriggerRequest) (*trigger.TriggerResponse, error) {
+	return n.record(req.Id, req.Origin, req.Seq, req.IdempotencyKey)
+}
+
+func (n *testTriggerNode) DeleteItemTrigger(_ context.Context, req *trigger.DeleteItemTriggerRequest) (*trigger.TriggerResponse, error) {
+	if req.Operation == string(fred.TriggerExpire) {
+		return n.record("~"+req.Id, req.Origin, req.Seq, req.IdempotencyKey)
+	}
+
+	return n.record("-"+req.Id, req.Origin, req.Seq, req.IdempotencyKey)
+}
+
+func (n *testTriggerNode) BatchTrigger(ctx context.Context, req *trigger.BatchTriggerRequest) (*trigger.TriggerResponse, error) {
+	n.Lock()
+	gate := n.gate
+	n.Unlock()
+
+	if gate != nil {
+		<-gate
+	}
+
+	n.Lock()
+	batch := n.batch
+	fail := n.failBatch && len(req.Events) > 1
+
+	if batch {
+		n.batches = append(n.batches, len(req.Events))
+	}
+
+	if fail {
+		n.failBatch = false
+	}
+	n.Unlock()
+
+	if !batch {
+		return nil, status.Error(codes.Unimplemented, "method BatchTrigger not implemented")
+	}
+
+	events := req.Events
+
+	if fail {
+		events = events[:1]
+	}
+
+	var res *trigger.TriggerResponse
+
+	for _, e := range events {
+		switch e.Operation {
+		case string(fred.TriggerPut), string(fred.TriggerAppend):
+			res, _ = n.PutItemTrigger(ctx, &trigger.PutItemTriggerRequest{Keygroup: e.Keygroup, Id: e.Id, Val: e.Val, Operation: e.Operation, Origin: e.Origin, Seq: e.Seq, IdempotencyKey: e.IdempotencyKey})
+		case string(fred.TriggerDeleteKeygroup):
+			res, _ = n.DeleteKeygroupTrigger(ctx, &trigger.DeleteKeygroupTriggerRequest{Keygroup: e.Keygroup, Origin: e.Origin, Seq: e.Seq, IdempotencyKey: e.IdempotencyKey})
+		default:
+			res, _ = n.DeleteItemTrigger(ctx, &trigger.DeleteItemTriggerRequest{Keygroup: e.Keygroup, Id: e.Id, Operation: e.Operation, Origin: e.Origin, Seq: e.Seq, IdempotencyKey: e.IdempotencyKey})
+		}
+	}
+
+	if fail {
+		return &trigger.TriggerResponse{Status: trigger.EnumTriggerStatus_TRIGGER_ERROR, ErrorMessage: "broken event", AckedSeq: events[0].Seq}, nil
+	}
+
+	return res, nil
+}
+
+func (n *testTriggerNode) receivedBatches() []int {
+	n.Lock()
+	defer n.Unlock()
+
+	return append([]int{}, n.batches...)
+}
+
+func (n *testTriggerNode) DeleteKeygroupTrigger(_ context.Context, req *trigger.DeleteKeygroupTriggerRequest) (*trigger.TriggerResponse, error) {
+	return n.record("!"+req.Keygroup, req.Origin, req.Seq, req.IdempotencyKey)
+}
+
+// countingListener counts the connections that a trigger node accepts.
+type countingListener struct {
+	net.Listener
+	node *testTriggerNode
+}
+
+func (l *countingListener) Accept() (net.Conn, error) {
+	c, err := l.Listener.Accept()
+
+	if err == nil {
+		l.node.Lock()
+		l.node.conns++
+		l.node.Unlock()
+	}
+
+	return c, err
+}
+
+func (n *testTriggerNode) connections() int {
+	n.Lock()
+	defer n.Unlock()
+
+	return n.conns
+}
+
+// freeAddr returns a local address that nothing is listening on.
+func freeAddr(t *testing.T) string {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	addr := lis.Addr().String()
+	assert.NoError(t, lis.Close())
+
+	return addr
+}
+
+// startTriggerNode starts a trigger node on the given address and returns it with a function to stop it.
+func startTriggerNode(t *testing.T, addr string) (*testTriggerNode, func()) {
+	l, err := net.Listen("tcp", addr)
+	assert.NoError(t, err)
+
+	node := &testTriggerNode{addr: addr}
+	s := grpc.NewServer(grpc.Creds(credentials.NewTLS(tlsProvider.ServerConfig(tls.RequireAndVerifyClientCert))))
+	trigger.RegisterTriggerNodeServer(s, node)
+
+	go func() {
+		_ = s.Serve(&countingListener{Listener: l, node: node})
+	}()
+
+	return node, s.Stop
+}
+
+func (n *testTriggerNode) received() []string {
+	n.Lock()
+	defer n.Unlock()
+
+	return append([]string{}, n.puts...)
+}
+
+func (n *testTriggerNode) receivedOrigins() []string {
+	n.Lock()
+	defer n.Unlock()
+
+	return append([]string{}, n.origins...)
+}
+
+func (n *testTriggerNode) receivedSeqs() ([]uint64, []string) {
+	n.Lock()
+	defer n.Unlock()
+
+	return append([]uint64{}, n.seqs...), append([]string{}, n.keys...)
+}
+
+func TestTriggerRetry(t *testing.T) {
+	user := "triggeruser"
+	kg := "triggertest"
+
+	testPut(t, user, kg, "before", "value")
+
+	// reserve an address for the trigger node that is not listening yet
+	addr := freeAddr(t)
+
+	k := fred.Keygroup{Name: fred.KeygroupName(kg)}
+
+	err := f.E.HandleAddTrigger(user, k, fred.Trigger{ID: "t1", Host: addr})
+	assert.NoError(t, err)
+
+	// writes work even though the trigger node is down
+	err = f.E.HandleUpdate(user, fred.Item{Keygroup: fred.KeygroupName(kg), ID: "a", Val: "1"})
+	assert.NoError(t, err)
+
+	err = f.E.HandleUpdate(user, fred.Item{Keygroup: fred.KeygroupName(kg), ID: "b", Val: "2"})
+	assert.NoError(t, err)
+
+	var failed []fred.TriggerEvent
+
+	assert.Eventually(t, func() bool {
+		failed, err = f.E.HandleGetFailedTriggerEvents(user, k, "t1")
+		return err == nil && len(failed) == 2
+	}, 10*time.Second, 50*time.Millisecond)
+
+	assert.Equal(t, "a", failed[0].ID)
+	assert.Equal(t, fred.TriggerPut, failed[0].Op)
+	assert.Equal(t, 2, failed[0].Attempts)
+	assert.NotEmpty(t, failed[0].LastError)
+
+	_, err = f.E.HandleGetFailedTriggerEvents("someoneelse", k, "t1")
+	assert.Error(t, err)
+
+	err = f.E.HandleReplayTriggerEvents(user, k, "unknown", nil)
+	assert.Error(t, err)
+
+	// now start the trigger node and replay the events
+	node, stop := startTriggerNode(t, addr)
+	defer stop()
+
+	err = f.E.HandleReplayTriggerEvents(user, k, "t1", []uint64{failed[1].Seq})
+	assert.NoError(t, err)
+
+	assert.Eventually(t, func() bool {
+		return len(node.received()) == 1
+	}, 10*time.Second, 50*time.Millisecond)
+
+	assert.Equal(t, []string{"b"}, node.received())
+
+	err = f.E.HandleReplayTriggerEvents(user, k, "t1", nil)
+	assert.NoError(t, err)
+
+	err = f.E.HandleUpdate(user, fred.Item{Keygroup: fred.KeygroupName(kg), ID: "c", Val: "3"})
+	assert.NoError(t, err)
+
+	// the replayed event is delivered before the new one
+	assert.Eventually(t, func() bool {
+		return len(node.received()) == 3
+	}, 10*time.Second, 50*time.Millisecond)
+
+	assert.Equal(t, []string{"b", "a", "c"}, node.received())
+
+	failed, err = f.E.HandleGetFailedTriggerEvents(user, k, "")
+	assert.NoError(t, err)
+	assert.Empty(t, failed)
+}
+
+func TestTriggerFilter(t *testing.T) {
+	user := "filteruser"
+	kg := "filtertest"
+
+	testPut(t, user, kg, "init", "value")
+
+	k := fred.Keygroup{Name: fred.KeygroupName(kg)}
+
+	err := f.E.HandleAddTrigger(user, k, fred.Trigger{ID: "bad", Host: "127.0.0.1:1", Filter: fred.TriggerFilter{IDRegex: "("}})
+	assert.Error(t, err)
+
+	err = f.E.HandleAddTrigger(user, k, fred.Trigger{ID: "bad", Host: "127.0.0.1:1", Filter: fred.TriggerFilter{Ops: []fred.TriggerOp{"rename"}}})
+	assert.Error(t, err)
+
+	err = f.E.HandleAddTrigger(user, k, fred.Trigger{ID: "bad", Host: "127.0.0.1:1", Filter: fred.TriggerFilter{ValuePredicate: "temp > 3"}})
+	assert.Error(t, err)
+
+	addr := freeAddr(t)
+
+	node, stop := startTriggerNode(t, addr)
+	defer stop()
+
+	filter := fred.TriggerFilter{
+		IDPrefix:       "sensor",
+		IDRegex:        "[0-9]$",
+		Ops:            []fred.TriggerOp{fred.TriggerPut, fred.TriggerDelete},
+		ValuePredicate: "$.readings[0].temp >= 30",
+	}
+
+	err = f.E.HandleAddTrigger(user, k, fred.Trigger{ID: "hot", Host: addr, Filter: filter})
+	assert.NoError(t, err)
+
+	triggers, err := f.E.HandleGetKeygroupTriggers(user, k)
+	assert.NoError(t, err)
+	assert.Len(t, triggers, 1)
+	assert.Equal(t, filter, triggers[0].Filter)
+
+	updates := []fred.Item{
+		{ID: "sensor1", Val: `{"readings": [{"temp": 35}]}`},
+		{ID: "sensor2", Val: `{"readings": [{"temp": 20}]}`},
+		{ID: "sensorX", Val: `{"readings": [{"temp": 40}]}`},
+		{ID: "other3", Val: `{"readings": [{"temp": 40}]}`},
+		{ID: "sensor4", Val: `not json`},
+		{ID: "sensor5", Val: `{"readings": []}`},
+		{ID: "sensor6", Val: `{"readings": [{"temp": 30}]}`},
+	}
+
+	for _, i := range updates {
+		i.Keygroup = k.Name
+		assert.NoError(t, f.E.HandleUpdate(user, i))
+	}
+
+	assert.NoError(t, f.E.HandleDelete(user, fred.Item{Keygroup: k.Name, ID: "sensor2"}))
+	assert.NoError(t, f.E.HandleDelete(user, fred.Item{Keygroup: k.Name, ID: "other3"}))
+
+	assert.Eventually(t, func() bool {
+		return len(node.received()) == 3
+	}, 10*time.Second, 50*time.Millisecond)
+
+	// give unexpected events a chance to show up
+	time.Sleep(100 * time.Millisecond)
+
+	assert.Equal(t, []string{"sensor1", "sensor6", "-sensor2"}, node.received())
+}
+
+func TestWebhookTrigger(t *testing.T) {
+	user := "webhookuser"
+	kg := "webhooktest"
+	secret := "s3cret"
+
+	testPut(t, user, kg, "init", "value")
+
+	k := fred.Keygroup{Name: fred.KeygroupName(kg)}
+
+	err := f.E.HandleAddTrigger(user, k, fred.Trigger{ID: "bad", Webhook: fred.Webhook{URL: "ftp://example.com"}})
+	assert.Error(t, err)
+
+	type request struct {
+		body      []byte
+		signature string
+	}
+
+	requests := make(chan request, 10)
+
+	ok := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		requests <- request{body: body, signature: r.Header.Get(fred.WebhookSignatureHeader)}
+	}))
+	defer ok.Close()
+
+	broken := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer broken.Close()
+
+	err = f.E.HandleAddTrigger(user, k, fred.Trigger{ID: "ok", Webhook: fred.Webhook{URL: ok.URL, Secret: secret}})
+	assert.NoError(t, err)
+
+	err = f.E.HandleAddTrigger(user, k, fred.Trigger{ID: "broken", Webhook: fred.Webhook{URL: broken.URL}})
+	assert.NoError(t, err)
+
+	err = f.E.HandleUpdate(user, fred.Item{Keygroup: k.Name, ID: "a", Val: "1"})
+	assert.NoError(t, err)
+
+	var r request
+
+	select {
+	case r = <-requests:
+	case <-time.After(10 * time.Second):
+		t.Fatal("webhook was not called")
+	}
+
+	assert.Equal(t, fred.SignWebhook(secret, r.body), r.signature)
+
+	var event map[string]interface{}
+	assert.NoError(t, json.Unmarshal(r.body, &event))
+	assert.Equal(t, kg, event["keygroup"])
+	assert.Equal(t, "a", event["id"])
+	assert.Equal(t, "1", event["value"])
+	assert.Equal(t, "put", event["operation"])
+	assert.Equal(t, "X", event["origin"])
+	assert.Equal(t, float64(1), event["seq"])
+	assert.Equal(t, "X/"+kg+"/1", event["idempotencyKey"])
+	assert.NotEmpty(t, event["timestamp"])
+
+	assert.Eventually(t, func() bool {
+		failed, err := f.E.HandleGetFailedTriggerEvents(user, k, "broken")
+		return err == nil && len(failed) == 1
+	}, 10*time.Second, 50*time.Millisecond)
+
+	failed, err := f.E.HandleGetFailedTriggerEvents(user, k, "broken")
+	assert.NoError(t, err)
+	assert.Contains(t, failed[0].LastError, "500")
+}
+
+func TestTriggerOrigins(t *testing.T) {
+	user := "originuser"
+	kg := "origintest"
+
+	testPut(t, user, kg, "init", "value")
+
+	k := fred.Keygroup{Name: fred.KeygroupName(kg)}
+
+	err := f.E.HandleAddTrigger(user, k, fred.Trigger{ID: "bad", Host: "127.0.0.1:1", Filter: fred.TriggerFilter{Origins: "elsewhere"}})
+	assert.Error(t, err)
+
+	all, stopAll := startTriggerNode(t, freeAddr(t))
+	defer stopAll()
+
+	local, stopLocal := startTriggerNode(t, freeAddr(t))
+	defer stopLocal()
+
+	remote, stopRemote := startTriggerNode(t, freeAddr(t))
+	defer stopRemote()
+
+	triggers := []struct {
+		id      string
+		node    *testTriggerNode
+		origins fred.TriggerOrigins
+	}{
+		{"all", all, fred.AllOrigins},
+		{"local", local, fred.LocalOrigins},
+		{"remote", remote, fred.RemoteOrigins},
+	}
+
+	for _, tr := range triggers {
+		assert.NoError(t, f.E.HandleAddTrigger(user, k, fred.Trigger{ID: tr.id, Host: tr.node.addr, Filter: fred.TriggerFilter{Origins: tr.origins}}))
+	}
+
+	// a client write on this node
+	assert.NoError(t, f.E.HandleUpdate(user, fred.Item{Keygroup: k.Name, ID: "fromclient", Val: "1"}))
+
+	// writes that another node replicated to us
+	assert.NoError(t, f.I.HandleUpdate(fred.Item{Keygroup: k.Name, ID: "frompeer", Val: "2"}, "Y"))
+	assert.NoError(t, f.I.HandleDelete(fred.Item{Keygroup: k.Name, ID: "frompeer"}, "Y"))
+
+	// the keygroup is deleted by another node
+	assert.NoError(t, f.I.HandleDeleteKeygroup(k, "Y"))
+
+	assert.Eventually(t, func() bool {
+		return len(all.received()) == 4 && len(local.received()) == 1 && len(remote.received()) == 3
+	}, 10*time.Second, 50*time.Millisecond)
+
+	assert.Equal(t, []string{"fromclient", "frompeer", "-frompeer", "!" + kg}, all.received())
+	assert.Equal(t, []string{"X", "Y", "Y", "Y"}, all.receivedOrigins())
+	assert.Equal(t, []string{"fromclient"}, local.received())
+	assert.Equal(t, []string{"frompeer", "-frompeer", "!" + kg}, remote.received())
+}
+
+func TestTriggerExpiry(t *testing.T) {
+	user := "expiryuser"
+	kg := "expirytest"
+
+	k := fred.Keygroup{Name: fred.KeygroupName(kg), Mutable: true, Expiry: 1}
+
+	assert.NoError(t, f.E.HandleCreateKeygroup(user, k))
+
+	node, stop := startTriggerNode(t, freeAddr(t))
+	defer stop()
+
+	assert.NoError(t, f.E.HandleAddTrigger(user, k, fred.Trigger{ID: "t", Host: node.addr}))
+
+	assert.NoError(t, f.E.HandleUpdate(user, fred.Item{Keygroup: k.Name, ID: "expires", Val: "1"}))
+	assert.NoError(t, f.E.HandleUpdate(user, fred.Item{Keygroup: k.Name, ID: "deleted", Val: "2"}))
+	assert.NoError(t, f.E.HandleDelete(user, fred.Item{Keygroup: k.Name, ID: "deleted"}))
+
+	assert.Eventually(t, func() bool {
+		return len(node.received()) == 4
+	}, 10*time.Second, 50*time.Millisecond)
+
+	// an item that was deleted before its expiry does not expire again
+	time.Sleep(2 * time.Second)
+
+	assert.Equal(t, []string{"expires", "deleted", "-deleted", "~expires"}, node.received())
+	assert.Equal(t, []string{"X", "X", "X", "X"}, node.receivedOrigins())
+}
+
+func TestTriggerSequence(t *testing.T) {
+	user := "sequser"
+	kg := "seqtest"
+
+	testPut(t, user, kg, "init", "value")
+
+	k := fred.Keygroup{Name: fred.KeygroupName(kg)}
+
+	node, stop := startTriggerNode(t, freeAddr(t))
+	defer stop()
+
+	assert.NoError(t, f.E.HandleAddTrigger(user, k, fred.Trigger{ID: "t", Host: node.addr}))
+
+	for i := 0; i < 5; i++ {
+		assert.NoError(t, f.E.HandleUpdate(user, fred.Item{Keygroup: k.Name, ID: "item", Val: strconv.Itoa(i)}))
+	}
+
+	assert.Eventually(t, func() bool {
+		return len(node.received()) == 5
+	}, 10*time.Second, 50*time.Millisecond)
+
+	seqs, keys := node.receivedSeqs()
+	assert.Equal(t, []uint64{1, 2, 3, 4, 5}, seqs)
+	assert.Equal(t, "X/"+kg+"/1", keys[0])
+	assert.Equal(t, "X/"+kg+"/5", keys[4])
+
+	// the trigger node acknowledges two events ahead with its next response, e.g., because it has already processed
+	// them before the FReD node restarted, so those two are skipped
+	node.Lock()
+	node.ackAhead = 2
+	node.Unlock()
+
+	for i := 0; i < 4; i++ {
+		assert.NoError(t, f.E.HandleUpdate(user, fred.Item{Keygroup: k.Name, ID: "item", Val: strconv.Itoa(i)}))
+	}
+
+	assert.Eventually(t, func() bool {
+		return len(node.received()) == 7
+	}, 10*time.Second, 50*time.Millisecond)
+
+	time.Sleep(200 * time.Millisecond)
+
+	seqs, _ = node.receivedSeqs()
+	assert.Equal(t, []uint64{1, 2, 3, 4, 5, 6, 9}, seqs)
+}
+
+func TestTriggerBatch(t *testing.T) {
+	user := "batchuser"
+	kg := "batchtest"
+
+	testPut(t, user, kg, "init", "value")
+
+	k := fred.Keygroup{Name: fred.KeygroupName(kg)}
+
+	node, stop := startTriggerNode(t, freeAddr(t))
+	defer stop()
+
+	gate := make(chan struct{})
+
+	node.Lock()
+	node.batch = true
+	node.failBatch = true
+	node.gate = gate
+	node.Unlock()
+
+	assert.NoError(t, f.E.HandleAddTrigger(user, k, fred.Trigger{ID: "t", Host: node.addr}))
+
+	expected := make([]string, 20)
+
+	for i := range expected {
+		expected[i] = "item" + strconv.Itoa(i)
+		assert.NoError(t, f.E.HandleUpdate(user, fred.Item{Keygroup: k.Name, ID: expected[i], Val: "1"}))
+	}
+
+	// the first event is held back by the trigger node, so that the others are queued in the meantime
+	close(gate)
+
+	assert.Eventually(t, func() bool {
+		return len(node.received()) == len(expected)
+	}, 10*time.Second, 50*time.Millisecond)
+
+	// every event arrives exactly once and in order: the first batch of 19 events fails after the first event, the next
+	// event is retried on its own, and then the rest is sent together
+	assert.Equal(t, expected, node.received())
+	assert.Equal(t, []int{1, 19, 1, 17}, node.receivedBatches())
+
+	seqs, _ := node.receivedSeqs()
+	for i := 1; i < len(seqs); i++ {
+		assert.Equal(t, seqs[i-1]+1, seqs[i])
+	}
+}
+
+func TestTriggerConnectionReuse(t *testing.T) {
+	user := "connuser"
+	kg := "conntest"
+
+	testPut(t, user, kg, "init", "value")
+
+	k := fred.Keygroup{Name: fred.KeygroupName(kg)}
+
+	addr := freeAddr(t)
+
+	node, stop := startTriggerNode(t, addr)
+
+	assert.NoError(t, f.E.HandleAddTrigger(user, k, fred.Trigger{ID: "t", Host: addr}))
+
+	// wait for every event, so that each is sent on its own
+	for i := 0; i < 5; i++ {
+		assert.NoError(t, f.E.HandleUpdate(user, fred.Item{Keygroup: k.Name, ID: "item", Val: strconv.Itoa(i)}))
+
+		assert.Eventually(t, func() bool {
+			return len(node.received()) == i+1
+		}, 10*time.Second, 10*time.Millisecond)
+	}
+
+	// all events are sent over the same connection
+	assert.Equal(t, 1, node.connections())
+
+	// when the trigger node comes back after an outage, a new connection is made
+	stop()
+
+	node, stop = startTriggerNode(t, addr)
+	defer stop()
+
+	assert.NoError(t, f.E.HandleUpdate(user, fred.Item{Keygroup: k.Name, ID: "item", Val: "after"}))
+
+	assert.Eventually(t, func() bool {
+		return len(node.received()) == 1
+	}, 10*time.Second, 10*time.Millisecond)
+
+	assert.Equal(t, 1, node.connections())
+}
+
+func TestTriggerStats(t *testing.T) {
+	user := "statsuser"
+	kg := "statstest"
+
+	testPut(t, user, kg, "init", "value")
+
+	k := fred.Keygroup{Name: fred.KeygroupName(kg)}
+
+	node, stop := startTriggerNode(t, freeAddr(t))
+	defer stop()
+
+	assert.NoError(t, f.E.HandleAddTrigger(user, k, fred.Trigger{ID: "ok", Host: node.addr}))
+	assert.NoError(t, f.E.HandleAddTrigger(user, k, fred.Trigger{ID: "broken", Host: freeAddr(t)}))
+
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, f.E.HandleUpdate(user, fred.Item{Keygroup: k.Name, ID: "item" + strconv.Itoa(i), Val: "1"}))
+	}
+
+	stats := func() map[string]fred.TriggerStats {
+		triggers, err := f.E.HandleGetKeygroupTriggers(user, k)
+		assert.NoError(t, err)
+
+		res := make(map[string]fred.TriggerStats)
+
+		for _, tn := range triggers {
+			res[tn.ID] = tn.Stats
+		}
+
+		return res
+	}
+
+	assert.Eventually(t, func() bool {
+		s := stats()
+		return s["ok"].Delivered == 3 && s["broken"].Dead == 3
+	}, 10*time.Second, 50*time.Millisecond)
+
+	s := stats()
+
+	assert.Equal(t, uint64(0), s["ok"].Failed)
+	assert.Equal(t, 0, s["ok"].Backlog)
+	assert.False(t, s["ok"].LastSuccess.IsZero())
+	assert.True(t, s["ok"].LastFailure.IsZero())
+	assert.Greater(t, int64(s["ok"].AvgLatency), int64(0))
+
+	// every event is tried twice before it is given up on
+	assert.Equal(t, uint64(6), s["broken"].Failed)
+	assert.Equal(t, uint64(0), s["broken"].Delivered)
+	assert.False(t, s["broken"].LastFailure.IsZero())
+	assert.NotEmpty(t, s["broken"].LastError)
+}
+
+// sdkHandler counts the keygroup deletions that a trigger node built with the SDK receives.
+type sdkHandler struct {
+	sync.Mutex
+	deleted []string
+}
+
+func (h *sdkHandler) OnPut(_ context.Context, _ triggernode.Event) error {
+	return nil
+}
+
+func (h *sdkHandler) OnDelete(_ context.Context, _ triggernode.Event) error {
+	return nil
+}
+
+func (h *sdkHandler) OnDeleteKeygroup(_ context.Context, e triggernode.Event) error {
+	h.Lock()
+	defer h.Unlock()
+
+	h.deleted = append(h.deleted, e.Keygroup)
+	return nil
+}
+
+func TestTriggerNodeSDK(t *testing.T) {
+	user := "sdkuser"
+	kg := "sdktest"
+
+	testPut(t, user, kg, "init", "value")
+
+	k := fred.Keygroup{Name: fred.KeygroupName(kg)}
+
+	h := &sdkHandler{}
+
+	node, err := triggernode.New(triggernode.Config{
+		Host: freeAddr(t),
+		TLS:  tlsProvider.ServerConfig(tls.RequireAndVerifyClientCert),
+	}, h)
+	assert.NoError(t, err)
+
+	served := make(chan error, 1)
+
+	go func() {
+		served <- node.Serve()
+	}()
+
+	assert.NoError(t, f.E.HandleAddTrigger(user, k, fred.Trigger{ID: "sdk", Host: node.Addr()}))
+
+	assert.NoError(t, f.E.HandleUpdate(user, fred.Item{Keygroup: k.Name, ID: "a", Val: "1"}))
+	assert.NoError(t, f.E.HandleDelete(user, fred.Item{Keygroup: k.Name, ID: "a"}))
+	assert.NoError(t, f.E.HandleDeleteKeygroup(user, k))
+
+	assert.Eventually(t, func() bool {
+		return len(node.Log()) == 3
+	}, 10*time.Second, 50*time.Millisecond)
+
+	l := node.Log()
+
+	assert.Equal(t, "put", l[0].Op)
+	assert.Equal(t, "a", l[0].ID)
+	assert.Equal(t, "1", l[0].Val)
+	assert.Equal(t, "X", l[0].Origin)
+	assert.Equal(t, "del", l[1].Op)
+	assert.Equal(t, "delkg", l[2].Op)
+	assert.Less(t, l[0].Seq, l[1].Seq)
+
+	h.Lock()
+	assert.Equal(t, []string{kg}, h.deleted)
+	h.Unlock()
+
+	ctx, cncl := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cncl()
+
+	assert.NoError(t, node.Stop(ctx))
+	assert.NoError(t, <-served)
+}
+
+func TestComputedKeygroup(t *testing.T) {
+	user := "computeuser"
+	src := fred.Keygroup{Name: "computesrc", Mutable: true}
+	idx := fred.Keygroup{Name: "computeidx", Mutable: true}
+
+	assert.NoError(t, f.E.HandleCreateKeygroup(user, src))
+	assert.NoError(t, f.E.HandleCreateKeygroup(user, idx))
+
+	compute := fred.Compute{Target: idx.Name, Key: "{$.user}{id}", Fields: map[string]string{"t": "$.temp"}}
+
+	err := f.E.HandleAddTrigger(user, src, fred.Trigger{ID: "self", Compute: fred.Compute{Target: src.Name}})
+	assert.Error(t, err)
+
+	err = f.E.HandleAddTrigger(user, src, fred.Trigger{ID: "missing", Compute: fred.Compute{Target: "computemissing"}})
+	assert.Error(t, err)
+
+	err = f.E.HandleAddTrigger(user, src, fred.Trigger{ID: "bad", Compute: fred.Compute{Target: idx.Name, Key: "{$.temp > 3}"}})
+	assert.Error(t, err)
+
+	// users can only compute keygroups they could write to themselves
+	other := fred.Keygroup{Name: "computeother", Mutable: true}
+	assert.NoError(t, f.E.HandleCreateKeygroup("otheruser", other))
+
+	err = f.E.HandleAddTrigger("otheruser", other, fred.Trigger{ID: "steal", Compute: compute})
+	assert.Error(t, err)
+
+	assert.NoError(t, f.E.HandleAddTrigger(user, src, fred.Trigger{ID: "byuser", Compute: compute}))
+
+	// computing the source from the target would go around in circles
+	err = f.E.HandleAddTrigger(user, idx, fred.Trigger{ID: "back", Compute: fred.Compute{Target: src.Name}})
+	assert.Error(t, err)
+
+	triggers, err := f.E.HandleGetKeygroupTriggers(user, src)
+	assert.NoError(t, err)
+	assert.Len(t, triggers, 1)
+	assert.Equal(t, compute, triggers[0].Compute)
+
+	read := func(id string) (string, bool) {
+		i, err := f.E.HandleRead(user, fred.Item{Keygroup: idx.Name, ID: id})
+
+		if err != nil {
+			return "", false
+		}
+
+		return i.Val, true
+	}
+
+	assert.NoError(t, f.E.HandleUpdate(user, fred.Item{Keygroup: src.Name, ID: "a", Val: `{"user":"alice","temp":3,"other":true}`}))
+
+	assert.Eventually(t, func() bool {
+		val, ok := read("alicea")
+		return ok && val == `{"t":3}`
+	}, 10*time.Second, 50*time.Millisecond)
+
+	// the computed item moves with its key
+	assert.NoError(t, f.E.HandleUpdate(user, fred.Item{Keygroup: src.Name, ID: "a", Val: `{"user":"bob","temp":4}`}))
+
+	assert.Eventually(t, func() bool {
+		val, ok := read("boba")
+		return ok && val == `{"t":4}`
+	}, 10*time.Second, 50*time.Millisecond)
+
+	_, ok := read("alicea")
+	assert.False(t, ok)
+
+	assert.NoError(t, f.E.HandleDelete(user, fred.Item{Keygroup: src.Name, ID: "a"}))
+
+	assert.Eventually(t, func() bool {
+		_, ok := read("boba")
+		return !ok
+	}, 10*time.Second, 50*time.Millisecond)
+
+	// removing the trigger keeps the computed keygroup, but takes away the access of the trigger
+	assert.NoError(t, f.E.HandleUpdate(user, fred.Item{Keygroup: src.Name, ID: "b", Val: `{"user":"carol","temp":5}`}))
+
+	assert.Eventually(t, func() bool {
+		_, ok := read("carolb")
+		return ok
+	}, 10*time.Second, 50*time.Millisecond)
+
+	assert.NoError(t, f.E.HandleRemoveTrigger(user, src, fred.Trigger{ID: "byuser"}))
+
+	_, ok = read("carolb")
+	assert.True(t, ok)
+
+	permissions, err := f.E.HandleGetKeygroupPermissions(user, idx)
+	assert.NoError(t, err)
+
+	for s := range permissions {
+		assert.Equal(t, user, s.Name)
+	}
+}
+
+func TestBackupRestore(t *testing.T) {
+	user := "user"
+	kg := fred.KeygroupName("backup-kg")
+
+	assert.NoError(t, f.E.HandleCreateKeygroup(user, fred.Keygroup{Name: kg, Mutable: true}))
+
+	items := map[string]string{
+		"a":                 "1",
+		"a|b":               "2",
+		"alice@example.com": "3",
+	}
+
+	for id, val := range items {
+		assert.NoError(t, f.E.HandleUpdate(user, fred.Item{Keygroup: kg, ID: id, Val: val}))
+	}
+
+	assert.NoError(t, f.E.HandleAddTrigger(user, fred.Keygroup{Name: kg}, fred.Trigger{ID: "backuptrigger", Host: "127.0.0.1:1"}))
+
+	var b bytes.Buffer
+	assert.NoError(t, f.E.HandleBackup(user, fred.Keygroup{Name: kg}, &b))
+
+	// only users that may back up a keygroup can do so
+	assert.Error(t, f.E.HandleBackup("stranger", fred.Keygroup{Name: kg}, &bytes.Buffer{}))
+
+	// a keygroup that still exists cannot be restored
+	_, err := f.E.HandleRestore("admin", bytes.NewReader(b.Bytes()))
+	assert.Error(t, err)
+
+	assert.NoError(t, f.E.HandleRemoveTrigger(user, fred.Keygroup{Name: kg}, fred.Trigger{ID: "backuptrigger"}))
+	assert.NoError(t, f.E.HandleDeleteKeygroup(user, fred.Keygroup{Name: kg}))
+
+	// broken backups are rejected before anything is restored
+	truncated := b.Bytes()[:b.Len()/2]
+	_, err = f.E.HandleRestore("admin", bytes.NewReader(truncated))
+	assert.Error(t, err)
+
+	_, err = f.E.HandleRestore("admin", strings.NewReader("not a backup"))
+	assert.Error(t, err)
+
+	_, err = f.E.HandleRestore("stranger", bytes.NewReader(b.Bytes()))
+	assert.Error(t, err)
+
+	k, err := f.E.HandleRestore("admin", bytes.NewReader(b.Bytes()))
+	assert.NoError(t, err)
+	assert.Equal(t, kg, k.Name)
+	assert.True(t, k.Mutable)
+
+	for id, val := range items {
+		i, err := f.E.HandleRead("admin", fred.Item{Keygroup: kg, ID: id})
+		assert.NoError(t, err)
+		assert.Equal(t, val, i.Val)
+	}
+
+	triggers, err := f.E.HandleGetKeygroupTriggers("admin", fred.Keygroup{Name: kg})
+	assert.NoError(t, err)
+	assert.Len(t, triggers, 1)
+	assert.Equal(t, "127.0.0.1:1", triggers[0].Host)
+
+	assert.NoError(t, f.E.HandleRemoveTrigger("admin", fred.Keygroup{Name: kg}, fred.Trigger{ID: "backuptrigger"}))
+	assert.NoError(t, f.E.HandleDeleteKeygroup("admin", fred.Keygroup{Name: kg}))
+}
+
+// craftBackup writes a backup of an empty keygroup with the given trigger nodes without going through a node, just like
+// anyone could.
+func craftBackup(t *testing.T, kg fred.KeygroupName, triggers []fred.Trigger) []byte {
+	header, err := json.Marshal(map[string]interface{}{
+		"header": map[string]interface{}{
+			"version":  1,
+			"keygroup": kg,
+			"mutable":  true,
+			"triggers": triggers,
+		},
+	})
+	assert.NoError(t, err)
+
+	header = append(header, '\n')
+	sum := sha256.Sum256(header)
+
+	end, err := json.Marshal(map[string]interface{}{
+		"end": map[string]interface{}{
+			"items":    0,
+			"checksum": hex.EncodeToString(sum[:]),
+		},
+	})
+	assert.NoError(t, err)
+
+	var b bytes.Buffer
+	gz := gzip.NewWriter(&b)
+	_, err = gz.Write(append(header, append(end, '\n')...))
+	assert.NoError(t, err)
+	assert.NoError(t, gz.Close())
+
+	return b.Bytes()
+}
+
+func TestRestoreTriggers(t *testing.T) {
+	owner := "restoreowner"
+	attacker := "restoreattacker"
+	target := fred.Keygroup{Name: "restore-target", Mutable: true}
+	kg := fred.KeygroupName("restore-crafted")
+
+	assert.NoError(t, f.E.HandleCreateKeygroup(owner, target))
+
+	// the attacker may restore the keygroup, but cannot write to the target
+	assert.NoError(t, f.E.HandleAddUser("admin", attacker, fred.Keygroup{Name: kg}, fred.ConfigureKeygroups))
+
+	compute := fred.Trigger{ID: "steal", Compute: fred.Compute{Target: target.Name, Key: "{id}"}}
+
+	_, err := f.E.HandleRestore(attacker, bytes.NewReader(craftBackup(t, kg, []fred.Trigger{compute})))
+	assert.Error(t, err)
+
+	// the computed keygroup got no access to the target
+	perms, err := f.E.HandleGetKeygroupPermissions("admin", target)
+	assert.NoError(t, err)
+
+	for s := range perms {
+		assert.Contains(t, []string{owner}, s.Name)
+	}
+
+	// trigger nodes are also checked for what any user could add
+	webhook := fred.Trigger{ID: "hook", Webhook: fred.Webhook{URL: "ftp://example.com"}}
+	_, err = f.E.HandleRestore(attacker, bytes.NewReader(craftBackup(t, kg, []fred.Trigger{webhook})))
+	assert.Error(t, err)
+
+	// as nothing has been restored, the owner of the target can still restore the same backup
+	assert.NoError(t, f.E.HandleAddUser("admin", owner, fred.Keygroup{Name: kg}, fred.ConfigureKeygroups))
+
+	k, err := f.E.HandleRestore(owner, bytes.NewReader(craftBackup(t, kg, []fred.Trigger{compute})))
+	assert.NoError(t, err)
+	assert.Equal(t, kg, k.Name)
+
+	triggers, err := f.E.HandleGetKeygroupTriggers(owner, k)
+	assert.NoError(t, err)
+	assert.Len(t, triggers, 1)
+
+	assert.NoError(t, f.E.HandleRemoveTrigger(owner, k, fred.Trigger{ID: "steal"}))
+	assert.NoError(t, f.E.HandleDeleteKeygroup(owner, k))
+	assert.NoError(t, f.E.HandleDeleteKeygroup(owner, target))
+}
+
+// newTestNode starts another node that shares the name service with f, e.g., for tests that change the node itself.
+func newTestNode(t *testing.T, nodeID string, port int, config fred.Config) fred.Fred {
+	n, err := etcdnase.NewNameService(nodeID, []string{"127.0.0.1:6000"}, certBasePath+"nodeB.crt", certBasePath+"nodeB.key", certBasePath+"ca.crt", true)
+	assert.NoError(t, err)
+
+	if config.Client == nil {
+		config.Client = peering.NewClient(fred.NodeID(nodeID), tlsProvider, false)
+	}
+
+	config.NaSe = n
+	config.PeeringHost = fmt.Sprintf("127.0.0.1:%d", 8000+port)
+	config.ExternalHost = fmt.Sprintf("127.0.0.1:%d", 9000+port)
+	config.NodeID = nodeID
+	config.TriggerTLS = tlsProvider.ClientConfig(true)
+	config.TriggerMaxAttempts = 2
+	config.TriggerMaxBackoff = 10 * time.Millisecond
+	config.Admins = []string{"admin"}
+
+	return fred.New(&config)
+}
+
+func TestMigrateStore(t *testing.T) {
+	// migrating makes a node read-only, so this uses a node of its own
+	store := badgerdb.NewMemory()
+	config := fred.Config{
+		Store:               store,
+		EncryptionKey:       bytes.Repeat([]byte{3}, fred.EncryptionKeySize),
+		EncryptionKeygroups: []fred.KeygroupName{"migrate-secret"},
+		CompressionRules:    []fred.CompressionRule{{Pattern: "migrate-secret", Algorithm: fred.CompressionGzip}},
+	}
+	config.NewAuditLog = func(store fred.Store) (fred.AuditLog, error) {
+		return auditlog.NewKeygroup(store, 0)
+	}
+	m := newTestNode(t, "M", 10, config)
+
+	user := "user"
+	mutable := fred.KeygroupName("migrate-kg")
+	immutable := fred.KeygroupName("migrate-log")
+	secret := fred.KeygroupName("migrate-secret")
+
+	assert.NoError(t, m.E.HandleCreateKeygroup(user, fred.Keygroup{Name: mutable, Mutable: true}))
+	assert.NoError(t, m.E.HandleCreateKeygroup(user, fred.Keygroup{Name: immutable, Mutable: false, Expiry: 100}))
+	assert.NoError(t, m.E.HandleCreateKeygroup(user, fred.Keygroup{Name: secret, Mutable: true}))
+	assert.NoError(t, m.E.HandleUpdate(user, fred.Item{Keygroup: secret, ID: "s", Val: strings.Repeat("confidential", 10)}))
+
+	assert.NoError(t, m.E.HandleUpdate(user, fred.Item{Keygroup: mutable, ID: "a|b", Val: "1"}))
+	assert.NoError(t, m.E.HandleUpdate(user, fred.Item{Keygroup: mutable, ID: "c", Val: "2"}))
+	assert.NoError(t, m.E.HandleAddTrigger(user, fred.Keygroup{Name: mutable}, fred.Trigger{ID: "migratetrigger", Host: "127.0.0.1:1"}))
+
+	appended, err := m.E.HandleAppend(user, fred.Item{Keygroup: immutable, Val: "entry"})
+	assert.NoError(t, err)
+
+	to := badgerdb.NewMemory()
+
+	stats, err := m.MigrateStore(to)
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, stats.Keygroups, 2)
+	assert.GreaterOrEqual(t, stats.Items, 3)
+	assert.GreaterOrEqual(t, stats.Triggers, 1)
+
+	audited, err := to.IDs("_audit")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, audited)
+
+	val, err := to.Read(string(mutable), "a|b")
+	assert.NoError(t, err)
+	assert.Equal(t, "1", val)
+
+	// appended items keep their IDs and their remaining time to live
+	err = to.Export(string(immutable), func(id string, val string, expiresAt int64) error {
+		assert.Equal(t, appended.ID, id)
+		assert.Equal(t, "entry", val)
+		assert.InDelta(t, time.Now().Unix()+100, expiresAt, 5)
+		return nil
+	})
+	assert.NoError(t, err)
+
+	triggers, err := to.GetKeygroupTrigger(string(mutable))
+	assert.NoError(t, err)
+	assert.Contains(t, triggers, "migratetrigger")
+
+	// values are copied as they are stored, so they stay encrypted and compressed
+	stored, err := store.Read(string(secret), "s")
+	assert.NoError(t, err)
+
+	copied, err := to.Read(string(secret), "s")
+	assert.NoError(t, err)
+	assert.Equal(t, stored, copied)
+	assert.NotContains(t, copied, "confidential")
+
+	config.Store = to
+	migrated := newTestNode(t, "M", 10, config)
+
+	i, err := migrated.E.HandleRead(user, fred.Item{Keygroup: secret, ID: "s"})
+	assert.NoError(t, err)
+	assert.Equal(t, strings.Repeat("confidential", 10), i.Val)
+
+	// the node can still be read from, but not written to
+	i, err = m.E.HandleRead(user, fred.Item{Keygroup: mutable, ID: "c"})
+	assert.NoError(t, err)
+	assert.Equal(t, "2", i.Val)
+
+	assert.Error(t, m.E.HandleUpdate(user, fred.Item{Keygroup: mutable, ID: "c", Val: "3"}))
+	assert.Error(t, m.I.HandleUpdate(fred.Item{Keygroup: mutable, ID: "d", Val: "4"}, "X"))
+	_, err = m.E.HandleAppend(user, fred.Item{Keygroup: immutable, Val: "entry"})
+	assert.Error(t, err)
+
+	// neither is the audit log, which would otherwise differ from the copy
+	ids, err := store.IDs("_audit")
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, audited, ids)
+
+	// keygroups are never merged into a store that already has them
+	_, err = fred.MigrateStore(store, to)
+	assert.Error(t, err)
+}
+
+// countingStore counts the reads that reach a store.
+type countingStore struct {
+	*badgerdb.Storage
+	reads int64
+}
+
+func (c *countingStore) Read(kg string, id string) (string, error) {
+	atomic.AddInt64(&c.reads, 1)
+	return c.Storage.Read(kg, id)
+}
+
+func TestCache(t *testing.T) {
+	store := &countingStore{Storage: badgerdb.NewMemory()}
+	c := newTestNode(t, "C", 11, fred.Config{
+		Store:     store,
+		CacheSize: 1024 * 1024,
+		CacheRules: []fred.CacheRule{
+			{Pattern: "cache-skip", TTL: 0},
+			{Pattern: "cache*", TTL: time.Minute},
+		},
+	})
+
+	user := "user"
+	kg := fred.KeygroupName("cache-kg")
+	skip := fred.KeygroupName("cache-skip")
+	expiring := fred.KeygroupName("cache-expiring")
+
+	assert.NoError(t, c.E.HandleCreateKeygroup(user, fred.Keygroup{Name: kg, Mutable: true}))
+	assert.NoError(t, c.E.HandleCreateKeygroup(user, fred.Keygroup{Name: skip, Mutable: true}))
+	assert.NoError(t, c.E.HandleCreateKeygroup(user, fred.Keygroup{Name: expiring, Mutable: true, Expiry: 1}))
+
+	read := func(kg fred.KeygroupName, id string) (string, int64) {
+		before := atomic.LoadInt64(&store.reads)
+		i, err := c.E.HandleRead(user, fred.Item{Keygroup: kg, ID: id})
+
+		if err != nil {
+			return "", atomic.LoadInt64(&store.reads) - before
+		}
+
+		return i.Val, atomic.LoadInt64(&store.reads) - before
+	}
+
+	assert.NoError(t, c.E.HandleUpdate(user, fred.Item{Keygroup: kg, ID: "a", Val: "1"}))
+
+	// the first read fills the cache, the second does not reach the store
+	val, reads := read(kg, "a")
+	assert.Equal(t, "1", val)
+	assert.Equal(t, int64(1), reads)
+
+	val, reads = read(kg, "a")
+	assert.Equal(t, "1", val)
+	assert.Equal(t, int64(0), reads)
+
+	// local and replicated writes and deletes invalidate the cache
+	assert.NoError(t, c.E.HandleUpdate(user, fred.Item{Keygroup: kg, ID: "a", Val: "2"}))
+	val, _ = read(kg, "a")
+	assert.Equal(t, "2", val)
+
+	assert.NoError(t, c.I.HandleUpdate(fred.Item{Keygroup: kg, ID: "a", Val: "3"}, "X"))
+	val, _ = read(kg, "a")
+	assert.Equal(t, "3", val)
+
+	assert.NoError(t, c.E.HandleDelete(user, fred.Item{Keygroup: kg, ID: "a"}))
+	_, err := c.E.HandleRead(user, fred.Item{Keygroup: kg, ID: "a"})
+	assert.Error(t, err)
+
+	// keygroups with a TTL of 0 are not cached
+	assert.NoError(t, c.E.HandleUpdate(user, fred.Item{Keygroup: skip, ID: "a", Val: "1"}))
+	read(skip, "a")
+	_, reads = read(skip, "a")
+	assert.Equal(t, int64(1), reads)
+
+	// items are not cached for longer than they exist
+	assert.NoError(t, c.E.HandleUpdate(user, fred.Item{Keygroup: expiring, ID: "a", Val: "1"}))
+	val, _ = read(expiring, "a")
+	assert.Equal(t, "1", val)
+	_, reads = read(expiring, "a")
+	assert.Equal(t, int64(0), reads)
+
+	time.Sleep(2 * time.Second)
+
+	_, err = c.E.HandleRead(user, fred.Item{Keygroup: expiring, ID: "a"})
+	assert.Error(t, err)
+
+	stats, ok := c.CacheStats()
+	assert.True(t, ok)
+	assert.Equal(t, uint64(2), stats.Hits[kg]+stats.Hits[expiring])
+	assert.Zero(t, stats.Hits[skip])
+
+	_, ok = f.CacheStats()
+	assert.False(t, ok)
+}
+
+func TestCacheInternalKeygroups(t *testing.T) {
+	store := &countingStore{Storage: badgerdb.NewMemory()}
+	// the cache only has room for a few items
+	k := newTestNode(t, "K", 21, fred.Config{
+		Store:     store,
+		CacheSize: 1024,
+		CacheRules: []fred.CacheRule{
+			{Pattern: "internal-busy", TTL: 0},
+			{Pattern: "*", TTL: time.Minute},
+		},
+	})
+
+	user := "user"
+	kg := fred.KeygroupName("internal-kg")
+	busy := fred.KeygroupName("internal-busy")
+
+	assert.NoError(t, k.E.HandleCreateKeygroup(user, fred.Keygroup{Name: kg, Mutable: true}))
+	assert.NoError(t, k.E.HandleCreateKeygroup(user, fred.Keygroup{Name: busy, Mutable: true}))
+	assert.NoError(t, k.E.HandleAddTrigger(user, fred.Keygroup{Name: busy}, fred.Trigger{ID: "t", Host: "127.0.0.1:1"}))
+
+	assert.NoError(t, k.E.HandleUpdate(user, fred.Item{Keygroup: kg, ID: "a", Val: "1"}))
+	_, err := k.E.HandleRead(user, fred.Item{Keygroup: kg, ID: "a"})
+	assert.NoError(t, err)
+
+	// writes to other items queue trigger events in internal keygroups, which neither push the item out of the cache
+	// nor keep it from being cached again
+	for i := 0; i < 20; i++ {
+		assert.NoError(t, k.E.HandleUpdate(user, fred.Item{Keygroup: busy, ID: strconv.Itoa(i), Val: "v"}))
+	}
+
+	before := atomic.LoadInt64(&store.reads)
+	i, err := k.E.HandleRead(user, fred.Item{Keygroup: kg, ID: "a"})
+	assert.NoError(t, err)
+	assert.Equal(t, "1", i.Val)
+	assert.Equal(t, before, atomic.LoadInt64(&store.reads))
+
+	assert.NoError(t, k.E.HandleDeleteKeygroup(user, fred.Keygroup{Name: kg}))
+	assert.NoError(t, k.E.HandleDeleteKeygroup(user, fred.Keygroup{Name: busy}))
+}
+
+func TestCompression(t *testing.T) {
+	store := badgerdb.NewMemory()
+	z := newTestNode(t, "Z", 12, fred.Config{
+		Store: store,
+		CompressionRules: []fred.CompressionRule{
+			{Pattern: "compress-none", Algorithm: fred.CompressionNone},
+			{Pattern: "compress-gzip", Algorithm: fred.CompressionGzip, MinSize: 16},
+			{Pattern: "compress*", Algorithm: fred.CompressionSnappy, MinSize: 16},
+		},
+	})
+
+	user := "user"
+	large := strings.Repeat(`{"sensor":"temperature","value":21.5},`, 100)
+
+	for _, c := range []struct {
+		kg         fred.KeygroupName
+		header     byte
+		compressed bool
+	}{
+		{"compress-snappy", 0xff, true},
+		{"compress-gzip", 0xfe, true},
+		{"compress-none", 0, false},
+	} {
+		assert.NoError(t, z.E.HandleCreateKeygroup(user, fred.Keygroup{Name: c.kg, Mutable: true}))
+		assert.NoError(t, z.E.HandleUpdate(user, fred.Item{Keygroup: c.kg, ID: "large", Val: large}))
+		assert.NoError(t, z.E.HandleUpdate(user, fred.Item{Keygroup: c.kg, ID: "small", Val: "21.5"}))
+
+		stored, err := store.Read(string(c.kg), "large")
+		assert.NoError(t, err)
+
+		if c.compressed {
+			assert.Equal(t, c.header, stored[0])
+			assert.Less(t, len(stored), len(large)/5)
+		} else {
+			assert.Equal(t, large, stored)
+		}
+
+		// small values are not worth compressing
+		stored, err = store.Read(string(c.kg), "small")
+		assert.NoError(t, err)
+		assert.Equal(t, "21.5", stored)
+
+		// values that were stored before compression was turned on can still be read
+		assert.NoError(t, store.Update(string(c.kg), "old", large, false, 0))
+
+		want := map[string]string{"large": large, "small": "21.5", "old": large}
+
+		for id, val := range want {
+			i, err := z.E.HandleRead(user, fred.Item{Keygroup: c.kg, ID: id})
+			assert.NoError(t, err)
+			assert.Equal(t, val, i.Val)
+		}
+
+		items, err := z.E.HandleScan(user, fred.Item{Keygroup: c.kg, ID: "large"}, 3)
+		assert.NoError(t, err)
+		assert.Len(t, items, 3)
+
+		for _, i := range items {
+			assert.Equal(t, want[i.ID], i.Val)
+		}
+	}
+
+	stats := z.CompressionStats()
+	assert.Equal(t, uint64(2), stats["compress-snappy"].Values)
+	assert.Equal(t, uint64(1), stats["compress-snappy"].Compressed)
+	assert.Greater(t, stats["compress-gzip"].Ratio(), 5.0)
+	assert.Equal(t, uint64(0), stats["compress-none"].Compressed)
+	assert.Equal(t, 1.0, stats["compress-none"].Ratio())
+}
+
+func TestEncryption(t *testing.T) {
+	store := badgerdb.NewMemory()
+	key := bytes.Repeat([]byte{1}, fred.EncryptionKeySize)
+	config := fred.Config{
+		Store:               store,
+		EncryptionKey:       key,
+		EncryptionKeygroups: []fred.KeygroupName{"secret*"},
+	}
+	e := newTestNode(t, "E", 13, config)
+
+	user := "user"
+	secret := fred.KeygroupName("secret-kg")
+	public := fred.KeygroupName("public-kg")
+
+	assert.NoError(t, e.E.HandleCreateKeygroup(user, fred.Keygroup{Name: secret, Mutable: true}))
+	assert.NoError(t, e.E.HandleCreateKeygroup(user, fred.Keygroup{Name: public, Mutable: true}))
+	assert.NoError(t, e.E.HandleUpdate(user, fred.Item{Keygroup: secret, ID: "a", Val: "confidential"}))
+	assert.NoError(t, e.E.HandleUpdate(user, fred.Item{Keygroup: secret, ID: "b", Val: "classified"}))
+	assert.NoError(t, e.E.HandleUpdate(user, fred.Item{Keygroup: public, ID: "a", Val: "open"}))
+
+	stored, err := store.Read(string(secret), "a")
+	assert.NoError(t, err)
+	assert.Equal(t, byte(0xfd), stored[0])
+	assert.NotContains(t, stored, "confidential")
+
+	stored, err = store.Read(string(public), "a")
+	assert.NoError(t, err)
+	assert.Equal(t, "open", stored)
+
+	i, err := e.E.HandleRead(user, fred.Item{Keygroup: secret, ID: "a"})
+	assert.NoError(t, err)
+	assert.Equal(t, "confidential", i.Val)
+
+	// values that were stored before encryption was turned on are encrypted when keys are rotated
+	assert.NoError(t, store.Update(string(secret), "old", "plain", false, 0))
+	assert.NoError(t, e.RotateKeys())
+
+	for id, val := range map[string]string{"a": "confidential", "b": "classified", "old": "plain"} {
+		stored, err = store.Read(string(secret), id)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0xfd, 0, 0, 0, 2}, []byte(stored[:5]))
+
+		i, err = e.E.HandleRead(user, fred.Item{Keygroup: secret, ID: id})
+		assert.NoError(t, err)
+		assert.Equal(t, val, i.Val)
+	}
+
+	// encrypted values are bound to their item
+	stored, err = store.Read(string(secret), "a")
+	assert.NoError(t, err)
+	assert.NoError(t, store.Update(string(secret), "b", stored, false, 0))
+	_, err = e.E.HandleRead(user, fred.Item{Keygroup: secret, ID: "b"})
+	assert.Error(t, err)
+	assert.NoError(t, e.E.HandleUpdate(user, fred.Item{Keygroup: secret, ID: "b", Val: "classified"}))
+
+	// restarting with a new master key and the old one wraps the data keys with the new master key
+	newKey := bytes.Repeat([]byte{2}, fred.EncryptionKeySize)
+	config.EncryptionKey, config.EncryptionOldKey = newKey, key
+	e = newTestNode(t, "E", 13, config)
+
+	i, err = e.E.HandleRead(user, fred.Item{Keygroup: secret, ID: "a"})
+	assert.NoError(t, err)
+	assert.Equal(t, "confidential", i.Val)
+
+	config.EncryptionOldKey = nil
+	e = newTestNode(t, "E", 13, config)
+
+	i, err = e.E.HandleRead(user, fred.Item{Keygroup: secret, ID: "b"})
+	assert.NoError(t, err)
+	assert.Equal(t, "classified", i.Val)
+
+	config.EncryptionKey = key
+	wrong := newTestNode(t, "E", 13, config)
+
+	_, err = wrong.E.HandleRead(user, fred.Item{Keygroup: secret, ID: "a"})
+	assert.Error(t, err)
+
+	// deleting a keygroup also deletes its data keys
+	assert.NoError(t, e.E.HandleDeleteKeygroup(user, fred.Keygroup{Name: secret}))
+	assert.False(t, store.Exists("_datakeys", string(secret)))
+	assert.NoError(t, e.E.HandleDeleteKeygroup(user, fred.Keygroup{Name: public}))
+}
+
+func TestQuota(t *testing.T) {
+	q := newTestNode(t, "Q", 14, fred.Config{
+		Store: badgerdb.NewMemory(),
+		QuotaRules: []fred.QuotaRule{
+			{Pattern: "quota-small", Quota: fred.Quota{MaxItemSize: 10, MaxItems: 3}},
+			{Pattern: "quota-log", Quota: fred.Quota{MaxBytes: 100}},
+			{Pattern: "quota-copy", Quota: fred.Quota{MaxItems: 3}},
+		},
+		NodeMaxItems: 1000,
+	})
+
+	// other nodes ask this node whether keygroups fit before they make it a replica
+	is := peering.NewServer("127.0.0.1:8014", q.I, tlsProvider)
+	defer is.Close()
+
+	user := "user"
+	small := fred.KeygroupName("quota-small")
+
+	assert.NoError(t, q.E.HandleCreateKeygroup(user, fred.Keygroup{Name: small, Mutable: true}))
+	assert.NoError(t, q.E.HandleUpdate(user, fred.Item{Keygroup: small, ID: "a", Val: "1234567890"}))
+
+	err := q.E.HandleUpdate(user, fred.Item{Keygroup: small, ID: "b", Val: "12345678901"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "quota exceeded")
+
+	assert.NoError(t, q.E.HandleUpdate(user, fred.Item{Keygroup: small, ID: "b", Val: "2"}))
+	assert.NoError(t, q.E.HandleUpdate(user, fred.Item{Keygroup: small, ID: "c", Val: "3"}))
+
+	err = q.E.HandleUpdate(user, fred.Item{Keygroup: small, ID: "d", Val: "4"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "quota exceeded")
+
+	// existing items can still be updated, and deleting items makes room
+	assert.NoError(t, q.E.HandleUpdate(user, fred.Item{Keygroup: small, ID: "a", Val: "1"}))
+	assert.NoError(t, q.E.HandleDelete(user, fred.Item{Keygroup: small, ID: "c"}))
+	assert.NoError(t, q.E.HandleUpdate(user, fred.Item{Keygroup: small, ID: "d", Val: "4"}))
+
+	u, err := q.E.HandleGetKeygroupUsage(user, fred.Keygroup{Name: small})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), u.Items)
+	assert.Equal(t, int64(6), u.Bytes)
+	assert.Equal(t, fred.Quota{MaxItemSize: 10, MaxItems: 3}, u.Quota)
+	assert.Equal(t, int64(1000), u.NodeMaxItems)
+
+	// writes from other replicas are never refused, as replicas would diverge otherwise
+	assert.NoError(t, q.I.HandleUpdate(fred.Item{Keygroup: small, ID: "e", Val: "5"}, "X"))
+
+	u, err = q.E.HandleGetKeygroupUsage(user, fred.Keygroup{Name: small})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), u.Items)
+
+	_, err = q.E.HandleGetKeygroupUsage("someoneelse", fred.Keygroup{Name: small})
+	assert.Error(t, err)
+
+	// appended items count with their generated IDs
+	immutable := fred.KeygroupName("quota-log")
+	assert.NoError(t, q.E.HandleCreateKeygroup(user, fred.Keygroup{Name: immutable, Mutable: false}))
+
+	for i := 0; i < 3; i++ {
+		_, err = q.E.HandleAppend(user, fred.Item{Keygroup: immutable, Val: "entry"})
+		assert.NoError(t, err)
+	}
+
+	_, err = q.E.HandleAppend(user, fred.Item{Keygroup: immutable, Val: "entry"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "quota exceeded")
+
+	u, err = q.E.HandleGetKeygroupUsage(user, fred.Keygroup{Name: immutable})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), u.Items)
+	assert.Equal(t, int64(7), u.NodeItems)
+
+	// a keygroup that does not fit is not replicated to this node
+	r := newTestNode(t, "R", 15, fred.Config{Store: badgerdb.NewMemory()})
+	kg := fred.KeygroupName("quota-copy")
+
+	assert.NoError(t, r.E.HandleCreateKeygroup(user, fred.Keygroup{Name: kg, Mutable: true}))
+
+	for i := 0; i < 5; i++ {
+		assert.NoError(t, r.E.HandleUpdate(user, fred.Item{Keygroup: kg, ID: strconv.Itoa(i), Val: "v"}))
+	}
+
+	err = r.E.HandleAddReplica(user, fred.Keygroup{Name: kg}, fred.Node{ID: "Q"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "quota exceeded")
+
+	replicas, _, err := r.E.HandleGetKeygroupReplica(user, fred.Keygroup{Name: kg})
+	assert.NoError(t, err)
+	assert.Empty(t, replicas)
+
+	for _, k := range []fred.KeygroupName{small, immutable} {
+		assert.NoError(t, q.E.HandleDeleteKeygroup(user, fred.Keygroup{Name: k}))
+	}
+
+	assert.NoError(t, r.E.HandleDeleteKeygroup(user, fred.Keygroup{Name: kg}))
+}
+
+// oldPeerClient talks to peers from an older version of FReD, which cannot tell the usage of keygroups.
+type oldPeerClient struct {
+	fred.Client
+	asked int64
+}
+
+func (c *oldPeerClient) SendGetKeygroupUsage(_ string, _ fred.KeygroupName) (fred.KeygroupUsage, error) {
+	atomic.AddInt64(&c.asked, 1)
+	return fred.KeygroupUsage{}, fred.ErrNoKeygroupUsage
+}
+
+func TestQuotaOldPeer(t *testing.T) {
+	c := &oldPeerClient{Client: peering.NewClient("O", tlsProvider, false)}
+	o := newTestNode(t, "O", 19, fred.Config{Store: badgerdb.NewMemory(), Client: c})
+	p := newTestNode(t, "P", 20, fred.Config{Store: badgerdb.NewMemory()})
+
+	ps := peering.NewServer("127.0.0.1:8020", p.I, tlsProvider)
+	defer ps.Close()
+
+	user := "user"
+	kg := fred.KeygroupName("quota-old")
+
+	assert.NoError(t, o.E.HandleCreateKeygroup(user, fred.Keygroup{Name: kg, Mutable: true}))
+	assert.NoError(t, o.E.HandleUpdate(user, fred.Item{Keygroup: kg, ID: "a", Val: "1"}))
+
+	// a node that cannot tell the usage of keygroups has no quotas
+	assert.NoError(t, o.E.HandleAddReplica(user, fred.Keygroup{Name: kg}, fred.Node{ID: "P"}))
+	assert.Equal(t, int64(1), atomic.LoadInt64(&c.asked))
+
+	i, err := p.E.HandleRead(user, fred.Item{Keygroup: kg, ID: "a"})
+	assert.NoError(t, err)
+	assert.Equal(t, "1", i.Val)
+
+	assert.NoError(t, o.E.HandleDeleteKeygroup(user, fred.Keygroup{Name: kg}))
+}
+
+func TestQuotaConcurrent(t *testing.T) {
+	w := newTestNode(t, "W", 18, fred.Config{
+		Store: badgerdb.NewMemory(),
+		QuotaRules: []fred.QuotaRule{
+			{Pattern: "quota-concurrent", Quota: fred.Quota{MaxItems: 10}},
+			{Pattern: "quota-concurrent-log", Quota: fred.Quota{MaxItems: 10}},
+		},
+	})
+
+	user := "user"
+	kg := fred.KeygroupName("quota-concurrent")
+	immutable := fred.KeygroupName("quota-concurrent-log")
+
+	assert.NoError(t, w.E.HandleCreateKeygroup(user, fred.Keygroup{Name: kg, Mutable: true}))
+	assert.NoError(t, w.E.HandleCreateKeygroup(user, fred.Keygroup{Name: immutable, Mutable: false}))
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var updated []string
+	var appended int64
+
+	// concurrent writes of different items cannot exceed the quota together
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+
+		go func(i int) {
+			defer wg.Done()
+
+			if w.E.HandleUpdate(user, fred.Item{Keygroup: kg, ID: strconv.Itoa(i), Val: "v"}) == nil {
+				mu.Lock()
+				updated = append(updated, strconv.Itoa(i))
+				mu.Unlock()
+			}
+		}(i)
+
+		go func() {
+			defer wg.Done()
+
+			if _, err := w.E.HandleAppend(user, fred.Item{Keygroup: immutable, Val: "v"}); err == nil {
+				atomic.AddInt64(&appended, 1)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	assert.Len(t, updated, 10)
+	assert.Equal(t, int64(10), appended)
+
+	u, err := w.E.HandleGetKeygroupUsage(user, fred.Keygroup{Name: kg})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10), u.Items)
+
+	u, err = w.E.HandleGetKeygroupUsage(user, fred.Keygroup{Name: immutable})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10), u.Items)
+
+	// refused writes do not hold anything back, so deleting items makes room again
+	assert.NoError(t, w.E.HandleDelete(user, fred.Item{Keygroup: kg, ID: updated[0]}))
+	assert.NoError(t, w.E.HandleDelete(user, fred.Item{Keygroup: kg, ID: updated[1]}))
+	assert.NoError(t, w.E.HandleUpdate(user, fred.Item{Keygroup: kg, ID: "a", Val: "v"}))
+	assert.NoError(t, w.E.HandleUpdate(user, fred.Item{Keygroup: kg, ID: "b", Val: "v"}))
+
+	assert.NoError(t, w.E.HandleDeleteKeygroup(user, fred.Keygroup{Name: kg}))
+	assert.NoError(t, w.E.HandleDeleteKeygroup(user, fred.Keygroup{Name: immutable}))
+}
+
+// bulkStore counts the items that are written to a store in bulk.
+type bulkStore struct {
+	*badgerdb.Storage
+	bulk int64
+}
+
+func (b *bulkStore) UpdateBulk(items []fred.BulkItem) error {
+	atomic.AddInt64(&b.bulk, int64(len(items)))
+
+	for _, i := range items {
+		if err := b.Storage.Update(i.Keygroup, i.ID, i.Val, i.Append, i.Expiry); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func TestBulkUpdate(t *testing.T) {
+	from := badgerdb.NewMemory()
+	kg := "bulk-kg"
+
+	assert.NoError(t, from.CreateKeygroup(kg))
+
+	for i := 0; i < 2500; i++ {
+		assert.NoError(t, from.Update(kg, strconv.Itoa(i), "value-"+strconv.Itoa(i), false, 0))
+	}
+
+	// migrations write items in batches to stores that can write them in bulk
+	to := &bulkStore{Storage: badgerdb.NewMemory()}
+
+	stats, err := fred.MigrateStore(from, to)
+	assert.NoError(t, err)
+	assert.Equal(t, 2500, stats.Items)
+	assert.Equal(t, int64(2500), atomic.LoadInt64(&to.bulk))
+
+	val, err := to.Read(kg, "1234")
+	assert.NoError(t, err)
+	assert.Equal(t, "value-1234", val)
+}
+
+func TestReplicaBootstrap(t *testing.T) {
+	s := newTestNode(t, "S", 16, fred.Config{Store: badgerdb.NewMemory()})
+	store := &bulkStore{Storage: badgerdb.NewMemory()}
+	// values pass through the other store decorators on their way and are counted towards quotas
+	b := newTestNode(t, "B", 17, fred.Config{
+		Store:            store,
+		CompressionRules: []fred.CompressionRule{{Pattern: "*", Algorithm: fred.CompressionGzip}},
+		CacheSize:        1024 * 1024,
+		CacheRules:       []fred.CacheRule{{Pattern: "*", TTL: time.Minute}},
+		QuotaRules:       []fred.QuotaRule{{Pattern: "bootstrap-*", Quota: fred.Quota{MaxItemSize: 20}}},
+	})
+
+	ss := peering.NewServer("127.0.0.1:8016", s.I, tlsProvider)
+	defer ss.Close()
+
+	bs := peering.NewServer("127.0.0.1:8017", b.I, tlsProvider)
+	defer bs.Close()
+
+	user := "user"
+	kg := fred.KeygroupName("bootstrap-kg")
+
+	assert.NoError(t, s.E.HandleCreateKeygroup(user, fred.Keygroup{Name: kg, Mutable: true}))
+
+	for i := 0; i < 10; i++ {
+		assert.NoError(t, s.E.HandleUpdate(user, fred.Item{Keygroup: kg, ID: strconv.Itoa(i), Val: "value-" + strconv.Itoa(i)}))
+	}
+
+	// a new replica that adds itself gets all items of the keygroup at once
+	assert.NoError(t, b.E.HandleAddReplica(user, fred.Keygroup{Name: kg}, fred.Node{ID: "B"}))
+	assert.Equal(t, int64(10), atomic.LoadInt64(&store.bulk))
+
+	i, err := b.E.HandleRead(user, fred.Item{Keygroup: kg, ID: "7"})
+	assert.NoError(t, err)
+	assert.Equal(t, "value-7", i.Val)
+
+	u, err := b.I.HandleGetKeygroupUsage(fred.Keygroup{Name: kg})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10), u.Items)
+
+	// items that do not fit into the quota of the new replica are not stored
+	big := fred.KeygroupName("bootstrap-big")
+
+	assert.NoError(t, s.E.HandleCreateKeygroup(user, fred.Keygroup{Name: big, Mutable: true}))
+	assert.NoError(t, s.E.HandleUpdate(user, fred.Item{Keygroup: big, ID: "big", Val: strings.Repeat("x", 100)}))
+
+	assert.Error(t, b.E.HandleAddReplica(user, fred.Keygroup{Name: big}, fred.Node{ID: "B"}))
+	assert.False(t, store.Exists(string(big), "big"))
+
+	assert.NoError(t, s.E.HandleDeleteKeygroup(user, fred.Keygroup{Name: kg}))
+	assert.NoError(t, s.E.HandleDeleteKeygroup(user, fred.Keygroup{Name: big}))
+}
@@ -0,0 +1,75 @@
+package fred
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// logicalBits are the lower bits of a hybrid logical clock timestamp that count events within the same millisecond.
+const logicalBits = 16
+
+// hlc is a hybrid logical clock. Its timestamps are close to the physical time in milliseconds but never go backwards
+// and always come after any timestamp the clock has observed from other nodes.
+type hlc struct {
+	sync.Mutex
+	last uint64
+	now  func() time.Time
+}
+
+func newHLC() *hlc {
+	return &hlc{
+		now: time.Now,
+	}
+}
+
+// next returns a new timestamp that is greater than any timestamp this clock has returned or observed.
+func (c *hlc) next() uint64 {
+	c.Lock()
+	defer c.Unlock()
+
+	pt := uint64(c.now().UnixNano()/int64(time.Millisecond)) << logicalBits
+
+	if pt > c.last {
+		c.last = pt
+	} else {
+		c.last++
+	}
+
+	return c.last
+}
+
+// observe moves the clock past a timestamp from another node.
+func (c *hlc) observe(ts uint64) {
+	c.Lock()
+	defer c.Unlock()
+
+	if ts > c.last {
+		c.last = ts
+	}
+}
+
+// appendIDLength is the length of the timestamp at the start of an append ID.
+const appendIDLength = 20
+
+// appendID returns the ID of an item appended at a timestamp on a node. IDs of different nodes never collide, and as
+// timestamps are zero-padded, sorting IDs sorts items by the time they were appended.
+func appendID(ts uint64, node NodeID) string {
+	return fmt.Sprintf("%0*d-%s", appendIDLength, ts, node)
+}
+
+// parseAppendID returns the timestamp of an append ID.
+func parseAppendID(id string) (uint64, bool) {
+	if len(id) <= appendIDLength || id[appendIDLength] != '-' {
+		return 0, false
+	}
+
+	ts, err := strconv.ParseUint(id[:appendIDLength], 10, 64)
+
+	if err != nil {
+		return 0, false
+	}
+
+	return ts, true
+}
@@ -61,7 +61,12 @@ func (h *inthandler) HandleCreateKeygroup(k Keygroup) error {
 }
 
 // HandleDeleteKeygroup handles requests to the DeleteKeygroup endpoint of the internal interface.
-func (h *inthandler) HandleDeleteKeygroup(k Keygroup) error {
+func (h *inthandler) HandleDeleteKeygroup(k Keygroup, origin NodeID) error {
+	// this has to happen before the keygroup and its trigger nodes are gone
+	if err := h.t.triggerDeleteKeygroup(k.Name, origin); err != nil {
+		log.Err(err).Msgf("could not queue trigger events for deleting keygroup %s", k.Name)
+	}
+
 	if err := h.s.deleteKeygroup(k.Name); err != nil {
 		log.Err(err).Msg(err.(*errors.Error).ErrorStack())
 		return errors.Errorf("error deleting keygroup")
@@ -78,7 +83,8 @@ func (h *inthandler) HandleDeleteKeygroup(k Keygroup) error {
 }
 
 // HandleUpdate handles requests to the Update endpoint of the internal interface.
-func (h *inthandler) HandleUpdate(i Item) error {
+// origin is the ID of the node that replicated the change to us.
+func (h *inthandler) HandleUpdate(i Item, origin NodeID) error {
 
 	expiry, err := h.n.GetExpiry(i.Keygroup)
 
@@ -91,16 +97,16 @@ func (h *inthandler) HandleUpdate(i Item) error {
 		return errors.Errorf("error updating item")
 	}
 
-	if err := h.t.triggerUpdate(i); err != nil {
-		log.Err(err).Msg(err.(*errors.Error).ErrorStack())
-		return errors.Errorf("error updating item")
+	// trigger nodes are informed in the background, a failure here should not fail the write
+	if err := h.t.triggerUpdate(i, expiry, origin); err != nil {
+		log.Err(err).Msgf("could not queue trigger events for item %s in keygroup %s", i.ID, i.Keygroup)
 	}
 
 	return nil
 }
 
 // HandleAppend handles requests to the Append endpoint of the internal interface.
-func (h *inthandler) HandleAppend(i Item) error {
+func (h *inthandler) HandleAppend(i Item, origin NodeID) error {
 
 	expiry, err := h.n.GetExpiry(i.Keygroup)
 
@@ -113,24 +119,24 @@ func (h *inthandler) HandleAppend(i Item) error {
 		return errors.Errorf("error updating item")
 	}
 
-	if err := h.t.triggerUpdate(i); err != nil {
-		log.Err(err).Msg(err.(*errors.Error).ErrorStack())
-		return errors.Errorf("error updating item")
+	// trigger nodes are informed in the background, a failure here should not fail the write
+	if err := h.t.triggerAppend(i, expiry, origin); err != nil {
+		log.Err(err).Msgf("could not queue trigger events for item %s in keygroup %s", i.ID, i.Keygroup)
 	}
 
 	return nil
 }
 
 // HandleDelete handles requests to the Delete endpoint of the internal interface.
-func (h *inthandler) HandleDelete(i Item) error {
+func (h *inthandler) HandleDelete(i Item, origin NodeID) error {
 	if err := h.s.delete(i.Keygroup, i.ID); err != nil {
 		log.Err(err).Msg(err.(*errors.Error).ErrorStack())
 		return errors.Errorf("error deleting item")
 	}
 
-	if err := h.t.triggerDelete(i); err != nil {
-		log.Err(err).Msg(err.(*errors.Error).ErrorStack())
-		return errors.Errorf("error deleting item")
+	// trigger nodes are informed in the background, a failure here should not fail the write
+	if err := h.t.triggerDelete(i, origin); err != nil {
+		log.Err(err).Msgf("could not queue trigger events for item %s in keygroup %s", i.ID, i.Keygroup)
 	}
 
 	return nil
@@ -143,3 +149,9 @@ func (h *inthandler) HandleAddReplica(k Keygroup, n Node) error {
 func (h *inthandler) HandleRemoveReplica(k Keygroup, n Node) error {
 	return h.r.removeReplica(k, n, false)
 }
+
+// HandleGetKeygroupUsage returns how much a keygroup stores on this node and what it may store, so that other nodes
+// can check whether it fits before they make this node a replica.
+func (h *inthandler) HandleGetKeygroupUsage(k Keygroup) (KeygroupUsage, error) {
+	return h.s.usage(k.Name)
+}
@@ -5,18 +5,70 @@ type Method string
 
 // These are all methods that clients can perform on FReD, implemented as constants for easier use.
 const (
-	CreateKeygroup Method = "CreateKeygroup"
-	DeleteKeygroup Method = "DeleteKeygroup"
-	Read           Method = "Read"
-	Update         Method = "Update"
-	Delete         Method = "Delete"
-	AddReplica     Method = "AddReplica"
-	GetReplica     Method = "GetReplica"
-	RemoveReplica  Method = "RemoveReplica"
-	GetAllReplica  Method = "GetAllReplica"
-	GetTrigger     Method = "GetTrigger"
-	AddTrigger     Method = "AddTrigger"
-	RemoveTrigger  Method = "RemoveTrigger"
-	AddUser        Method = "AddUser"
-	RemoveUser     Method = "RemoveUser"
+	CreateKeygroup      Method = "CreateKeygroup"
+	DeleteKeygroup      Method = "DeleteKeygroup"
+	Read                Method = "Read"
+	Scan                Method = "Scan"
+	Update              Method = "Update"
+	Append              Method = "Append"
+	Delete              Method = "Delete"
+	AddReplica          Method = "AddReplica"
+	GetReplica          Method = "GetReplica"
+	GetKeygroupReplica  Method = "GetKeygroupReplica"
+	RemoveReplica       Method = "RemoveReplica"
+	GetUsage            Method = "GetUsage"
+	GetAllReplica       Method = "GetAllReplica"
+	GetTrigger          Method = "GetTrigger"
+	AddTrigger          Method = "AddTrigger"
+	RemoveTrigger       Method = "RemoveTrigger"
+	GetTriggerEvents    Method = "GetTriggerEvents"
+	ReplayTriggerEvents Method = "ReplayTriggerEvents"
+	AddUser             Method = "AddUser"
+	RemoveUser          Method = "RemoveUser"
+	GetPermissions      Method = "GetPermissions"
+	GetAuditLog         Method = "GetAuditLog"
+	ConfigureRoles      Method = "ConfigureRoles"
+	ConfigureGroups     Method = "ConfigureGroups"
+	Backup              Method = "Backup"
+	Restore             Method = "Restore"
+)
+
+var (
+	// methods is the set of all methods that can be part of a role.
+	methods = map[Method]struct{}{
+		CreateKeygroup:      {},
+		DeleteKeygroup:      {},
+		Read:                {},
+		Scan:                {},
+		Update:              {},
+		Append:              {},
+		Delete:              {},
+		AddReplica:          {},
+		GetKeygroupReplica:  {},
+		RemoveReplica:       {},
+		GetUsage:            {},
+		GetTrigger:          {},
+		AddTrigger:          {},
+		RemoveTrigger:       {},
+		GetTriggerEvents:    {},
+		ReplayTriggerEvents: {},
+		AddUser:             {},
+		RemoveUser:          {},
+		GetPermissions:      {},
+		GetAuditLog:         {},
+		ConfigureRoles:      {},
+		ConfigureGroups:     {},
+		Backup:              {},
+		Restore:             {},
+	}
+
+	// legacyMethods maps methods to the method that used to cover them before they had their own permission.
+	// Users that were granted the old method directly should not lose access.
+	legacyMethods = map[Method]Method{
+		Scan:                Read,
+		Append:              Update,
+		GetKeygroupReplica:  GetReplica,
+		GetTriggerEvents:    GetTrigger,
+		ReplayTriggerEvents: AddTrigger,
+	}
 )
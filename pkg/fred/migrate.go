@@ -0,0 +1,291 @@
+package fred
+
+import (
+	"crypto/sha256"
+	"sync"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/rs/zerolog/log"
+)
+
+// migrateBatchSize is how many items are written to the target store at once.
+const migrateBatchSize = 1000
+
+// MigrationStats counts what was copied by a migration. Expired items had expired before they could be copied.
+type MigrationStats struct {
+	Keygroups int
+	Items     int
+	Expired   int
+	Triggers  int
+}
+
+// readOnlyStore is a Store that can be switched to reject all writes, e.g., while its contents are copied to another
+// store. Every part of FReD writes through it, including peers, trigger nodes, and internal keygroups.
+type readOnlyStore struct {
+	Store
+	mu sync.RWMutex
+	on bool
+}
+
+func newReadOnlyStore(s Store) *readOnlyStore {
+	return &readOnlyStore{
+		Store: s,
+	}
+}
+
+// setReadOnly rejects all further writes. It returns once all writes that are in progress are done.
+func (r *readOnlyStore) setReadOnly() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.on = true
+}
+
+// write runs a write unless the store is read-only.
+func (r *readOnlyStore) write(fn func() error) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.on {
+		return errors.Errorf("node is read-only while its store is migrated")
+	}
+
+	return fn()
+}
+
+func (r *readOnlyStore) Update(kg, id, val string, append bool, expiry int) error {
+	return r.write(func() error { return r.Store.Update(kg, id, val, append, expiry) })
+}
+
+func (r *readOnlyStore) UpdateBulk(items []BulkItem) error {
+	return r.write(func() error { return updateBulk(r.Store, items) })
+}
+
+func (r *readOnlyStore) Delete(kg, id string) error {
+	return r.write(func() error { return r.Store.Delete(kg, id) })
+}
+
+func (r *readOnlyStore) Append(kg, id, val string, expiry int) error {
+	return r.write(func() error { return r.Store.Append(kg, id, val, expiry) })
+}
+
+func (r *readOnlyStore) CreateKeygroup(kg string) error {
+	return r.write(func() error { return r.Store.CreateKeygroup(kg) })
+}
+
+func (r *readOnlyStore) DeleteKeygroup(kg string) error {
+	return r.write(func() error { return r.Store.DeleteKeygroup(kg) })
+}
+
+func (r *readOnlyStore) AddKeygroupTrigger(kg string, id string, host string) error {
+	return r.write(func() error { return r.Store.AddKeygroupTrigger(kg, id, host) })
+}
+
+func (r *readOnlyStore) DeleteKeygroupTrigger(kg string, id string) error {
+	return r.write(func() error { return r.Store.DeleteKeygroupTrigger(kg, id) })
+}
+
+// Export keeps the expiry of items if the underlying store is an Exporter.
+func (r *readOnlyStore) Export(kg string, fn func(id string, val string, expiresAt int64) error) error {
+	return exportStore(r.Store, kg, fn)
+}
+
+// MigrateStore makes this node read-only and copies everything in its store to another store. Values are copied as
+// they are stored, i.e., still encrypted and compressed. The node stays read-only and should be restarted with the
+// other store once the migration has succeeded. Writes from peers that are rejected in the meantime are fetched again
+// after the restart, as for any other node that was offline.
+func (f Fred) MigrateStore(to Store) (MigrationStats, error) {
+	if f.store == nil {
+		return MigrationStats{}, errors.Errorf("node has no store to migrate")
+	}
+
+	f.store.setReadOnly()
+
+	log.Info().Msg("node is now read-only, migrating store")
+
+	return MigrateStore(f.store.Store, to)
+}
+
+// migratedItem is what is needed to verify that an item was copied: a checksum of its value and when it expires.
+type migratedItem struct {
+	sum       [sha256.Size]byte
+	expiresAt int64
+}
+
+// MigrateStore copies all keygroups with their items and trigger nodes from one store to another and verifies the copy.
+// Items keep their remaining time to live, items that expire during the migration are skipped. The source store must
+// thus be an Exporter. Appended items keep their IDs. The target store must not have any of the keygroups yet, and the
+// source store must not be written to during the migration.
+func MigrateStore(from, to Store) (MigrationStats, error) {
+	var stats MigrationStats
+
+	if _, ok := from.(Exporter); !ok {
+		return stats, errors.Errorf("cannot migrate from a store that cannot tell when its items expire")
+	}
+
+	kgs, err := from.Keygroups()
+
+	if err != nil {
+		return stats, err
+	}
+
+	for _, kg := range kgs {
+		if to.ExistsKeygroup(kg) {
+			return stats, errors.Errorf("keygroup %s already exists in the target store", kg)
+		}
+	}
+
+	for _, kg := range kgs {
+		items, expired, err := migrateKeygroup(from, to, kg)
+
+		if err != nil {
+			return stats, err
+		}
+
+		triggers, err := migrateTriggers(from, to, kg)
+
+		if err != nil {
+			return stats, err
+		}
+
+		if err := verifyKeygroup(to, kg, items); err != nil {
+			return stats, err
+		}
+
+		log.Debug().Msgf("migrated keygroup %s with %d items and %d trigger nodes", kg, len(items), triggers)
+
+		stats.Keygroups++
+		stats.Items += len(items)
+		stats.Expired += expired
+		stats.Triggers += triggers
+	}
+
+	return stats, nil
+}
+
+// migrateKeygroup creates a keygroup in the target store and copies its items.
+func migrateKeygroup(from, to Store, kg string) (map[string]migratedItem, int, error) {
+	if err := to.CreateKeygroup(kg); err != nil {
+		return nil, 0, err
+	}
+
+	items := make(map[string]migratedItem)
+	expired := 0
+	batch := make([]BulkItem, 0, migrateBatchSize)
+
+	err := from.(Exporter).Export(kg, func(id string, val string, expiresAt int64) error {
+		expiry := 0
+
+		if expiresAt != 0 {
+			// round up, so that an item does not expire before it would have in the source store
+			left := time.Until(time.Unix(expiresAt, 0))
+
+			if left <= 0 {
+				expired++
+				return nil
+			}
+
+			expiry = int((left + time.Second - 1) / time.Second)
+		}
+
+		items[id] = migratedItem{
+			sum:       sha256.Sum256([]byte(val)),
+			expiresAt: expiresAt,
+		}
+
+		batch = append(batch, BulkItem{Keygroup: kg, ID: id, Val: val, Expiry: expiry})
+
+		if len(batch) < migrateBatchSize {
+			return nil
+		}
+
+		err := updateBulk(to, batch)
+		batch = batch[:0]
+
+		return err
+	})
+
+	if errors.Is(err, ErrNoExport) {
+		return nil, 0, errors.Errorf("cannot migrate keygroup %s because the store cannot tell when its items expire", kg)
+	}
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if err := updateBulk(to, batch); err != nil {
+		return nil, 0, err
+	}
+
+	return items, expired, nil
+}
+
+// migrateTriggers copies the trigger nodes of a keygroup and checks that the target store has the same trigger nodes.
+func migrateTriggers(from, to Store, kg string) (int, error) {
+	triggers, err := from.GetKeygroupTrigger(kg)
+
+	if err != nil {
+		return 0, err
+	}
+
+	for id, host := range triggers {
+		if err := to.AddKeygroupTrigger(kg, id, host); err != nil {
+			return 0, err
+		}
+	}
+
+	copied, err := to.GetKeygroupTrigger(kg)
+
+	if err != nil {
+		return 0, err
+	}
+
+	if len(copied) != len(triggers) {
+		return 0, errors.Errorf("keygroup %s should have %d trigger nodes in the target store but has %d", kg, len(triggers), len(copied))
+	}
+
+	for id, host := range triggers {
+		if copied[id] != host {
+			return 0, errors.Errorf("trigger node %s of keygroup %s was not copied correctly", id, kg)
+		}
+	}
+
+	return len(triggers), nil
+}
+
+// verifyKeygroup checks that the target store has exactly the copied items of a keygroup. Items may only be missing if
+// they have expired in the meantime.
+func verifyKeygroup(to Store, kg string, items map[string]migratedItem) error {
+	copied, err := to.ReadAll(kg)
+
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().Unix()
+
+	for id, i := range items {
+		val, ok := copied[id]
+
+		if !ok {
+			if i.expiresAt != 0 && i.expiresAt <= now {
+				continue
+			}
+
+			return errors.Errorf("item %q of keygroup %s is missing in the target store", id, kg)
+		}
+
+		if sha256.Sum256([]byte(val)) != i.sum {
+			return errors.Errorf("item %q of keygroup %s has a different checksum in the target store", id, kg)
+		}
+	}
+
+	for id := range copied {
+		if _, ok := items[id]; !ok {
+			return errors.Errorf("keygroup %s has item %q in the target store that was not copied", kg, id)
+		}
+	}
+
+	return nil
+}
@@ -6,10 +6,27 @@ type NameService interface {
 	GetNodeID() NodeID
 	RegisterSelf(host string, externalHost string) error
 
+	// get the revision of the nameservice that the local view of this node reflects
+	Revision() int64
+
 	// manage permissions
 	AddUserPermissions(user string, method Method, keygroup KeygroupName) error
 	RevokeUserPermissions(user string, method Method, keygroup KeygroupName) error
 	GetUserPermissions(user string, keygroup KeygroupName) (map[Method]struct{}, error)
+	GetAllUserPermissions(keygroup KeygroupName) (map[string]map[Method]struct{}, error)
+
+	// manage roles, groups and role bindings
+	AddRole(role Role, methods []Method) error
+	RemoveRole(role Role) error
+	GetRoles() (map[Role]map[Method]struct{}, error)
+	AddGroupMember(group string, user string) error
+	RemoveGroupMember(group string, user string) error
+	GetGroupMembers(group string) ([]string, error)
+	GetUserGroups(user string) ([]string, error)
+	AddRoleBinding(b RoleBinding) error
+	RevokeRoleBinding(b RoleBinding) error
+	GetRoleBindings(s Subject) ([]RoleBinding, error)
+	GetAllRoleBindings() ([]RoleBinding, error)
 
 	// get information about a keygroup
 	IsMutable(kg KeygroupName) (bool, error)
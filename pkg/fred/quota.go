@@ -0,0 +1,431 @@
+package fred
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-errors/errors"
+)
+
+// usageRecountInterval is how often the usage of a keygroup whose items expire may be counted again in the store, as
+// the store removes expired items without telling us.
+const usageRecountInterval = 1 * time.Second
+
+// Quota limits what a keygroup may store on this node. Bytes count the IDs and values of items, MaxItemSize only
+// counts values. 0 means no limit.
+type Quota struct {
+	MaxItemSize int
+	MaxItems    int64
+	MaxBytes    int64
+}
+
+// QuotaRule sets the quota of keygroups that match a pattern.
+type QuotaRule struct {
+	Pattern KeygroupName
+	Quota   Quota
+}
+
+// KeygroupUsage is how much a keygroup stores on a node and what it may store there. The node totals cover all
+// keygroups on the node and are only known if the node has quotas.
+type KeygroupUsage struct {
+	Items        int64
+	Bytes        int64
+	Quota        Quota
+	NodeItems    int64
+	NodeBytes    int64
+	NodeMaxItems int64
+	NodeMaxBytes int64
+}
+
+// ErrNoKeygroupUsage is returned when a node cannot tell how much a keygroup stores on it, e.g., because it is from an
+// older version of FReD without quotas.
+var ErrNoKeygroupUsage = errors.Errorf("node cannot tell the usage of keygroups")
+
+// limited checks whether the quota of the keygroup or the node limits anything.
+func (u KeygroupUsage) limited() bool {
+	return u.Quota.MaxItems > 0 || u.Quota.MaxBytes > 0 || u.NodeMaxItems > 0 || u.NodeMaxBytes > 0
+}
+
+// fits checks whether items and bytes can be added without exceeding the quota of the keygroup or the limits of the
+// node.
+func (u KeygroupUsage) fits(kg KeygroupName, items, bytes int64) error {
+	if u.Quota.MaxItems > 0 && items > 0 && u.Items+items > u.Quota.MaxItems {
+		return errors.Errorf("quota exceeded: keygroup %s may have at most %d items on this node and has %d", kg, u.Quota.MaxItems, u.Items)
+	}
+
+	if u.Quota.MaxBytes > 0 && bytes > 0 && u.Bytes+bytes > u.Quota.MaxBytes {
+		return errors.Errorf("quota exceeded: keygroup %s may store at most %d bytes on this node and stores %d", kg, u.Quota.MaxBytes, u.Bytes)
+	}
+
+	if u.NodeMaxItems > 0 && items > 0 && u.NodeItems+items > u.NodeMaxItems {
+		return errors.Errorf("quota exceeded: node may have at most %d items and has %d", u.NodeMaxItems, u.NodeItems)
+	}
+
+	if u.NodeMaxBytes > 0 && bytes > 0 && u.NodeBytes+bytes > u.NodeMaxBytes {
+		return errors.Errorf("quota exceeded: node may store at most %d bytes and stores %d", u.NodeMaxBytes, u.NodeBytes)
+	}
+
+	return nil
+}
+
+// usage is what a keygroup stores. writes counts changes, so that a count from the store does not replace changes that
+// happened while it was counted. Pending items and bytes are reserved by writes that are still in progress.
+type usage struct {
+	items        int64
+	bytes        int64
+	pendingItems int64
+	pendingBytes int64
+	expires      bool
+	counted      time.Time
+	writes       uint64
+}
+
+// reservation holds items and bytes of a keygroup for a write that is in progress.
+type reservation struct {
+	k     *usage
+	items int64
+	bytes int64
+	done  bool
+}
+
+// quotaTracker keeps track of how many items and bytes every keygroup stores on this node. Usage is counted in the
+// store when it is first needed and then updated on every write. Internal keygroups are not counted.
+type quotaTracker struct {
+	store     Store
+	rules     []QuotaRule
+	nodeItems int64
+	nodeBytes int64
+	locks     itemLocks
+	mu        sync.Mutex
+	loaded    bool
+	usage     map[KeygroupName]*usage
+	total     usage
+}
+
+func newQuotaTracker(store Store, rules []QuotaRule, nodeItems, nodeBytes int64) *quotaTracker {
+	return &quotaTracker{
+		store:     store,
+		rules:     rules,
+		nodeItems: nodeItems,
+		nodeBytes: nodeBytes,
+		usage:     make(map[KeygroupName]*usage),
+	}
+}
+
+// limited checks whether there are any quotas. Usage is only tracked if there are.
+func (q *quotaTracker) limited() bool {
+	return len(q.rules) > 0 || q.nodeItems > 0 || q.nodeBytes > 0
+}
+
+// quota returns the quota of the first rule that matches a keygroup.
+func (q *quotaTracker) quota(kg KeygroupName) Quota {
+	for _, r := range q.rules {
+		if matchKeygroup(r.Pattern, kg) {
+			return r.Quota
+		}
+	}
+
+	return Quota{}
+}
+
+// count counts the items of a keygroup and their bytes in the store.
+func (q *quotaTracker) count(kg KeygroupName) (int64, int64, error) {
+	var items, bytes int64
+	now := time.Now().Unix()
+
+	err := exportStore(q.store, string(kg), func(id string, val string, expiresAt int64) error {
+		if expiresAt != 0 && expiresAt <= now {
+			return nil
+		}
+
+		items++
+		bytes += int64(len(id) + len(val))
+
+		return nil
+	})
+
+	return items, bytes, err
+}
+
+// load counts the usage of all keygroups in the store. Must be called with the lock held.
+func (q *quotaTracker) load() error {
+	if q.loaded {
+		return nil
+	}
+
+	kgs, err := q.store.Keygroups()
+
+	if err != nil {
+		return err
+	}
+
+	for _, kg := range kgs {
+		if strings.HasPrefix(kg, "_") {
+			continue
+		}
+
+		items, bytes, err := q.count(KeygroupName(kg))
+
+		if err != nil {
+			return err
+		}
+
+		q.usage[KeygroupName(kg)] = &usage{items: items, bytes: bytes, counted: time.Now()}
+		q.total.items += items
+		q.total.bytes += bytes
+	}
+
+	q.loaded = true
+
+	return nil
+}
+
+// prepare counts usage in the store before the first write is recorded, so that the write is not counted twice.
+func (q *quotaTracker) prepare() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.load()
+}
+
+// get returns the usage of a keygroup and of the node.
+func (q *quotaTracker) get(kg KeygroupName) (KeygroupUsage, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if err := q.load(); err != nil {
+		return KeygroupUsage{}, err
+	}
+
+	return q.current(kg, false), nil
+}
+
+// current returns the usage of a keygroup and of the node, including what writes in progress have reserved if pending
+// is set. Must be called with the lock held.
+func (q *quotaTracker) current(kg KeygroupName, pending bool) KeygroupUsage {
+	u := KeygroupUsage{
+		Quota:        q.quota(kg),
+		NodeItems:    q.total.items,
+		NodeBytes:    q.total.bytes,
+		NodeMaxItems: q.nodeItems,
+		NodeMaxBytes: q.nodeBytes,
+	}
+
+	if pending {
+		u.NodeItems += q.total.pendingItems
+		u.NodeBytes += q.total.pendingBytes
+	}
+
+	if k, ok := q.usage[kg]; ok {
+		u.Items = k.items
+		u.Bytes = k.bytes
+
+		if pending {
+			u.Items += k.pendingItems
+			u.Bytes += k.pendingBytes
+		}
+	}
+
+	return u
+}
+
+// recount counts a keygroup in the store again if its items expire and it has not been counted recently.
+func (q *quotaTracker) recount(kg KeygroupName) error {
+	q.mu.Lock()
+	k, ok := q.usage[kg]
+
+	if !ok || !k.expires || time.Since(k.counted) < usageRecountInterval {
+		q.mu.Unlock()
+		return nil
+	}
+
+	writes := k.writes
+	q.mu.Unlock()
+
+	items, bytes, err := q.count(kg)
+
+	if err != nil {
+		return err
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.usage[kg] != k || k.writes != writes {
+		return nil
+	}
+
+	q.total.items += items - k.items
+	q.total.bytes += bytes - k.bytes
+	k.items = items
+	k.bytes = bytes
+	k.counted = time.Now()
+
+	return nil
+}
+
+// recountAll counts all keygroups whose items expire again.
+func (q *quotaTracker) recountAll() error {
+	q.mu.Lock()
+	kgs := make([]KeygroupName, 0, len(q.usage))
+
+	for kg, k := range q.usage {
+		if k.expires {
+			kgs = append(kgs, kg)
+		}
+	}
+
+	q.mu.Unlock()
+
+	for _, kg := range kgs {
+		if err := q.recount(kg); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// reserve checks whether a write that adds items and bytes to a keygroup and has a value of size bytes is within its
+// quota, counting what other writes in progress have reserved, and reserves the items and bytes for the write until it
+// is committed or released. If the write does not fit, keygroups whose items may have expired in the meantime are
+// counted again before the write is refused. expiry is the expiry of the keygroup on this node.
+func (q *quotaTracker) reserve(kg KeygroupName, items, bytes int64, size int, expiry int) (*reservation, error) {
+	quota := q.quota(kg)
+
+	if quota.MaxItemSize > 0 && size > quota.MaxItemSize {
+		return nil, errors.Errorf("quota exceeded: values in keygroup %s may have at most %d bytes but the value has %d", kg, quota.MaxItemSize, size)
+	}
+
+	r, err := q.hold(kg, items, bytes)
+
+	if err == nil {
+		return r, nil
+	}
+
+	if expiry > 0 {
+		q.mu.Lock()
+		if k, ok := q.usage[kg]; ok {
+			k.expires = true
+		}
+		q.mu.Unlock()
+	}
+
+	if err := q.recount(kg); err != nil {
+		return nil, err
+	}
+
+	if q.nodeItems > 0 || q.nodeBytes > 0 {
+		if err := q.recountAll(); err != nil {
+			return nil, err
+		}
+	}
+
+	return q.hold(kg, items, bytes)
+}
+
+// hold reserves items and bytes for a write to a keygroup if they fit into its quota.
+func (q *quotaTracker) hold(kg KeygroupName, items, bytes int64) (*reservation, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if err := q.load(); err != nil {
+		return nil, err
+	}
+
+	if err := q.current(kg, true).fits(kg, items, bytes); err != nil {
+		return nil, err
+	}
+
+	k, ok := q.usage[kg]
+
+	if !ok {
+		k = &usage{counted: time.Now()}
+		q.usage[kg] = k
+	}
+
+	// writes that free items or bytes only free them once they are done
+	r := &reservation{k: k}
+
+	if items > 0 {
+		r.items = items
+	}
+
+	if bytes > 0 {
+		r.bytes = bytes
+	}
+
+	k.pendingItems += r.items
+	k.pendingBytes += r.bytes
+	q.total.pendingItems += r.items
+	q.total.pendingBytes += r.bytes
+
+	return r, nil
+}
+
+// release gives back what a write has reserved, e.g., because it failed. Releasing a reservation again or releasing no
+// reservation does nothing.
+func (q *quotaTracker) release(r *reservation) {
+	if r == nil {
+		return
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.unreserve(r)
+}
+
+// unreserve gives back what a write has reserved. Must be called with the lock held.
+func (q *quotaTracker) unreserve(r *reservation) {
+	if r == nil || r.done {
+		return
+	}
+
+	r.done = true
+	r.k.pendingItems -= r.items
+	r.k.pendingBytes -= r.bytes
+	q.total.pendingItems -= r.items
+	q.total.pendingBytes -= r.bytes
+}
+
+// written records that a write added items and bytes to a keygroup, both of which may be negative, and gives back what
+// the write had reserved, if anything. Usage must have been prepared before the write.
+func (q *quotaTracker) written(kg KeygroupName, items, bytes int64, expiry int, r *reservation) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.unreserve(r)
+
+	k, ok := q.usage[kg]
+
+	if !ok {
+		k = &usage{counted: time.Now()}
+		q.usage[kg] = k
+	}
+
+	k.items += items
+	k.bytes += bytes
+	k.writes++
+	k.expires = k.expires || expiry > 0
+
+	q.total.items += items
+	q.total.bytes += bytes
+}
+
+// dropped forgets the usage of a keygroup that was deleted.
+func (q *quotaTracker) dropped(kg KeygroupName) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	k, ok := q.usage[kg]
+
+	if !ok {
+		return
+	}
+
+	q.total.items -= k.items
+	q.total.bytes -= k.bytes
+	delete(q.usage, kg)
+}
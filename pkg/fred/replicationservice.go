@@ -16,19 +16,22 @@ type Client interface {
 	SendRemoveReplica(host string, kgname KeygroupName, node Node) error
 	SendGetItem(host string, kgname KeygroupName, id string) (Item, error)
 	SendGetAllItems(host string, kgname KeygroupName) ([]Item, error)
+	SendGetKeygroupUsage(host string, kgname KeygroupName) (KeygroupUsage, error)
 }
 
 type replicationService struct {
 	c Client
 	s *storeService
+	t *triggerService
 	n NameService
 }
 
 // newReplicationService creates a new handler for internal request (i.e. from peer nodes or the naming service).
 // The nameservice makes sure that the information is synced with the other nodes
-func newReplicationService(s *storeService, c Client, n NameService) *replicationService {
+func newReplicationService(s *storeService, t *triggerService, c Client, n NameService) *replicationService {
 	service := &replicationService{
 		s: s,
+		t: t,
 		c: c,
 		n: n,
 	}
@@ -263,6 +266,77 @@ func (s *replicationService) relayDelete(i Item) error {
 	return err
 }
 
+// checkCapacity checks whether a keygroup fits into the quotas of a node before the node becomes its replica. Only the
+// new replica knows its quotas, so it is asked first and nothing is counted if it has none. Nodes that cannot tell the
+// usage of keygroups have no quotas. If this node is the new replica, the size of the keygroup is asked from another
+// replica.
+func (s *replicationService) checkCapacity(k Keygroup, n Node) error {
+	var have, target KeygroupUsage
+	var err error
+
+	if n.ID != s.n.GetNodeID() {
+		addr, err := s.n.GetNodeAddress(n.ID)
+
+		if err != nil {
+			return err
+		}
+
+		target, err = s.c.SendGetKeygroupUsage(addr, k.Name)
+
+		if errors.Is(err, ErrNoKeygroupUsage) {
+			return nil
+		}
+
+		if err != nil {
+			return errors.Errorf("could not get usage of node %s: %v", n.ID, err)
+		}
+
+		if !target.limited() {
+			return nil
+		}
+
+		if have, err = s.s.usage(k.Name); err != nil {
+			return err
+		}
+	} else {
+		if !s.s.q.limited() {
+			return nil
+		}
+
+		if target, err = s.s.usage(k.Name); err != nil {
+			return err
+		}
+
+		if !target.limited() {
+			return nil
+		}
+
+		_, addr := s.n.GetNodeWithBiggerExpiry(k.Name)
+
+		// without another replica there is no data to copy
+		if addr == "" {
+			return nil
+		}
+
+		have, err = s.c.SendGetKeygroupUsage(addr, k.Name)
+
+		// the items are still checked against the quota one by one when they are stored
+		if errors.Is(err, ErrNoKeygroupUsage) {
+			return nil
+		}
+
+		if err != nil {
+			return errors.Errorf("could not get usage of keygroup %s: %v", k.Name, err)
+		}
+	}
+
+	if err := target.fits(k.Name, have.Items, have.Bytes); err != nil {
+		return errors.Errorf("node %s cannot become a replica of keygroup %s: %v", n.ID, k.Name, err)
+	}
+
+	return nil
+}
+
 // addReplica handles replication after requests to the AddReplica endpoint. It relays this command if "relay" is set to "true".
 func (s *replicationService) addReplica(k Keygroup, n Node, relay bool) error {
 	log.Debug().Msgf("AddReplica from replservice: in kg=%#v no=%#v", k, n)
@@ -383,6 +457,17 @@ func (s *replicationService) addReplica(k Keygroup, n Node, relay bool) error {
 			return err
 		}
 
+		// we are the new node: store the data ourselves at once instead of sending it to ourselves item by item
+		if n.ID == s.n.GetNodeID() {
+			log.Debug().Msgf("AddReplica from replservice: About to store %d Elements", len(i))
+			if err := s.bootstrap(k.Name, i, !mutable); err != nil {
+				log.Err(err).Msgf("could not store the items of keygroup %s", k.Name)
+				return errors.Errorf("error adding replica")
+			}
+
+			return nil
+		}
+
 		log.Debug().Msgf("AddReplica from replservice: About to send %d Elements to new node", len(i))
 		for _, item := range i {
 			// iterate over all data for that keygroup and send it to the new node
@@ -422,6 +507,35 @@ func (s *replicationService) addReplica(k Keygroup, n Node, relay bool) error {
 	return nil
 }
 
+// bootstrap stores the items that this node got from another replica after it became a replica of their keygroup and
+// informs the trigger nodes of the keygroup about them, as if they had been replicated one by one.
+func (s *replicationService) bootstrap(kg KeygroupName, items []Item, appended bool) error {
+	expiry, err := s.n.GetExpiry(kg)
+
+	if err != nil {
+		return err
+	}
+
+	written, err := s.s.updateAll(kg, items, appended, expiry)
+
+	// trigger nodes are informed in the background, a failure here should not fail the write
+	for _, i := range written {
+		var terr error
+
+		if appended {
+			terr = s.t.triggerAppend(i, expiry, s.n.GetNodeID())
+		} else {
+			terr = s.t.triggerUpdate(i, expiry, s.n.GetNodeID())
+		}
+
+		if terr != nil {
+			log.Err(terr).Msgf("could not queue trigger events for item %s in keygroup %s", i.ID, i.Keygroup)
+		}
+	}
+
+	return err
+}
+
 // removeReplica handles replication after requests to the RemoveReplica endpoint
 // If relay==true this call comes from the exthandler => relay it to other nodes.
 // The other nodes will be called with relay=false
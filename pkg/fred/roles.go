@@ -12,29 +12,57 @@ const (
 	ConfigureKeygroups Role = "K"
 )
 
+// AllKeygroups is the keygroup pattern that matches every keygroup. Methods that are not specific to a keygroup, such
+// as ConfigureRoles and ConfigureGroups, are checked against this pattern.
+const AllKeygroups KeygroupName = "*"
+
 var (
 	permissions = map[Role]map[Method]struct{}{
 		ReadKeygroup: {
-			Read: {},
+			Read:     {},
+			Scan:     {},
+			GetUsage: {},
 		},
 		WriteKeygroup: {
 			Update: {},
+			Append: {},
 			Delete: {},
 		},
 		ConfigureReplica: {
-			AddReplica:    {},
-			GetReplica:    {},
-			RemoveReplica: {},
+			AddReplica:         {},
+			GetKeygroupReplica: {},
+			RemoveReplica:      {},
 		},
 		ConfigureTrigger: {
-			GetTrigger:    {},
-			AddTrigger:    {},
-			RemoveTrigger: {},
+			GetTrigger:          {},
+			AddTrigger:          {},
+			RemoveTrigger:       {},
+			GetTriggerEvents:    {},
+			ReplayTriggerEvents: {},
 		},
 		ConfigureKeygroups: {
+			CreateKeygroup: {},
 			DeleteKeygroup: {},
 			AddUser:        {},
 			RemoveUser:     {},
+			GetPermissions: {},
+			GetAuditLog:    {},
+			Backup:         {},
+			Restore:        {},
 		},
 	}
 )
+
+// Subject is someone that roles can be granted to: either a single user or a group of users.
+type Subject struct {
+	Name  string
+	Group bool
+}
+
+// RoleBinding grants a role to a subject on all keygroups that match a keygroup pattern.
+// The pattern is either a keygroup name or contains "*" wildcards, e.g., "sensor*".
+type RoleBinding struct {
+	Subject  Subject
+	Role     Role
+	Keygroup KeygroupName
+}
@@ -1,15 +1,23 @@
 package fred
 
-import "github.com/go-errors/errors"
+import (
+	"encoding/json"
+	"hash/fnv"
+	"strings"
+	"sync"
 
-// Store is an interface for the storage medium that the key-value val items are persisted on.
+	"github.com/go-errors/errors"
+)
+
+// Store is an interface for the storage medium that the key-value val items are persisted on. IDs can be any UTF-8
+// string, so stores must encode keygroup names and IDs in a way that keeps keygroups apart, e.g., with pkg/storekey.
 type Store interface {
 	// Needs: keygroup, id, val
 	Update(kg, id, val string, append bool, expiry int) error
 	// Needs: keygroup, id
 	Delete(kg, id string) error
-	// Needs: keygroup, val, Returns: key
-	Append(kg, val string, expiry int) (string, error)
+	// Needs: keygroup, id, val; fails if the id already exists
+	Append(kg, id, val string, expiry int) error
 	// Needs: keygroup, id; Returns: val
 	Read(kg, id string) (string, error)
 	// Needs: keygroup, id, range; Returns: ids and values
@@ -28,24 +36,103 @@ type Store interface {
 	DeleteKeygroup(kg string) error
 	// Needs: keygroup
 	ExistsKeygroup(kg string) bool
+	// Returns: all keygroups in the store
+	Keygroups() ([]string, error)
 	// Needs: keygroup, trigger node id, trigger node host
+	// The host may also be a JSON object with the host and further configuration of the trigger node, which the store
+	// should keep as it is.
 	AddKeygroupTrigger(kg string, id string, host string) error
 	// Needs: keygroup, trigger node id
 	DeleteKeygroupTrigger(kg string, id string) error
-	// Needs: keygroup; Returns map: trigger node id -> trigger node host
+	// Needs: keygroup; Returns map: trigger node id -> trigger node host (as passed to AddKeygroupTrigger)
 	GetKeygroupTrigger(kg string) (map[string]string, error)
 	// Close indicates that the underlying store should be closed as it is no longer needed.
 	Close() error
 }
 
+// Exporter can optionally be implemented by a Store to export a keygroup for a backup. Unlike ReadAll, it also returns
+// when items expire, as a Unix timestamp or 0 if they do not, and it should read all items at the same point in time.
+type Exporter interface {
+	Export(kg string, fn func(id string, val string, expiresAt int64) error) error
+}
+
+// ErrNoExport is returned by an Exporter that turns out not to be able to tell when items expire, e.g., a remote store
+// whose server is from an older version of FReD.
+var ErrNoExport = errors.Errorf("store cannot export items with their expiry")
+
+// BulkItem is an item that is written together with other items. Expiry is in seconds, 0 means that it does not expire.
+type BulkItem struct {
+	Keygroup string
+	ID       string
+	Val      string
+	Append   bool
+	Expiry   int
+}
+
+// BulkUpdater can optionally be implemented by a Store to write many items in one request, which saves remote stores a
+// round-trip per item, e.g., when a new replica gets all items of a keygroup or a store is migrated to them. Some items
+// may have been written if it fails.
+type BulkUpdater interface {
+	UpdateBulk(items []BulkItem) error
+}
+
+// updateBulk writes items to a store, in one request if the store is a BulkUpdater.
+func updateBulk(st Store, items []BulkItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	if b, ok := st.(BulkUpdater); ok {
+		return b.UpdateBulk(items)
+	}
+
+	for _, i := range items {
+		if err := st.Update(i.Keygroup, i.ID, i.Val, i.Append, i.Expiry); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// exportStore exports a keygroup from a store. Stores that cannot tell when items expire, because they are not an
+// Exporter or return ErrNoExport, export their items without expiry.
+func exportStore(st Store, kg string, fn func(id string, val string, expiresAt int64) error) error {
+	if e, ok := st.(Exporter); ok {
+		if err := e.Export(kg, fn); !errors.Is(err, ErrNoExport) {
+			return err
+		}
+	}
+
+	items, err := st.ReadAll(kg)
+
+	if err != nil {
+		return err
+	}
+
+	for id, val := range items {
+		if err := fn(id, val, 0); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 type storeService struct {
 	iS Store
+	c  *hlc
+	n  NodeID
+	q  *quotaTracker
 }
 
-// NewStoreService creates a new val manipulation service.
-func newStoreService(iS Store) *storeService {
+// NewStoreService creates a new val manipulation service. The node ID makes the IDs of appended items unique.
+func newStoreService(iS Store, n NodeID, q *quotaTracker) *storeService {
 	return &storeService{
 		iS: iS,
+		c:  newHLC(),
+		n:  n,
+		q:  q,
 	}
 }
 
@@ -145,6 +232,17 @@ func (s *storeService) readAll(kg KeygroupName) ([]Item, error) {
 	return i, nil
 }
 
+// export calls fn for every item of a keygroup with the time its expiry passes.
+func (s *storeService) export(kg KeygroupName, fn func(i Item, expiresAt int64) error) error {
+	if !s.iS.ExistsKeygroup(string(kg)) {
+		return errors.Errorf("no such keygroup in store: %#v", kg)
+	}
+
+	return exportStore(s.iS, string(kg), func(id string, val string, expiresAt int64) error {
+		return fn(Item{Keygroup: kg, ID: id, Val: val}, expiresAt)
+	})
+}
+
 // exists checks if an item exists in the key-value store.
 func (s *storeService) exists(i Item) bool {
 	return s.iS.Exists(string(i.Keygroup), i.ID)
@@ -152,6 +250,13 @@ func (s *storeService) exists(i Item) bool {
 
 // Update updates an item in the key-value store.
 func (s *storeService) update(i Item, append bool, expiry int) error {
+	return s.updateReserved(i, append, expiry, nil)
+}
+
+// updateReserved updates an item like update and then gives back what the update had reserved of its quota.
+func (s *storeService) updateReserved(i Item, append bool, expiry int, r *reservation) error {
+	defer s.q.release(r)
+
 	err := checkItem(i)
 
 	if err != nil {
@@ -162,15 +267,213 @@ func (s *storeService) update(i Item, append bool, expiry int) error {
 		return errors.Errorf("no such keygroup in store: %#v", i.Keygroup)
 	}
 
+	// appends from other nodes move our clock, so that our next appends come after them
+	if append {
+		if ts, ok := parseAppendID(i.ID); ok {
+			s.c.observe(ts)
+		}
+	}
+
+	if !s.q.limited() {
+		return s.iS.Update(string(i.Keygroup), i.ID, i.Val, append, expiry)
+	}
+
+	l := s.q.locks.get(string(i.Keygroup), i.ID)
+	l.Lock()
+	defer l.Unlock()
+
+	if err := s.q.prepare(); err != nil {
+		return err
+	}
+
+	old, existed, err := s.size(i.Keygroup, i.ID)
+
+	if err != nil {
+		return err
+	}
+
 	err = s.iS.Update(string(i.Keygroup), i.ID, i.Val, append, expiry)
 
 	if err != nil {
 		return err
 	}
 
+	items := int64(1)
+
+	if existed {
+		items = 0
+	}
+
+	s.q.written(i.Keygroup, items, itemSize(i)-old, expiry, r)
+
 	return nil
 }
 
+// updateAll writes many items of a keygroup to the key-value store at once, in one request if the store is a
+// BulkUpdater. Each item is checked against the quota of the keygroup like a single update. If an item does not fit,
+// the items before it are still written. It returns the items that were written.
+func (s *storeService) updateAll(kg KeygroupName, items []Item, appended bool, expiry int) ([]Item, error) {
+	if !s.iS.ExistsKeygroup(string(kg)) {
+		return nil, errors.Errorf("no such keygroup in store: %#v", kg)
+	}
+
+	type counted struct {
+		items int64
+		bytes int64
+		r     *reservation
+	}
+
+	bulk := make([]BulkItem, 0, len(items))
+	counts := make([]counted, 0, len(items))
+
+	defer func() {
+		for _, c := range counts {
+			s.q.release(c.r)
+		}
+	}()
+
+	var fail error
+
+	for _, i := range items {
+		if err := checkItem(i); err != nil {
+			return nil, err
+		}
+
+		if i.Keygroup != kg {
+			return nil, errors.Errorf("item %s is in keygroup %s instead of %s", i.ID, i.Keygroup, kg)
+		}
+
+		if s.q.limited() {
+			old, existed, err := s.size(kg, i.ID)
+
+			if err != nil {
+				return nil, err
+			}
+
+			c := counted{items: 1, bytes: itemSize(i) - old}
+
+			if existed {
+				c.items = 0
+			}
+
+			c.r, err = s.q.reserve(kg, c.items, c.bytes, len(i.Val), expiry)
+
+			if err != nil {
+				fail = err
+				break
+			}
+
+			counts = append(counts, c)
+		}
+
+		// appends from other nodes move our clock, so that our next appends come after them
+		if appended {
+			if ts, ok := parseAppendID(i.ID); ok {
+				s.c.observe(ts)
+			}
+		}
+
+		bulk = append(bulk, BulkItem{Keygroup: string(kg), ID: i.ID, Val: i.Val, Append: appended, Expiry: expiry})
+	}
+
+	if s.q.limited() {
+		if err := s.q.prepare(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := updateBulk(s.iS, bulk); err != nil {
+		return nil, err
+	}
+
+	for _, c := range counts {
+		s.q.written(kg, c.items, c.bytes, expiry, c.r)
+	}
+
+	return items[:len(bulk)], fail
+}
+
+// itemSize is how many bytes an item counts towards quotas.
+func itemSize(i Item) int64 {
+	return int64(len(i.ID) + len(i.Val))
+}
+
+// size returns how many bytes an item in the store counts towards quotas and whether it exists.
+func (s *storeService) size(kg KeygroupName, id string) (int64, bool, error) {
+	if !s.iS.Exists(string(kg), id) {
+		return 0, false, nil
+	}
+
+	val, err := s.iS.Read(string(kg), id)
+
+	if err != nil {
+		return 0, false, err
+	}
+
+	return itemSize(Item{ID: id, Val: val}), true, nil
+}
+
+// reserveQuota checks whether a client can update an item without exceeding the quota of its keygroup or the limits of
+// this node and reserves what the update needs until it is written with updateReserved or released. expiry is the
+// expiry of the keygroup on this node.
+func (s *storeService) reserveQuota(i Item, expiry int) (*reservation, error) {
+	if !s.q.limited() {
+		return nil, nil
+	}
+
+	old, existed, err := s.size(i.Keygroup, i.ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	items := int64(1)
+
+	if existed {
+		items = 0
+	}
+
+	return s.q.reserve(i.Keygroup, items, itemSize(i)-old, len(i.Val), expiry)
+}
+
+// reserveAppendQuota checks whether a client can append an item without exceeding the quota of its keygroup or the
+// limits of this node and reserves what the append needs until it is written with append or released.
+func (s *storeService) reserveAppendQuota(i Item, expiry int) (*reservation, error) {
+	if !s.q.limited() {
+		return nil, nil
+	}
+
+	// IDs of appended items on this node always have the same length
+	i.ID = appendID(0, s.n)
+
+	return s.q.reserve(i.Keygroup, 1, itemSize(i), len(i.Val), expiry)
+}
+
+// usage returns how much a keygroup stores on this node. Without quotas, usage is not tracked and the keygroup is
+// counted in the store instead.
+func (s *storeService) usage(kg KeygroupName) (KeygroupUsage, error) {
+	if err := checkKeygroup(kg); err != nil {
+		return KeygroupUsage{}, err
+	}
+
+	if !s.q.limited() {
+		var u KeygroupUsage
+		var err error
+
+		if s.iS.ExistsKeygroup(string(kg)) {
+			u.Items, u.Bytes, err = s.q.count(kg)
+		}
+
+		return u, err
+	}
+
+	if err := s.q.recount(kg); err != nil {
+		return KeygroupUsage{}, err
+	}
+
+	return s.q.get(kg)
+}
+
 // Delete removes an item from the key-value store.
 func (s *storeService) delete(kg KeygroupName, id string) error {
 	err := checkKGandID(kg, id)
@@ -183,28 +486,63 @@ func (s *storeService) delete(kg KeygroupName, id string) error {
 		return errors.Errorf("no such keygroup in store: %#v", kg)
 	}
 
+	if !s.q.limited() {
+		return s.iS.Delete(string(kg), id)
+	}
+
+	l := s.q.locks.get(string(kg), id)
+	l.Lock()
+	defer l.Unlock()
+
+	if err := s.q.prepare(); err != nil {
+		return err
+	}
+
+	old, existed, err := s.size(kg, id)
+
+	if err != nil {
+		return err
+	}
+
 	err = s.iS.Delete(string(kg), id)
 
 	if err != nil {
 		return err
 	}
 
+	if existed {
+		s.q.written(kg, -1, -old, 0, nil)
+	}
+
 	return nil
 }
 
-// append appends an item in the key-value store.
-func (s *storeService) append(i Item, expiry int) (Item, error) {
+// append appends an item in the key-value store with an ID that is unique across all nodes and then gives back what the
+// append had reserved of its quota.
+func (s *storeService) append(i Item, expiry int, r *reservation) (Item, error) {
+	defer s.q.release(r)
+
 	if !s.iS.ExistsKeygroup(string(i.Keygroup)) {
 		return i, errors.Errorf("no such keygroup in store: %#v", i.Keygroup)
 	}
 
-	k, err := s.iS.Append(string(i.Keygroup), i.Val, expiry)
+	i.ID = appendID(s.c.next(), s.n)
+
+	if s.q.limited() {
+		if err := s.q.prepare(); err != nil {
+			return i, err
+		}
+	}
+
+	err := s.iS.Append(string(i.Keygroup), i.ID, i.Val, expiry)
 
 	if err != nil {
 		return i, err
 	}
 
-	i.ID = k
+	if s.q.limited() {
+		s.q.written(i.Keygroup, 1, itemSize(i), expiry, r)
+	}
 
 	return i, nil
 }
@@ -250,6 +588,8 @@ func (s *storeService) deleteKeygroup(kg KeygroupName) error {
 		return err
 	}
 
+	s.q.dropped(kg)
+
 	return nil
 }
 
@@ -264,7 +604,13 @@ func (s *storeService) addKeygroupTrigger(kg KeygroupName, t Trigger) error {
 		return errors.Errorf("no such keygroup in store: %#v", kg)
 	}
 
-	err = s.iS.AddKeygroupTrigger(string(kg), t.ID, t.Host)
+	host, err := encodeTrigger(t)
+
+	if err != nil {
+		return err
+	}
+
+	err = s.iS.AddKeygroupTrigger(string(kg), t.ID, host)
 
 	if err != nil {
 		return err
@@ -313,11 +659,92 @@ func (s *storeService) getKeygroupTrigger(kg KeygroupName) ([]Trigger, error) {
 	var tn []Trigger
 
 	for id, host := range t {
-		tn = append(tn, Trigger{
-			ID:   id,
-			Host: host,
-		})
+		trigger, err := decodeTrigger(id, host)
+
+		if err != nil {
+			return nil, err
+		}
+
+		tn = append(tn, trigger)
 	}
 
 	return tn, nil
 }
+
+// storedTrigger is how the configuration of a trigger node is kept in the store if it has more than a host.
+type storedTrigger struct {
+	Host    string        `json:"host"`
+	Filter  TriggerFilter `json:"filter"`
+	Webhook *Webhook      `json:"webhook,omitempty"`
+	Compute *Compute      `json:"compute,omitempty"`
+}
+
+// encodeTrigger turns a trigger into the host string for the store. Plain gRPC triggers without a filter are stored
+// with just their host, as before filters were introduced.
+func encodeTrigger(t Trigger) (string, error) {
+	if t.Filter.IsEmpty() && t.Webhook.URL == "" && t.Compute.Target == "" {
+		return t.Host, nil
+	}
+
+	st := storedTrigger{
+		Host:   t.Host,
+		Filter: t.Filter,
+	}
+
+	if t.Webhook.URL != "" {
+		st.Webhook = &t.Webhook
+	}
+
+	if t.Compute.Target != "" {
+		st.Compute = &t.Compute
+	}
+
+	b, err := json.Marshal(st)
+
+	if err != nil {
+		return "", errors.New(err)
+	}
+
+	return string(b), nil
+}
+
+func decodeTrigger(id string, host string) (Trigger, error) {
+	if !strings.HasPrefix(host, "{") {
+		return Trigger{ID: id, Host: host}, nil
+	}
+
+	var st storedTrigger
+
+	if err := json.Unmarshal([]byte(host), &st); err != nil {
+		return Trigger{}, errors.Errorf("malformed configuration for trigger node %s: %v", id, err)
+	}
+
+	t := Trigger{
+		ID:     id,
+		Host:   st.Host,
+		Filter: st.Filter,
+	}
+
+	if st.Webhook != nil {
+		t.Webhook = *st.Webhook
+	}
+
+	if st.Compute != nil {
+		t.Compute = *st.Compute
+	}
+
+	return t, nil
+}
+
+// itemLocks spreads locks for single items over a fixed number of mutexes.
+type itemLocks [64]sync.Mutex
+
+// get returns the lock for an item.
+func (l *itemLocks) get(kg, id string) *sync.Mutex {
+	h := fnv.New32a()
+	h.Write([]byte(kg))
+	h.Write([]byte{0})
+	h.Write([]byte(id))
+
+	return &l[h.Sum32()%uint32(len(l))]
+}
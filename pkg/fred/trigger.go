@@ -2,37 +2,112 @@ package fred
 
 import (
 	"context"
-	"crypto/tls"
-	"crypto/x509"
-	"io/ioutil"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
 
 	"git.tu-berlin.de/mcc-fred/fred/proto/trigger"
 	"github.com/go-errors/errors"
 	"github.com/rs/zerolog/log"
-	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
 )
 
-// Trigger is one trigger node with an ID and a host address.
+// These are the defaults for retrying trigger events. The backoff starts at minTriggerBackoff and doubles with every
+// failed attempt up to the maximum backoff.
+const (
+	defaultTriggerMaxAttempts = 10
+	defaultTriggerMaxBackoff  = 1 * time.Minute
+	defaultTriggerBatchSize   = 100
+	minTriggerBackoff         = 100 * time.Millisecond
+	triggerTimeout            = 5 * time.Second
+)
+
+// Trigger is one trigger node with an ID and a host address. The filter decides which events it receives.
+// If the trigger has a webhook, events are posted to its URL instead and the host is not used. If it computes a
+// keygroup, events are applied to the target keygroup inside FReD. Stats are only set when listing the triggers of a
+// keygroup.
 type Trigger struct {
-	ID   string
-	Host string
+	ID      string
+	Host    string
+	Filter  TriggerFilter
+	Webhook Webhook
+	Compute Compute
+	Stats   TriggerStats `json:"-"`
 }
 
-type triggerService struct {
-	tc *credentials.TransportCredentials
-	s  *storeService
+// unbatched returns true if events for the trigger are not sent to a gRPC trigger node and thus cannot be batched.
+func (tn Trigger) unbatched() bool {
+	return tn.Webhook.URL != "" || tn.Compute.Target != ""
 }
 
-func (t *triggerService) getConnAndClient(host string) (client trigger.TriggerNodeClient, conn *grpc.ClientConn) {
-	conn, err := grpc.Dial(host, grpc.WithTransportCredentials(*t.tc))
-	if err != nil {
-		log.Fatal().Err(err).Msg("Cannot create Grpc connection")
-		return nil, nil
-	}
-	log.Debug().Msgf("Interclient: Created Connection to %s", host)
-	client = trigger.NewTriggerNodeClient(conn)
-	return
+// TriggerOp is the kind of change that a trigger event is about.
+type TriggerOp string
+
+// These are the operations that trigger nodes are informed about. TriggerExpire is an item that has been removed
+// because its keygroup's expiry has passed, TriggerDeleteKeygroup is the deletion of the whole keygroup.
+const (
+	TriggerPut            TriggerOp = "put"
+	TriggerAppend         TriggerOp = "append"
+	TriggerDelete         TriggerOp = "delete"
+	TriggerExpire         TriggerOp = "expire"
+	TriggerDeleteKeygroup TriggerOp = "deletekeygroup"
+)
+
+// TriggerEvent is a change to an item that is delivered to a trigger node. Seq is the position of the event in the
+// queue of this FReD node, Origin is the FReD node where the change originated: this node for client writes, expiries,
+// and keygroup deletions requested by clients, or the peer that replicated the change to us.
+// KeygroupSeq and IdempotencyKey are what the trigger node sees: KeygroupSeq increases with every change of the
+// keygroup on this node and the IdempotencyKey stays the same when an event is sent again. Replayed events are
+// delivered even if the trigger node has acknowledged a higher KeygroupSeq.
+type TriggerEvent struct {
+	Seq            uint64       `json:"seq"`
+	KeygroupSeq    uint64       `json:"keygroupSeq"`
+	IdempotencyKey string       `json:"idempotencyKey"`
+	Keygroup       KeygroupName `json:"keygroup"`
+	Origin         NodeID       `json:"origin"`
+	Trigger        Trigger      `json:"trigger"`
+	Op             TriggerOp    `json:"op"`
+	ID             string       `json:"id"`
+	Val            string       `json:"val,omitempty"`
+	Time           time.Time    `json:"time"`
+	Attempts       int          `json:"attempts"`
+	LastError      string       `json:"lastError,omitempty"`
+	Replayed       bool         `json:"replayed,omitempty"`
+}
+
+// triggerKey identifies a trigger node of a keygroup.
+type triggerKey struct {
+	kg KeygroupName
+	id string
+}
+
+// triggerService delivers events to trigger nodes in the background. Every trigger node of a keygroup has its own
+// worker that delivers events in the order they were queued. If a delivery fails, it is retried with an exponential
+// backoff until it succeeds or the maximum number of attempts is reached, after which the event is moved to the dead
+// letters. Events are stored in the triggerQueue until delivered, so a trigger outage never fails a client write.
+// Events are queued one change at a time, so that the keygroup sequence numbers are in the same order as the events in
+// the workers and every item's changes reach trigger nodes in the order they were made.
+type triggerService struct {
+	node         NodeID
+	p            *triggerPool
+	hc           *http.Client
+	s            *storeService
+	q            *triggerQueue
+	x            *expiryTracker
+	st           *statsKeeper
+	k            *computedKeys
+	h            ExtHandler
+	a            *authService
+	maxAttempts  int
+	maxBackoff   time.Duration
+	batchSize    int
+	batchLatency time.Duration
+	order        sync.Mutex
+	sync.Mutex
+	workers map[triggerKey]*triggerWorker
 }
 
 // logs the response and returns the correct error message
@@ -43,8 +118,12 @@ func dealWithStatusResponse(res *trigger.TriggerResponse, err error, from string
 		log.Debug().Msgf("Interclient got empty Response from %s", from)
 	}
 
-	if err != nil || res == nil {
-		return err
+	if err != nil {
+		return errors.New(err)
+	}
+
+	if res == nil {
+		return errors.Errorf("empty response from %s", from)
 	}
 
 	if res.Status == trigger.EnumTriggerStatus_TRIGGER_ERROR {
@@ -55,125 +134,808 @@ func dealWithStatusResponse(res *trigger.TriggerResponse, err error, from string
 
 }
 
-func newTriggerService(s *storeService, certFile string, keyFile string, caFiles []string) *triggerService {
-	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+// ackedSeq returns the high-water mark that a trigger node has sent with its response, if any.
+func ackedSeq(res *trigger.TriggerResponse) uint64 {
+	if res == nil {
+		return 0
+	}
+
+	return res.AckedSeq
+}
+
+// newTriggerService creates a trigger service with the trigger settings of the config and restarts the delivery of
+// all events that were still queued when the node was stopped. Settings that are 0 are replaced by defaults. A batch
+// size of 1 turns batching off, with a batch latency of 0 batches only contain events that are already queued.
+func newTriggerService(s *storeService, node NodeID, config *Config) (*triggerService, error) {
+	q, err := newTriggerQueue(s.iS)
 
 	if err != nil {
-		log.Fatal().Err(err).Msg("Cannot load certificates")
-		return nil
+		return nil, err
+	}
+
+	maxAttempts := config.TriggerMaxAttempts
+
+	if maxAttempts <= 0 {
+		maxAttempts = defaultTriggerMaxAttempts
+	}
+
+	maxBackoff := config.TriggerMaxBackoff
+
+	if maxBackoff <= 0 {
+		maxBackoff = defaultTriggerMaxBackoff
+	}
+
+	batchSize := config.TriggerBatchSize
+
+	if batchSize <= 0 {
+		batchSize = defaultTriggerBatchSize
 	}
 
-	// Create a new cert pool and add our own CA certificate
-	rootCAs, err := x509.SystemCertPool()
+	t := &triggerService{
+		node:         node,
+		p:            newTriggerPool(credentials.NewTLS(config.TriggerTLS), config.TriggerIdleTimeout),
+		hc:           newWebhookClient(config.TriggerTLS),
+		s:            s,
+		q:            q,
+		maxAttempts:  maxAttempts,
+		maxBackoff:   maxBackoff,
+		batchSize:    batchSize,
+		batchLatency: config.TriggerBatchLatency,
+		workers:      make(map[triggerKey]*triggerWorker),
+	}
+
+	t.st, err = newStatsKeeper(s.iS)
 
 	if err != nil {
-		log.Fatal().Err(err).Msg("Cannot load root certificates")
-		return nil
+		return nil, err
 	}
 
-	for _, f := range caFiles {
-		loaded, err := ioutil.ReadFile(f)
+	t.k, err = newComputedKeys(s.iS)
 
-		if err != nil {
-			log.Fatal().Msgf("unexpected missing certfile: %v", err)
+	if err != nil {
+		return nil, err
+	}
+
+	t.x, err = newExpiryTracker(s.iS, func(kg KeygroupName, id string) {
+		if _, err := t.enqueue(TriggerExpire, Item{Keygroup: kg, ID: id}, node); err != nil {
+			log.Err(err).Msgf("could not queue trigger events for expired item %s in keygroup %s", id, kg)
 		}
+	})
+
+	if err != nil {
+		return nil, err
+	}
 
-		rootCAs.AppendCertsFromPEM(loaded)
+	pending, err := q.pending()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(pending) > 0 {
+		log.Info().Msgf("resuming delivery of %d queued trigger events", len(pending))
+	}
+
+	for _, e := range pending {
+		t.worker(e.Keygroup, e.Trigger.ID).add(e)
 	}
 
-	tlsConfig := &tls.Config{
-		Certificates: []tls.Certificate{cert},
-		MinVersion:   tls.VersionTLS12,
-		RootCAs:      rootCAs,
+	return t, nil
+}
+
+// worker returns the worker for a trigger node and starts it if necessary.
+func (t *triggerService) worker(kg KeygroupName, id string) *triggerWorker {
+	t.Lock()
+	defer t.Unlock()
+
+	k := triggerKey{kg: kg, id: id}
+
+	if w, ok := t.workers[k]; ok {
+		return w
 	}
 
-	tc := credentials.NewTLS(tlsConfig)
+	acked, err := t.q.acked(kg, id)
 
-	return &triggerService{
-		tc: &tc,
-		s:  s,
+	if err != nil {
+		log.Err(err).Msgf("could not read acknowledged sequence number of trigger node %s of keygroup %s", id, kg)
+	}
+
+	w := &triggerWorker{
+		t:     t,
+		kg:    kg,
+		id:    id,
+		acked: acked,
+		wake:  make(chan struct{}, 1),
+		stop:  make(chan struct{}),
 	}
+
+	t.workers[k] = w
+
+	go w.run()
+
+	return w
 }
 
-func (t *triggerService) triggerDelete(i Item) (e error) {
-	log.Debug().Msgf("triggerDelete from triggerservice: in %#v", i)
+// stopWorker stops the worker for a trigger node, if there is one.
+func (t *triggerService) stopWorker(kg KeygroupName, id string) {
+	t.Lock()
+	defer t.Unlock()
 
-	nodes, err := t.s.getKeygroupTrigger(i.Keygroup)
+	k := triggerKey{kg: kg, id: id}
+
+	if w, ok := t.workers[k]; ok {
+		close(w.stop)
+		delete(t.workers, k)
+	}
+}
+
+// backoff returns how long to wait before the next attempt to deliver an event.
+func (t *triggerService) backoff(attempts int) time.Duration {
+	b := minTriggerBackoff
+
+	for i := 1; i < attempts && b < t.maxBackoff; i++ {
+		b *= 2
+	}
+
+	if b > t.maxBackoff {
+		b = t.maxBackoff
+	}
+
+	return b
+}
+
+// deliver sends a single event to its trigger node or webhook, or applies it to a computed keygroup. It returns the
+// high-water mark that the trigger node acknowledged, or 0 if it did not send one.
+func (t *triggerService) deliver(e TriggerEvent) (uint64, error) {
+	if e.Trigger.Compute.Target != "" {
+		return 0, t.deliverCompute(e)
+	}
+
+	if e.Trigger.Webhook.URL != "" {
+		return t.deliverWebhook(e)
+	}
+
+	client, release, err := t.p.get(e.Trigger.Host)
 
 	if err != nil {
-		return err
+		return 0, err
 	}
 
-	for _, node := range nodes {
-		client, conn := t.getConnAndClient(node.Host)
-		res, err := client.DeleteItemTrigger(context.Background(), &trigger.DeleteItemTriggerRequest{
-			Keygroup: string(i.Keygroup),
-			Id:       i.ID,
+	defer release()
+
+	ctx, cncl := context.WithTimeout(context.Background(), triggerTimeout)
+	defer cncl()
+
+	switch e.Op {
+	case TriggerPut, TriggerAppend:
+		res, err := client.PutItemTrigger(ctx, &trigger.PutItemTriggerRequest{
+			Keygroup:       string(e.Keygroup),
+			Id:             e.ID,
+			Val:            e.Val,
+			Operation:      string(e.Op),
+			Origin:         string(e.Origin),
+			Seq:            e.KeygroupSeq,
+			IdempotencyKey: e.IdempotencyKey,
+		})
+
+		return ackedSeq(res), dealWithStatusResponse(res, err, "TriggerUpdate")
+	case TriggerDelete, TriggerExpire:
+		res, err := client.DeleteItemTrigger(ctx, &trigger.DeleteItemTriggerRequest{
+			Keygroup:       string(e.Keygroup),
+			Id:             e.ID,
+			Operation:      string(e.Op),
+			Origin:         string(e.Origin),
+			Seq:            e.KeygroupSeq,
+			IdempotencyKey: e.IdempotencyKey,
 		})
 
-		err = dealWithStatusResponse(res, err, "TriggerDelete")
+		return ackedSeq(res), dealWithStatusResponse(res, err, "TriggerDelete")
+	case TriggerDeleteKeygroup:
+		res, err := client.DeleteKeygroupTrigger(ctx, &trigger.DeleteKeygroupTriggerRequest{
+			Keygroup:       string(e.Keygroup),
+			Origin:         string(e.Origin),
+			Seq:            e.KeygroupSeq,
+			IdempotencyKey: e.IdempotencyKey,
+		})
 
-		if err != nil {
-			e = errors.Errorf("%#v %#v", e, err)
+		// older trigger nodes do not know about deleted keygroups, that is fine
+		if status.Code(err) == codes.Unimplemented {
+			log.Debug().Msgf("trigger node %s does not implement DeleteKeygroupTrigger, skipping event %d", e.Trigger.ID, e.Seq)
+			return 0, nil
 		}
 
-		err = conn.Close()
+		return ackedSeq(res), dealWithStatusResponse(res, err, "TriggerDeleteKeygroup")
+	}
 
-		if err != nil {
-			e = errors.Errorf("%#v %#v", e, err)
+	return 0, errors.Errorf("unknown trigger operation %s", e.Op)
+}
+
+// errBatchUnimplemented is returned by deliverBatch if the trigger node does not implement BatchTrigger.
+var errBatchUnimplemented = errors.New("trigger node does not implement BatchTrigger")
+
+// deliverBatch sends several events to a trigger node at once. All events must be for the same trigger node. It
+// returns the high-water mark that the trigger node acknowledged, or 0 if it did not send one.
+func (t *triggerService) deliverBatch(events []TriggerEvent) (uint64, error) {
+	client, release, err := t.p.get(events[0].Trigger.Host)
+
+	if err != nil {
+		return 0, err
+	}
+
+	defer release()
+
+	req := &trigger.BatchTriggerRequest{
+		Events: make([]*trigger.TriggerEvent, len(events)),
+	}
+
+	for i, e := range events {
+		req.Events[i] = &trigger.TriggerEvent{
+			Keygroup:       string(e.Keygroup),
+			Id:             e.ID,
+			Val:            e.Val,
+			Operation:      string(e.Op),
+			Origin:         string(e.Origin),
+			Seq:            e.KeygroupSeq,
+			IdempotencyKey: e.IdempotencyKey,
 		}
 	}
 
-	return e
-}
+	ctx, cncl := context.WithTimeout(context.Background(), triggerTimeout)
+	defer cncl()
+
+	res, err := client.BatchTrigger(ctx, req)
 
-func (t *triggerService) triggerUpdate(i Item) (e error) {
-	log.Debug().Msgf("triggerUpdate from triggerservice: in %#v", i)
+	if status.Code(err) == codes.Unimplemented {
+		return 0, errBatchUnimplemented
+	}
+
+	return ackedSeq(res), dealWithStatusResponse(res, err, "TriggerBatch")
+}
 
+// enqueue queues an event for every trigger node of the item's keygroup whose filter matches the event. It returns
+// the number of trigger nodes of the keygroup, no matter whether they want the event.
+func (t *triggerService) enqueue(op TriggerOp, i Item, origin NodeID) (int, error) {
 	nodes, err := t.s.getKeygroupTrigger(i.Keygroup)
 
 	if err != nil {
-		return err
+		return 0, err
 	}
 
-	for _, node := range nodes {
-		client, conn := t.getConnAndClient(node.Host)
-		res, err := client.PutItemTrigger(context.Background(), &trigger.PutItemTriggerRequest{
-			Keygroup: string(i.Keygroup),
-			Id:       i.ID,
-			Val:      i.Val,
-		})
+	local := origin == t.node
+
+	t.order.Lock()
+	defer t.order.Unlock()
+
+	var kgSeq uint64
 
-		err = dealWithStatusResponse(res, err, "TriggerUpdate")
+	for _, node := range nodes {
+		m, err := node.Filter.compile()
 
 		if err != nil {
-			e = errors.Errorf("%#v %#v", e, err)
+			log.Err(err).Msgf("invalid filter for trigger node %s of keygroup %s, skipping it", node.ID, i.Keygroup)
+			continue
+		}
+
+		if !m.match(op, i.ID, i.Val, local) {
+			continue
+		}
+
+		// all trigger nodes get the same sequence number for the same change
+		if kgSeq == 0 {
+			kgSeq, err = t.q.nextKeygroupSeq(i.Keygroup)
+
+			if err != nil {
+				return 0, err
+			}
 		}
 
-		err = conn.Close()
+		e, err := t.q.push(TriggerEvent{
+			KeygroupSeq:    kgSeq,
+			IdempotencyKey: fmt.Sprintf("%s/%s/%d", t.node, i.Keygroup, kgSeq),
+			Keygroup:       i.Keygroup,
+			Origin:         origin,
+			Trigger:        node,
+			Op:             op,
+			ID:             i.ID,
+			Val:            i.Val,
+			Time:           time.Now(),
+		})
 
 		if err != nil {
-			e = errors.Errorf("%#v %#v", e, err)
+			return 0, err
 		}
+
+		t.worker(i.Keygroup, node.ID).add(e)
+	}
+
+	return len(nodes), nil
+}
+
+// trackExpiry remembers when an item expires, so that trigger nodes can be told about it. This is only necessary in
+// keygroups that have an expiry and trigger nodes.
+func (t *triggerService) trackExpiry(i Item, expiry int, nodes int) error {
+	if expiry <= 0 || nodes == 0 {
+		return nil
+	}
+
+	return t.x.track(i.Keygroup, i.ID, expiry)
+}
+
+func (t *triggerService) triggerDelete(i Item, origin NodeID) error {
+	log.Debug().Msgf("triggerDelete from triggerservice: in %#v %s", i, origin)
+
+	if err := t.x.untrack(i.Keygroup, i.ID); err != nil {
+		return err
+	}
+
+	_, err := t.enqueue(TriggerDelete, Item{Keygroup: i.Keygroup, ID: i.ID}, origin)
+
+	return err
+}
+
+func (t *triggerService) triggerUpdate(i Item, expiry int, origin NodeID) error {
+	log.Debug().Msgf("triggerUpdate from triggerservice: in %#v %s", i, origin)
+
+	n, err := t.enqueue(TriggerPut, i, origin)
+
+	if err != nil {
+		return err
+	}
+
+	return t.trackExpiry(i, expiry, n)
+}
+
+func (t *triggerService) triggerAppend(i Item, expiry int, origin NodeID) error {
+	log.Debug().Msgf("triggerAppend from triggerservice: in %#v %s", i, origin)
+
+	n, err := t.enqueue(TriggerAppend, i, origin)
+
+	if err != nil {
+		return err
+	}
+
+	return t.trackExpiry(i, expiry, n)
+}
+
+// triggerDeleteKeygroup informs trigger nodes that their keygroup is deleted. This has to be called before the
+// keygroup is deleted from the store, as the trigger nodes are deleted with it.
+func (t *triggerService) triggerDeleteKeygroup(kg KeygroupName, origin NodeID) error {
+	log.Debug().Msgf("triggerDeleteKeygroup from triggerservice: in %s %s", kg, origin)
+
+	if err := t.x.untrackKeygroup(kg); err != nil {
+		return err
 	}
 
-	return e
+	_, err := t.enqueue(TriggerDeleteKeygroup, Item{Keygroup: kg}, origin)
+
+	return err
 }
 
+// addTrigger adds a trigger to a keygroup or replaces the trigger with the same ID. A computed keygroup gets access to
+// its target keygroup and loses access to the target of the trigger it replaces.
 func (t *triggerService) addTrigger(k Keygroup, tn Trigger) error {
 	log.Debug().Msgf("addTrigger from triggerservice: in %#v %#v", k, tn)
 
+	old, err := t.findTrigger(k.Name, tn.ID)
+
+	if err != nil {
+		return err
+	}
+
+	if old != nil && old.Compute.Target != "" && old.Compute.Target != tn.Compute.Target {
+		if err := t.revokeCompute(k.Name, *old); err != nil {
+			return err
+		}
+	}
+
+	if tn.Compute.Target != "" {
+		if err := t.grantCompute(k.Name, tn); err != nil {
+			return err
+		}
+	}
+
 	return t.s.addKeygroupTrigger(k.Name, tn)
 }
 
+// findTrigger returns the trigger of a keygroup with an ID, or nil if there is none.
+func (t *triggerService) findTrigger(kg KeygroupName, id string) (*Trigger, error) {
+	nodes, err := t.s.getKeygroupTrigger(kg)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range nodes {
+		if nodes[i].ID == id {
+			return &nodes[i], nil
+		}
+	}
+
+	return nil, nil
+}
+
 func (t *triggerService) getTrigger(k Keygroup) ([]Trigger, error) {
 	log.Debug().Msgf("getTrigger from triggerservice: in %#v", k)
 
-	return t.s.getKeygroupTrigger(k.Name)
+	nodes, err := t.s.getKeygroupTrigger(k.Name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range nodes {
+		nodes[i].Stats = t.st.get(k.Name, nodes[i].ID)
+		nodes[i].Stats.Backlog = t.backlog(k.Name, nodes[i].ID)
+	}
+
+	return nodes, nil
+}
+
+// backlog returns the number of events that still need to be delivered to a trigger node.
+func (t *triggerService) backlog(kg KeygroupName, id string) int {
+	t.Lock()
+	w, ok := t.workers[triggerKey{kg: kg, id: id}]
+	t.Unlock()
+
+	if !ok {
+		return 0
+	}
+
+	w.Lock()
+	defer w.Unlock()
+
+	return len(w.events)
 }
 
 func (t *triggerService) removeTrigger(k Keygroup, tn Trigger) error {
 	log.Debug().Msgf("removeTrigger from triggerservice: in %#v %#v", k, tn)
 
-	return t.s.deleteKeygroupTrigger(k.Name, tn)
+	old, err := t.findTrigger(k.Name, tn.ID)
+
+	if err != nil {
+		return err
+	}
+
+	if err := t.s.deleteKeygroupTrigger(k.Name, tn); err != nil {
+		return err
+	}
+
+	// the computed keygroup stays as it is, but is no longer updated
+	if old != nil && old.Compute.Target != "" {
+		if err := t.revokeCompute(k.Name, *old); err != nil {
+			return err
+		}
+
+		if err := t.k.drop(k.Name, tn.ID); err != nil {
+			return err
+		}
+	}
+
+	// events for a removed trigger node can no longer be delivered
+	t.stopWorker(k.Name, tn.ID)
+
+	if err := t.st.remove(k.Name, tn.ID); err != nil {
+		return err
+	}
+
+	return t.q.drop(k.Name, tn.ID)
+}
+
+// getFailedEvents returns the events of a keygroup that could not be delivered. If id is set, only events for that
+// trigger node are returned.
+func (t *triggerService) getFailedEvents(k Keygroup, id string) ([]TriggerEvent, error) {
+	log.Debug().Msgf("getFailedEvents from triggerservice: in %#v %s", k, id)
+
+	events, err := t.q.dead(k.Name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if id == "" {
+		return events, nil
+	}
+
+	res := make([]TriggerEvent, 0, len(events))
+
+	for _, e := range events {
+		if e.Trigger.ID == id {
+			res = append(res, e)
+		}
+	}
+
+	return res, nil
+}
+
+// replay queues events that could not be delivered again. If seqs is empty, all failed events of the trigger node
+// are replayed. Events are sent to the current host of the trigger node, so that a trigger node can be moved.
+func (t *triggerService) replay(k Keygroup, id string, seqs []uint64) error {
+	log.Debug().Msgf("replay from triggerservice: in %#v %s %v", k, id, seqs)
+
+	node, err := t.findTrigger(k.Name, id)
+
+	if err != nil {
+		return err
+	}
+
+	if node == nil {
+		return errors.Errorf("no trigger node %s in keygroup %s", id, k.Name)
+	}
+
+	events, err := t.getFailedEvents(k, id)
+
+	if err != nil {
+		return err
+	}
+
+	selected := make(map[uint64]struct{}, len(seqs))
+
+	for _, s := range seqs {
+		selected[s] = struct{}{}
+	}
+
+	for _, e := range events {
+		if _, ok := selected[e.Seq]; len(seqs) > 0 && !ok {
+			continue
+		}
+
+		e.Trigger = *node
+
+		revived, err := t.q.revive(e)
+
+		if err != nil {
+			return err
+		}
+
+		t.worker(k.Name, id).add(revived)
+	}
+
+	return nil
+}
+
+// triggerWorker delivers the events for a single trigger node in order, several at once if the trigger node supports
+// it. Events up to the high-water mark that the trigger node has acknowledged are not delivered again.
+type triggerWorker struct {
+	t     *triggerService
+	kg    KeygroupName
+	id    string
+	acked uint64
+	unary bool
+	retry bool
+	sync.Mutex
+	events []TriggerEvent
+	wake   chan struct{}
+	stop   chan struct{}
+}
+
+func (w *triggerWorker) add(e TriggerEvent) {
+	w.Lock()
+	w.events = append(w.events, e)
+	w.Unlock()
+
+	select {
+	case w.wake <- struct{}{}:
+	default:
+	}
+}
+
+// next blocks until there is an event to deliver. It returns as many events from the front of the queue as can be
+// sent together, waiting up to the batch latency for more events if the batch is not full. It returns false if the
+// worker has been stopped.
+func (w *triggerWorker) next() ([]TriggerEvent, bool) {
+	for !w.pending(1) {
+		select {
+		case <-w.wake:
+		case <-w.stop:
+			return nil, false
+		}
+	}
+
+	w.Lock()
+	max := w.batchSize(w.events[0])
+	w.Unlock()
+
+	if max > 1 && w.t.batchLatency > 0 {
+		deadline := time.NewTimer(w.t.batchLatency)
+
+		for !w.pending(max) {
+			select {
+			case <-w.wake:
+				continue
+			case <-deadline.C:
+			case <-w.stop:
+				deadline.Stop()
+				return nil, false
+			}
+
+			break
+		}
+
+		deadline.Stop()
+	}
+
+	w.Lock()
+	defer w.Unlock()
+
+	head := w.events[0]
+	n := 1
+
+	// events that were queued for an older configuration of the trigger node or that will be skipped end the batch
+	for n < max && n < len(w.events) {
+		e := w.events[n]
+
+		if e.Trigger.Host != head.Trigger.Host || e.Trigger.Webhook != head.Trigger.Webhook || w.skip(e) {
+			break
+		}
+
+		n++
+	}
+
+	return append([]TriggerEvent{}, w.events[:n]...), true
+}
+
+// pending returns true if there are at least n events to deliver.
+func (w *triggerWorker) pending(n int) bool {
+	w.Lock()
+	defer w.Unlock()
+
+	return len(w.events) >= n
+}
+
+// skip returns true if the trigger node has already acknowledged an event. Replayed events are never skipped.
+func (w *triggerWorker) skip(e TriggerEvent) bool {
+	return e.KeygroupSeq > 0 && e.KeygroupSeq <= w.acked && !e.Replayed
+}
+
+// batchSize returns how many events to send at once to the trigger node. Webhooks, computed keygroups, and trigger
+// nodes that do not implement BatchTrigger get one event at a time, and so does a trigger node after a batch has
+// failed, so that a single broken event does not hold back the others.
+func (w *triggerWorker) batchSize(head TriggerEvent) int {
+	if w.unary || w.retry || head.Trigger.unbatched() {
+		return 1
+	}
+
+	return w.t.batchSize
+}
+
+// ack raises the high-water mark of the trigger node after an event has been delivered, either to the sequence number
+// of the event or to what the trigger node acknowledged, whichever is higher.
+func (w *triggerWorker) ack(seq uint64, acked uint64) {
+	if acked > seq {
+		seq = acked
+	}
+
+	if seq <= w.acked {
+		return
+	}
+
+	w.acked = seq
+
+	if err := w.t.q.ack(w.kg, w.id, seq); err != nil {
+		log.Err(err).Msgf("could not store acknowledged sequence number of trigger node %s of keygroup %s", w.id, w.kg)
+	}
+}
+
+// done removes the first n events from the worker, after they have been delivered or given up on.
+func (w *triggerWorker) done(n int) {
+	w.Lock()
+	w.events = w.events[n:]
+	w.Unlock()
+}
+
+// remove removes events that need not be delivered anymore from the queue and from the worker.
+func (w *triggerWorker) remove(events []TriggerEvent) {
+	for _, e := range events {
+		if err := w.t.q.remove(e); err != nil {
+			log.Err(err).Msgf("could not remove trigger event %d from queue", e.Seq)
+		}
+	}
+
+	w.done(len(events))
+}
+
+func (w *triggerWorker) run() {
+	for {
+		events, ok := w.next()
+
+		if !ok {
+			return
+		}
+
+		if w.skip(events[0]) {
+			log.Debug().Msgf("trigger node %s of keygroup %s has already acknowledged event %d, skipping it", w.id, w.kg, events[0].KeygroupSeq)
+			w.remove(events[:1])
+			continue
+		}
+
+		var acked uint64
+		var err error
+
+		start := time.Now()
+
+		if len(events) == 1 && (w.unary || events[0].Trigger.unbatched()) {
+			acked, err = w.t.deliver(events[0])
+		} else {
+			acked, err = w.t.deliverBatch(events)
+		}
+
+		// the trigger node has been removed while we were delivering, so its events are already gone
+		select {
+		case <-w.stop:
+			return
+		default:
+		}
+
+		if err == errBatchUnimplemented {
+			log.Info().Msgf("trigger node %s of keygroup %s does not implement BatchTrigger, sending events one by one", w.id, w.kg)
+			w.unary = true
+			continue
+		}
+
+		if err == nil {
+			w.t.st.success(w.kg, w.id, len(events), time.Since(start))
+			w.ack(events[len(events)-1].KeygroupSeq, acked)
+			w.remove(events)
+			w.retry = false
+			continue
+		}
+
+		w.t.st.failure(w.kg, w.id, err)
+
+		// the trigger node may have processed the first events of a failed batch
+		if acked > 0 {
+			w.ack(0, acked)
+
+			n := 0
+			for n < len(events) && w.skip(events[n]) {
+				n++
+			}
+
+			w.t.st.update(w.kg, w.id, func(s *triggerStats) {
+				s.Delivered += uint64(n)
+			})
+
+			w.remove(events[:n])
+			events = events[n:]
+
+			if len(events) == 0 {
+				continue
+			}
+		}
+
+		w.retry = len(events) > 1
+		e := events[0]
+
+		e.Attempts++
+		e.LastError = err.Error()
+
+		if e.Attempts >= w.t.maxAttempts {
+			log.Error().Msgf("giving up on trigger event %d for trigger node %s of keygroup %s after %d attempts: %s", e.Seq, e.Trigger.ID, e.Keygroup, e.Attempts, e.LastError)
+
+			if err := w.t.q.bury(e); err != nil {
+				log.Err(err).Msgf("could not move trigger event %d to dead letters", e.Seq)
+			}
+
+			w.t.st.dead(w.kg, w.id)
+
+			w.done(1)
+			continue
+		}
+
+		log.Warn().Msgf("could not deliver trigger event %d to trigger node %s of keygroup %s (attempt %d): %s", e.Seq, e.Trigger.ID, e.Keygroup, e.Attempts, e.LastError)
+
+		if err := w.t.q.update(e); err != nil {
+			log.Err(err).Msgf("could not update trigger event %d in queue", e.Seq)
+		}
+
+		w.Lock()
+		w.events[0] = e
+		w.Unlock()
+
+		select {
+		case <-time.After(w.t.backoff(e.Attempts)):
+		case <-w.stop:
+			return
+		}
+	}
 }
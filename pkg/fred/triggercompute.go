@@ -0,0 +1,482 @@
+package fred
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/rs/zerolog/log"
+)
+
+// computedKeysKeygroup remembers which item in the target keygroup of a computed keygroup belongs to which source item,
+// so that it can be removed when the source item is deleted or moves to another key.
+const computedKeysKeygroup = "_computedkeys"
+
+// Compute makes a trigger compute a keygroup from its own keygroup inside FReD, instead of sending events to a trigger
+// node. Every put or append in the source keygroup writes an item to the Target keygroup and every delete or expiry
+// removes it again.
+// Key is the template for the ID of the item in the target keygroup. "{id}" is replaced with the ID of the source item
+// and a JSON path in braces, e.g., "{$.user}", with that part of the source item's JSON value. An empty template is
+// "{id}". Fields project the value: every field of the new JSON value is set to the result of a JSON path on the source
+// value, fields that the source value does not have are left out. Without fields, the value is copied as is.
+// If several source items have the same key, the last write wins and deleting any of them removes the computed item.
+type Compute struct {
+	Target KeygroupName      `json:"target"`
+	Key    string            `json:"key,omitempty"`
+	Fields map[string]string `json:"fields,omitempty"`
+}
+
+// keyPart is either literal text of a key template, the source ID, or a JSON path.
+type keyPart struct {
+	lit  string
+	id   bool
+	path *valuePredicate
+}
+
+// computeMapping is a Compute that is ready to be applied.
+type computeMapping struct {
+	key    []keyPart
+	fields map[string]*valuePredicate
+	json   bool
+}
+
+// parsePath parses a JSON path without a comparison.
+func parsePath(s string) (*valuePredicate, error) {
+	p, err := parseValuePredicate(s)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if p.op != "" {
+		return nil, errors.Errorf("invalid JSON path %s: comparisons are not allowed", s)
+	}
+
+	return p, nil
+}
+
+// compile checks a Compute and prepares it for evaluation.
+func (c Compute) compile() (*computeMapping, error) {
+	m := &computeMapping{
+		fields: make(map[string]*valuePredicate, len(c.Fields)),
+		json:   len(c.Fields) > 0,
+	}
+
+	tmpl := c.Key
+
+	if tmpl == "" {
+		tmpl = "{id}"
+	}
+
+	for len(tmpl) > 0 {
+		start := strings.IndexByte(tmpl, '{')
+
+		if start == -1 {
+			m.key = append(m.key, keyPart{lit: tmpl})
+			break
+		}
+
+		if start > 0 {
+			m.key = append(m.key, keyPart{lit: tmpl[:start]})
+		}
+
+		end := strings.IndexByte(tmpl[start:], '}')
+
+		if end == -1 {
+			return nil, errors.Errorf("invalid key template %s: missing }", c.Key)
+		}
+
+		inner := tmpl[start+1 : start+end]
+		tmpl = tmpl[start+end+1:]
+
+		if inner == "id" {
+			m.key = append(m.key, keyPart{id: true})
+			continue
+		}
+
+		p, err := parsePath(inner)
+
+		if err != nil {
+			return nil, errors.Errorf("invalid key template %s: %v", c.Key, err)
+		}
+
+		m.key = append(m.key, keyPart{path: p})
+		m.json = true
+	}
+
+	for f, path := range c.Fields {
+		if f == "" {
+			return nil, errors.New("invalid field projection: empty field name")
+		}
+
+		p, err := parsePath(path)
+
+		if err != nil {
+			return nil, errors.Errorf("invalid field projection for %s: %v", f, err)
+		}
+
+		m.fields[f] = p
+	}
+
+	return m, nil
+}
+
+// keyString turns a part of a JSON value into a part of a key. Strings are used as they are, everything else as JSON.
+func keyString(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+
+	b, _ := json.Marshal(v)
+	return string(b)
+}
+
+// apply returns the key and value of the computed item for a source item.
+func (m *computeMapping) apply(id string, val string) (string, string, error) {
+	var v interface{}
+
+	if m.json {
+		if err := json.Unmarshal([]byte(val), &v); err != nil {
+			return "", "", errors.Errorf("value of item %s is not JSON: %v", id, err)
+		}
+	}
+
+	var key strings.Builder
+
+	for _, part := range m.key {
+		switch {
+		case part.id:
+			key.WriteString(id)
+		case part.path != nil:
+			x, ok := part.path.lookup(v)
+
+			if !ok || x == nil {
+				return "", "", errors.Errorf("value of item %s has nothing for its computed key", id)
+			}
+
+			key.WriteString(keyString(x))
+		default:
+			key.WriteString(part.lit)
+		}
+	}
+
+	if key.Len() == 0 {
+		return "", "", errors.Errorf("computed key for item %s is empty", id)
+	}
+
+	if len(m.fields) == 0 {
+		return key.String(), val, nil
+	}
+
+	projected := make(map[string]interface{}, len(m.fields))
+
+	for f, p := range m.fields {
+		if x, ok := p.lookup(v); ok {
+			projected[f] = x
+		}
+	}
+
+	b, err := json.Marshal(projected)
+
+	if err != nil {
+		return "", "", errors.New(err)
+	}
+
+	return key.String(), string(b), nil
+}
+
+// computeUser is the identity that a computed keygroup writes to its target keygroup with. It gets the WriteKeygroup
+// role on the target keygroup for as long as the trigger exists.
+func computeUser(kg KeygroupName, id string) string {
+	return "_compute/" + string(kg) + "/" + id
+}
+
+// computedKeys keeps the keys of computed items in the local store.
+type computedKeys struct {
+	store Store
+}
+
+func newComputedKeys(store Store) (*computedKeys, error) {
+	if !store.ExistsKeygroup(computedKeysKeygroup) {
+		if err := store.CreateKeygroup(computedKeysKeygroup); err != nil {
+			return nil, errors.New(err)
+		}
+	}
+
+	return &computedKeys{store: store}, nil
+}
+
+// prefix is the beginning of the IDs of all keys of a trigger.
+func (c *computedKeys) prefix(kg KeygroupName, trigger string) string {
+	return scopedID(kg, trigger) + "x"
+}
+
+// get returns the key of the computed item for a source item, if there is one.
+func (c *computedKeys) get(kg KeygroupName, trigger string, id string) (string, bool, error) {
+	cid := c.prefix(kg, trigger) + hex.EncodeToString([]byte(id))
+
+	if !c.store.Exists(computedKeysKeygroup, cid) {
+		return "", false, nil
+	}
+
+	key, err := c.store.Read(computedKeysKeygroup, cid)
+
+	if err != nil {
+		return "", false, errors.New(err)
+	}
+
+	return key, true, nil
+}
+
+func (c *computedKeys) set(kg KeygroupName, trigger string, id string, key string) error {
+	if err := c.store.Update(computedKeysKeygroup, c.prefix(kg, trigger)+hex.EncodeToString([]byte(id)), key, false, 0); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+func (c *computedKeys) remove(kg KeygroupName, trigger string, id string) error {
+	cid := c.prefix(kg, trigger) + hex.EncodeToString([]byte(id))
+
+	if !c.store.Exists(computedKeysKeygroup, cid) {
+		return nil
+	}
+
+	if err := c.store.Delete(computedKeysKeygroup, cid); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+// all returns the keys of all computed items of a trigger by the IDs of their source items.
+func (c *computedKeys) all(kg KeygroupName, trigger string) (map[string]string, error) {
+	stored, err := c.store.ReadAll(computedKeysKeygroup)
+
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	p := c.prefix(kg, trigger)
+	res := make(map[string]string)
+
+	for cid, key := range stored {
+		if !strings.HasPrefix(cid, p) {
+			continue
+		}
+
+		id, err := hex.DecodeString(cid[len(p):])
+
+		if err != nil {
+			return nil, errors.Errorf("malformed computed key %s: %v", cid, err)
+		}
+
+		res[string(id)] = key
+	}
+
+	return res, nil
+}
+
+// drop forgets about all computed items of a trigger.
+func (c *computedKeys) drop(kg KeygroupName, trigger string) error {
+	keys, err := c.all(kg, trigger)
+
+	if err != nil {
+		return err
+	}
+
+	for id := range keys {
+		if err := c.remove(kg, trigger, id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// setComputeHandler sets the handler that computed keygroups are written with and the authorization service that
+// grants them access to their target keygroups. Events for computed keygroups fail until this is set.
+func (t *triggerService) setComputeHandler(h ExtHandler, a *authService) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.h = h
+	t.a = a
+}
+
+func (t *triggerService) computeHandler() (ExtHandler, *authService) {
+	t.Lock()
+	defer t.Unlock()
+
+	return t.h, t.a
+}
+
+// checkCompute makes sure that a trigger of a keygroup can compute its target keygroup. The target has to be stored on
+// this node and computed keygroups must not form a cycle.
+func (t *triggerService) checkCompute(kg KeygroupName, tn Trigger) error {
+	if tn.Host != "" || tn.Webhook.URL != "" {
+		return errors.Errorf("trigger %s computes a keygroup and cannot have a host or webhook", tn.ID)
+	}
+
+	if _, err := tn.Compute.compile(); err != nil {
+		return err
+	}
+
+	if err := checkKeygroup(tn.Compute.Target); err != nil {
+		return err
+	}
+
+	if !t.s.iS.ExistsKeygroup(string(tn.Compute.Target)) {
+		return errors.Errorf("target keygroup %s is not stored on this node", tn.Compute.Target)
+	}
+
+	seen := map[KeygroupName]struct{}{}
+	next := []KeygroupName{tn.Compute.Target}
+
+	for len(next) > 0 {
+		k := next[0]
+		next = next[1:]
+
+		if k == kg {
+			return errors.Errorf("computing keygroup %s from %s would create a cycle", tn.Compute.Target, kg)
+		}
+
+		if _, ok := seen[k]; ok {
+			continue
+		}
+
+		seen[k] = struct{}{}
+
+		nodes, err := t.s.getKeygroupTrigger(k)
+
+		if err != nil {
+			return err
+		}
+
+		for _, n := range nodes {
+			if n.Compute.Target != "" {
+				next = append(next, n.Compute.Target)
+			}
+		}
+	}
+
+	return nil
+}
+
+// grantCompute gives the identity of a computed keygroup access to its target keygroup.
+func (t *triggerService) grantCompute(kg KeygroupName, tn Trigger) error {
+	_, a := t.computeHandler()
+
+	if a == nil {
+		return errors.New("computed keygroups are not available")
+	}
+
+	return a.addRoles(Subject{Name: computeUser(kg, tn.ID)}, []Role{WriteKeygroup}, tn.Compute.Target)
+}
+
+// revokeCompute takes the access to its target keygroup away from a computed keygroup.
+func (t *triggerService) revokeCompute(kg KeygroupName, tn Trigger) error {
+	_, a := t.computeHandler()
+
+	if a == nil {
+		return nil
+	}
+
+	return a.revokeRoles(Subject{Name: computeUser(kg, tn.ID)}, []Role{WriteKeygroup}, tn.Compute.Target)
+}
+
+// deliverCompute applies an event to the target keygroup of a computed keygroup.
+func (t *triggerService) deliverCompute(e TriggerEvent) error {
+	h, _ := t.computeHandler()
+
+	if h == nil {
+		return errors.New("computed keygroups are not available yet")
+	}
+
+	m, err := e.Trigger.Compute.compile()
+
+	if err != nil {
+		return err
+	}
+
+	user := computeUser(e.Keygroup, e.Trigger.ID)
+	target := e.Trigger.Compute.Target
+
+	switch e.Op {
+	case TriggerPut, TriggerAppend:
+		key, val, err := m.apply(e.ID, e.Val)
+
+		if err != nil {
+			return err
+		}
+
+		// the source item has moved to a new key, so the old computed item has to go
+		old, ok, err := t.k.get(e.Keygroup, e.Trigger.ID, e.ID)
+
+		if err != nil {
+			return err
+		}
+
+		if ok && old != key {
+			if err := t.removeComputed(h, user, target, old); err != nil {
+				return err
+			}
+		}
+
+		if err := h.HandleUpdate(user, Item{Keygroup: target, ID: key, Val: val}); err != nil {
+			return err
+		}
+
+		return t.k.set(e.Keygroup, e.Trigger.ID, e.ID, key)
+	case TriggerDelete, TriggerExpire:
+		key, ok, err := t.k.get(e.Keygroup, e.Trigger.ID, e.ID)
+
+		if err != nil || !ok {
+			return err
+		}
+
+		if err := t.removeComputed(h, user, target, key); err != nil {
+			return err
+		}
+
+		return t.k.remove(e.Keygroup, e.Trigger.ID, e.ID)
+	case TriggerDeleteKeygroup:
+		keys, err := t.k.all(e.Keygroup, e.Trigger.ID)
+
+		if err != nil {
+			return err
+		}
+
+		for id, key := range keys {
+			if err := t.removeComputed(h, user, target, key); err != nil {
+				return err
+			}
+
+			if err := t.k.remove(e.Keygroup, e.Trigger.ID, id); err != nil {
+				return err
+			}
+		}
+
+		// the trigger is gone with its keygroup, and so is its access to the target
+		if err := t.revokeCompute(e.Keygroup, e.Trigger); err != nil {
+			log.Err(err).Msgf("could not revoke access of computed keygroup %s of keygroup %s to %s", e.Trigger.ID, e.Keygroup, target)
+		}
+
+		return nil
+	}
+
+	return errors.Errorf("unknown trigger operation %s", e.Op)
+}
+
+// removeComputed deletes a computed item, if it still exists.
+func (t *triggerService) removeComputed(h ExtHandler, user string, target KeygroupName, key string) error {
+	i := Item{Keygroup: target, ID: key}
+
+	if !t.s.exists(i) {
+		return nil
+	}
+
+	return h.HandleDelete(user, i)
+}
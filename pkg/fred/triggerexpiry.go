@@ -0,0 +1,198 @@
+package fred
+
+import (
+	"container/heap"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// triggerExpiryKeygroup keeps track of items that expire in keygroups with triggers, as the store removes expired
+	// items on its own and does not tell us about it.
+	triggerExpiryKeygroup = "_triggerexpiry"
+	expiryCheckInterval   = 1 * time.Second
+)
+
+// expiryRecord is an item that is expected to expire at a certain time.
+type expiryRecord struct {
+	Keygroup KeygroupName `json:"keygroup"`
+	ID       string       `json:"id"`
+	Due      time.Time    `json:"due"`
+}
+
+// expiryHeap sorts records by their due time.
+type expiryHeap []expiryRecord
+
+func (h expiryHeap) Len() int            { return len(h) }
+func (h expiryHeap) Less(i, j int) bool  { return h[i].Due.Before(h[j].Due) }
+func (h expiryHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *expiryHeap) Push(x interface{}) { *h = append(*h, x.(expiryRecord)) }
+func (h *expiryHeap) Pop() interface{} {
+	old := *h
+	r := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return r
+}
+
+// expiryTracker checks when tracked items have expired and calls fire for each of them. Records are kept in the
+// store, so that items that expire while the node is down are reported after a restart. There is only one record per
+// item, so that updating an item replaces its record. The heap may contain stale entries for items that were updated
+// or deleted in the meantime, those are skipped by comparing them to the stored record.
+type expiryTracker struct {
+	store Store
+	fire  func(kg KeygroupName, id string)
+	sync.Mutex
+	h expiryHeap
+}
+
+func newExpiryTracker(store Store, fire func(kg KeygroupName, id string)) (*expiryTracker, error) {
+	if !store.ExistsKeygroup(triggerExpiryKeygroup) {
+		if err := store.CreateKeygroup(triggerExpiryKeygroup); err != nil {
+			return nil, errors.New(err)
+		}
+	}
+
+	records, err := store.ReadAll(triggerExpiryKeygroup)
+
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	e := &expiryTracker{
+		store: store,
+		fire:  fire,
+		h:     make(expiryHeap, 0, len(records)),
+	}
+
+	for id, val := range records {
+		var r expiryRecord
+
+		if err := json.Unmarshal([]byte(val), &r); err != nil {
+			return nil, errors.Errorf("malformed expiry record %s: %v", id, err)
+		}
+
+		e.h = append(e.h, r)
+	}
+
+	heap.Init(&e.h)
+
+	go e.run()
+
+	return e, nil
+}
+
+// track remembers that an item expires after the given number of seconds.
+func (e *expiryTracker) track(kg KeygroupName, id string, expiry int) error {
+	r := expiryRecord{
+		Keygroup: kg,
+		ID:       id,
+		Due:      time.Now().Add(time.Duration(expiry) * time.Second),
+	}
+
+	b, err := json.Marshal(r)
+
+	if err != nil {
+		return errors.New(err)
+	}
+
+	if err := e.store.Update(triggerExpiryKeygroup, scopedID(kg, id), string(b), false, 0); err != nil {
+		return errors.New(err)
+	}
+
+	e.Lock()
+	heap.Push(&e.h, r)
+	e.Unlock()
+
+	return nil
+}
+
+// untrack forgets about an item, e.g., because it has been deleted before it expired.
+func (e *expiryTracker) untrack(kg KeygroupName, id string) error {
+	if err := e.store.Delete(triggerExpiryKeygroup, scopedID(kg, id)); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+// untrackKeygroup forgets about all items of a keygroup.
+func (e *expiryTracker) untrackKeygroup(kg KeygroupName) error {
+	records, err := e.store.ReadAll(triggerExpiryKeygroup)
+
+	if err != nil {
+		return errors.New(err)
+	}
+
+	for id, val := range records {
+		var r expiryRecord
+
+		if err := json.Unmarshal([]byte(val), &r); err != nil || r.Keygroup != kg {
+			continue
+		}
+
+		if err := e.store.Delete(triggerExpiryKeygroup, id); err != nil {
+			return errors.New(err)
+		}
+	}
+
+	return nil
+}
+
+func (e *expiryTracker) run() {
+	t := time.NewTicker(expiryCheckInterval)
+	defer t.Stop()
+
+	for now := range t.C {
+		e.check(now)
+	}
+}
+
+// check fires for all items that are due and have actually been removed from the store.
+func (e *expiryTracker) check(now time.Time) {
+	e.Lock()
+	var due []expiryRecord
+	for e.h.Len() > 0 && !e.h[0].Due.After(now) {
+		due = append(due, heap.Pop(&e.h).(expiryRecord))
+	}
+	e.Unlock()
+
+	var later []expiryRecord
+
+	for _, r := range due {
+		val, err := e.store.Read(triggerExpiryKeygroup, scopedID(r.Keygroup, r.ID))
+
+		if err != nil {
+			// the item has been deleted or its keygroup is gone
+			continue
+		}
+
+		var stored expiryRecord
+
+		if err := json.Unmarshal([]byte(val), &stored); err != nil || !stored.Due.Equal(r.Due) {
+			// the item has been updated, there is another entry with the new due time
+			continue
+		}
+
+		if e.store.Exists(string(r.Keygroup), r.ID) {
+			// the store has not removed the item yet, check again later
+			later = append(later, r)
+			continue
+		}
+
+		if err := e.store.Delete(triggerExpiryKeygroup, scopedID(r.Keygroup, r.ID)); err != nil {
+			log.Err(err).Msgf("could not remove expiry record for item %s in keygroup %s", r.ID, r.Keygroup)
+		}
+
+		e.fire(r.Keygroup, r.ID)
+	}
+
+	e.Lock()
+	for _, r := range later {
+		heap.Push(&e.h, r)
+	}
+	e.Unlock()
+}
@@ -0,0 +1,322 @@
+package fred
+
+import (
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/go-errors/errors"
+)
+
+// TriggerOrigins selects events by where their change originated.
+type TriggerOrigins string
+
+// Trigger nodes can get events for all changes, only for changes by clients of this node, or only for changes that
+// were replicated from other nodes. Expiries count as local changes.
+const (
+	AllOrigins    TriggerOrigins = ""
+	LocalOrigins  TriggerOrigins = "local"
+	RemoteOrigins TriggerOrigins = "remote"
+)
+
+// TriggerFilter restricts which events are sent to a trigger node. Empty fields match everything, an event has to
+// match all fields that are set.
+// ValuePredicate is a JSON path on the item value, optionally followed by a comparison with a JSON literal, e.g.,
+// `$.sensor.temperature > 30`, `$.tags[0] == "door"`, or just `$.alarm`. A path without a comparison matches if it
+// exists and is neither null nor false. Items that are not JSON never match a predicate. Only puts and appends carry
+// a value, so the predicate is not checked for other events. Likewise, keygroup deletions are not checked against the
+// ID filters.
+type TriggerFilter struct {
+	IDPrefix       string         `json:"idPrefix,omitempty"`
+	IDRegex        string         `json:"idRegex,omitempty"`
+	Ops            []TriggerOp    `json:"ops,omitempty"`
+	ValuePredicate string         `json:"valuePredicate,omitempty"`
+	Origins        TriggerOrigins `json:"origins,omitempty"`
+}
+
+// IsEmpty returns true if the filter matches all events.
+func (f TriggerFilter) IsEmpty() bool {
+	return f.IDPrefix == "" && f.IDRegex == "" && len(f.Ops) == 0 && f.ValuePredicate == "" && f.Origins == AllOrigins
+}
+
+// triggerMatcher is a TriggerFilter that is ready to be evaluated.
+type triggerMatcher struct {
+	origins TriggerOrigins
+	prefix  string
+	re      *regexp.Regexp
+	ops     map[TriggerOp]struct{}
+	pred    *valuePredicate
+}
+
+// compile checks a filter and prepares it for evaluation.
+func (f TriggerFilter) compile() (*triggerMatcher, error) {
+	m := &triggerMatcher{
+		origins: f.Origins,
+		prefix:  f.IDPrefix,
+	}
+
+	switch f.Origins {
+	case AllOrigins, LocalOrigins, RemoteOrigins:
+	default:
+		return nil, errors.Errorf("invalid trigger origins %s", f.Origins)
+	}
+
+	if f.IDRegex != "" {
+		re, err := regexp.Compile(f.IDRegex)
+
+		if err != nil {
+			return nil, errors.Errorf("invalid ID regex %s: %v", f.IDRegex, err)
+		}
+
+		m.re = re
+	}
+
+	if len(f.Ops) > 0 {
+		m.ops = make(map[TriggerOp]struct{}, len(f.Ops))
+
+		for _, op := range f.Ops {
+			switch op {
+			case TriggerPut, TriggerAppend, TriggerDelete, TriggerExpire, TriggerDeleteKeygroup:
+				m.ops[op] = struct{}{}
+			default:
+				return nil, errors.Errorf("invalid trigger operation %s", op)
+			}
+		}
+	}
+
+	if f.ValuePredicate != "" {
+		p, err := parseValuePredicate(f.ValuePredicate)
+
+		if err != nil {
+			return nil, err
+		}
+
+		m.pred = p
+	}
+
+	return m, nil
+}
+
+// match checks whether an event should be sent to the trigger node. local is true if the change originated on this
+// node.
+func (m *triggerMatcher) match(op TriggerOp, id string, val string, local bool) bool {
+	if (m.origins == LocalOrigins && !local) || (m.origins == RemoteOrigins && local) {
+		return false
+	}
+
+	if m.ops != nil {
+		if _, ok := m.ops[op]; !ok {
+			return false
+		}
+	}
+
+	if op == TriggerDeleteKeygroup {
+		return true
+	}
+
+	if !strings.HasPrefix(id, m.prefix) {
+		return false
+	}
+
+	if m.re != nil && !m.re.MatchString(id) {
+		return false
+	}
+
+	if m.pred != nil && (op == TriggerPut || op == TriggerAppend) {
+		return m.pred.match(val)
+	}
+
+	return true
+}
+
+// pathStep is either a key of an object or an index of an array.
+type pathStep struct {
+	key   string
+	index int
+	isKey bool
+}
+
+type valuePredicate struct {
+	path    []pathStep
+	op      string
+	operand interface{}
+}
+
+// comparisons are ordered so that longer operators are found first.
+var comparisons = []string{"==", "!=", "<=", ">=", "<", ">"}
+
+func parseValuePredicate(s string) (*valuePredicate, error) {
+	s = strings.TrimSpace(s)
+
+	if !strings.HasPrefix(s, "$") {
+		return nil, errors.Errorf("invalid value predicate %s: path must start with $", s)
+	}
+
+	p := &valuePredicate{}
+	rest := s[1:]
+
+	for len(rest) > 0 && (rest[0] == '.' || rest[0] == '[') {
+		if rest[0] == '.' {
+			end := strings.IndexAny(rest[1:], ".[ =!<>")
+
+			if end == -1 {
+				end = len(rest) - 1
+			}
+
+			if end == 0 {
+				return nil, errors.Errorf("invalid value predicate %s: empty key in path", s)
+			}
+
+			p.path = append(p.path, pathStep{key: rest[1 : end+1], isKey: true})
+			rest = rest[end+1:]
+			continue
+		}
+
+		end := strings.IndexByte(rest, ']')
+
+		if end == -1 {
+			return nil, errors.Errorf("invalid value predicate %s: missing ]", s)
+		}
+
+		inner := rest[1:end]
+		rest = rest[end+1:]
+
+		if len(inner) >= 2 && (inner[0] == '\'' || inner[0] == '"') && inner[len(inner)-1] == inner[0] {
+			p.path = append(p.path, pathStep{key: inner[1 : len(inner)-1], isKey: true})
+			continue
+		}
+
+		i, err := strconv.Atoi(inner)
+
+		if err != nil || i < 0 {
+			return nil, errors.Errorf("invalid value predicate %s: invalid index %s", s, inner)
+		}
+
+		p.path = append(p.path, pathStep{index: i})
+	}
+
+	rest = strings.TrimSpace(rest)
+
+	if rest == "" {
+		return p, nil
+	}
+
+	for _, c := range comparisons {
+		if !strings.HasPrefix(rest, c) {
+			continue
+		}
+
+		if err := json.Unmarshal([]byte(strings.TrimSpace(rest[len(c):])), &p.operand); err != nil {
+			return nil, errors.Errorf("invalid value predicate %s: operand is not a JSON literal: %v", s, err)
+		}
+
+		p.op = c
+		return p, nil
+	}
+
+	return nil, errors.Errorf("invalid value predicate %s: unknown comparison %s", s, rest)
+}
+
+// lookup follows the path in a decoded JSON value.
+func (p *valuePredicate) lookup(v interface{}) (interface{}, bool) {
+	for _, step := range p.path {
+		if step.isKey {
+			o, ok := v.(map[string]interface{})
+
+			if !ok {
+				return nil, false
+			}
+
+			if v, ok = o[step.key]; !ok {
+				return nil, false
+			}
+
+			continue
+		}
+
+		a, ok := v.([]interface{})
+
+		if !ok || step.index >= len(a) {
+			return nil, false
+		}
+
+		v = a[step.index]
+	}
+
+	return v, true
+}
+
+func (p *valuePredicate) match(val string) bool {
+	var v interface{}
+
+	if err := json.Unmarshal([]byte(val), &v); err != nil {
+		return false
+	}
+
+	v, ok := p.lookup(v)
+
+	if !ok {
+		return false
+	}
+
+	switch p.op {
+	case "":
+		return v != nil && v != false
+	case "==":
+		return reflect.DeepEqual(v, p.operand)
+	case "!=":
+		return !reflect.DeepEqual(v, p.operand)
+	}
+
+	c, ok := compare(v, p.operand)
+
+	if !ok {
+		return false
+	}
+
+	switch p.op {
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	case ">":
+		return c > 0
+	case ">=":
+		return c >= 0
+	}
+
+	return false
+}
+
+// compare orders two numbers or two strings. Other values cannot be ordered.
+func compare(a, b interface{}) (int, bool) {
+	switch x := a.(type) {
+	case float64:
+		y, ok := b.(float64)
+
+		if !ok {
+			return 0, false
+		}
+
+		switch {
+		case x < y:
+			return -1, true
+		case x > y:
+			return 1, true
+		}
+
+		return 0, true
+	case string:
+		y, ok := b.(string)
+
+		if !ok {
+			return 0, false
+		}
+
+		return strings.Compare(x, y), true
+	}
+
+	return 0, false
+}
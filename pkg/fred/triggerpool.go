@@ -0,0 +1,155 @@
+package fred
+
+import (
+	"sync"
+	"time"
+
+	"git.tu-berlin.de/mcc-fred/fred/proto/trigger"
+	"github.com/go-errors/errors"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials"
+)
+
+// These are the defaults for the trigger connection pool.
+const (
+	defaultTriggerIdleTimeout = 5 * time.Minute
+	triggerPoolCheckInterval  = 10 * time.Second
+)
+
+// triggerConn is a connection to a trigger node in the pool. Connections that are in use are never closed.
+type triggerConn struct {
+	conn     *grpc.ClientConn
+	client   trigger.TriggerNodeClient
+	inUse    int
+	lastUsed time.Time
+}
+
+// triggerPool keeps one long-lived connection to every trigger node host that all trigger operations share. gRPC
+// reconnects broken connections on its own, but as that can take a while, connections that have failed are replaced
+// when they are needed again. Connections that have not been used for the idle timeout are closed.
+type triggerPool struct {
+	tc          credentials.TransportCredentials
+	idleTimeout time.Duration
+	sync.Mutex
+	conns map[string]*triggerConn
+}
+
+func newTriggerPool(tc credentials.TransportCredentials, idleTimeout time.Duration) *triggerPool {
+	if idleTimeout <= 0 {
+		idleTimeout = defaultTriggerIdleTimeout
+	}
+
+	p := &triggerPool{
+		tc:          tc,
+		idleTimeout: idleTimeout,
+		conns:       make(map[string]*triggerConn),
+	}
+
+	go p.run()
+
+	return p
+}
+
+// healthy returns false if a connection has failed or has been shut down.
+func healthy(conn *grpc.ClientConn) bool {
+	s := conn.GetState()
+	return s != connectivity.TransientFailure && s != connectivity.Shutdown
+}
+
+// get returns a client for a trigger node host, with a function that must be called when the client is no longer
+// needed.
+func (p *triggerPool) get(host string) (trigger.TriggerNodeClient, func(), error) {
+	p.Lock()
+	defer p.Unlock()
+
+	c, ok := p.conns[host]
+
+	// a failed connection that is still in use is closed once it is released
+	if ok && !healthy(c.conn) {
+		log.Debug().Msgf("replacing failed connection to trigger node %s", host)
+		delete(p.conns, host)
+
+		if c.inUse == 0 {
+			p.close(host, c)
+		}
+
+		ok = false
+	}
+
+	if !ok {
+		conn, err := grpc.Dial(host, grpc.WithTransportCredentials(p.tc))
+
+		if err != nil {
+			return nil, nil, errors.Errorf("cannot create grpc connection to trigger node %s: %v", host, err)
+		}
+
+		log.Debug().Msgf("Interclient: Created Connection to %s", host)
+
+		c = &triggerConn{
+			conn:   conn,
+			client: trigger.NewTriggerNodeClient(conn),
+		}
+
+		p.conns[host] = c
+	}
+
+	c.inUse++
+	c.lastUsed = time.Now()
+
+	return c.client, func() {
+		p.Lock()
+		defer p.Unlock()
+
+		c.inUse--
+		c.lastUsed = time.Now()
+
+		if c.inUse == 0 && p.conns[host] != c {
+			p.close(host, c)
+		}
+	}, nil
+}
+
+// close closes a connection and removes it from the pool. The pool must be locked.
+func (p *triggerPool) close(host string, c *triggerConn) {
+	if err := c.conn.Close(); err != nil {
+		log.Err(err).Msgf("could not close connection to trigger node %s", host)
+	}
+
+	if p.conns[host] == c {
+		delete(p.conns, host)
+	}
+}
+
+func (p *triggerPool) run() {
+	t := time.NewTicker(triggerPoolCheckInterval)
+	defer t.Stop()
+
+	for now := range t.C {
+		p.check(now)
+	}
+}
+
+// check closes all connections that are not in use and have either been idle for too long or have failed.
+func (p *triggerPool) check(now time.Time) {
+	p.Lock()
+	defer p.Unlock()
+
+	for host, c := range p.conns {
+		if c.inUse > 0 {
+			continue
+		}
+
+		if now.Sub(c.lastUsed) >= p.idleTimeout {
+			log.Debug().Msgf("closing idle connection to trigger node %s", host)
+			p.close(host, c)
+			continue
+		}
+
+		if !healthy(c.conn) {
+			log.Debug().Msgf("closing failed connection to trigger node %s", host)
+			p.close(host, c)
+		}
+	}
+}
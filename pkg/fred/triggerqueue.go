@@ -0,0 +1,295 @@
+package fred
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-errors/errors"
+)
+
+// These are the internal keygroups that trigger events are stored in. Their names are not valid keygroup names for
+// clients, so they can neither create nor read them through the API.
+const (
+	triggerQueueKeygroup = "_triggerqueue"
+	triggerDeadKeygroup  = "_triggerdead"
+	triggerSeqKeygroup   = "_triggerseq"
+	triggerAckKeygroup   = "_triggerack"
+)
+
+// triggerQueue persists trigger events in the local store until they are delivered, so that no events are lost if a
+// trigger node is unavailable or the FReD node restarts. Events that could not be delivered at all end up in a
+// dead-letter keygroup, from where they can be replayed.
+// Events are stored with their sequence number as ID, so that sorting IDs as strings also sorts them by time.
+// Besides that, the queue keeps the last sequence number of every keygroup that trigger nodes see, and the high-water
+// marks that trigger nodes have acknowledged.
+type triggerQueue struct {
+	store Store
+	sync.Mutex
+	last  uint64
+	kgSeq map[KeygroupName]uint64
+}
+
+func newTriggerQueue(store Store) (*triggerQueue, error) {
+	for _, kg := range []string{triggerQueueKeygroup, triggerDeadKeygroup, triggerSeqKeygroup, triggerAckKeygroup} {
+		if store.ExistsKeygroup(kg) {
+			continue
+		}
+
+		if err := store.CreateKeygroup(kg); err != nil {
+			return nil, errors.New(err)
+		}
+	}
+
+	return &triggerQueue{
+		store: store,
+		kgSeq: make(map[KeygroupName]uint64),
+	}, nil
+}
+
+// readUint reads a number from one of the internal keygroups. A missing number is 0.
+func (q *triggerQueue) readUint(kg string, id string) (uint64, error) {
+	if !q.store.Exists(kg, id) {
+		return 0, nil
+	}
+
+	val, err := q.store.Read(kg, id)
+
+	if err != nil {
+		return 0, errors.New(err)
+	}
+
+	n, err := strconv.ParseUint(val, 10, 64)
+
+	if err != nil {
+		return 0, errors.Errorf("malformed number %s in %s: %v", id, kg, err)
+	}
+
+	return n, nil
+}
+
+func (q *triggerQueue) writeUint(kg string, id string, n uint64) error {
+	if err := q.store.Update(kg, id, strconv.FormatUint(n, 10), false, 0); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+// nextKeygroupSeq returns the next sequence number of a keygroup. Sequence numbers are kept when a keygroup is
+// deleted, so that they still increase if a keygroup with the same name is created again.
+func (q *triggerQueue) nextKeygroupSeq(kg KeygroupName) (uint64, error) {
+	q.Lock()
+	defer q.Unlock()
+
+	id := hex.EncodeToString([]byte(kg))
+
+	last, ok := q.kgSeq[kg]
+
+	if !ok {
+		var err error
+		last, err = q.readUint(triggerSeqKeygroup, id)
+
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if err := q.writeUint(triggerSeqKeygroup, id, last+1); err != nil {
+		return 0, err
+	}
+
+	q.kgSeq[kg] = last + 1
+
+	return last + 1, nil
+}
+
+// scopedID returns an ID for something that belongs to a keygroup, such as an item or a trigger node. Keygroup and ID
+// are hex encoded to keep them apart.
+func scopedID(kg KeygroupName, id string) string {
+	return hex.EncodeToString([]byte(kg)) + "x" + hex.EncodeToString([]byte(id))
+}
+
+// acked returns the sequence number up to which a trigger node has processed all events of its keygroup.
+func (q *triggerQueue) acked(kg KeygroupName, id string) (uint64, error) {
+	return q.readUint(triggerAckKeygroup, scopedID(kg, id))
+}
+
+// ack stores the high-water mark of a trigger node.
+func (q *triggerQueue) ack(kg KeygroupName, id string, seq uint64) error {
+	return q.writeUint(triggerAckKeygroup, scopedID(kg, id), seq)
+}
+
+// nextSeq returns a unique, increasing sequence number based on the current time.
+func (q *triggerQueue) nextSeq() uint64 {
+	q.Lock()
+	defer q.Unlock()
+
+	s := uint64(time.Now().UnixNano())
+
+	if s <= q.last {
+		s = q.last + 1
+	}
+
+	q.last = s
+
+	return s
+}
+
+func seqID(seq uint64) string {
+	return fmt.Sprintf("%020d", seq)
+}
+
+func (q *triggerQueue) put(kg string, e TriggerEvent) error {
+	b, err := json.Marshal(e)
+
+	if err != nil {
+		return errors.New(err)
+	}
+
+	if err := q.store.Update(kg, seqID(e.Seq), string(b), false, 0); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+func (q *triggerQueue) delete(kg string, seq uint64) error {
+	if err := q.store.Delete(kg, seqID(seq)); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+// push adds an event to the end of the queue and returns it with its sequence number.
+func (q *triggerQueue) push(e TriggerEvent) (TriggerEvent, error) {
+	e.Seq = q.nextSeq()
+	return e, q.put(triggerQueueKeygroup, e)
+}
+
+// update stores changes to an event that is still in the queue, e.g., the number of attempts.
+func (q *triggerQueue) update(e TriggerEvent) error {
+	return q.put(triggerQueueKeygroup, e)
+}
+
+// remove removes an event from the queue after it has been delivered.
+func (q *triggerQueue) remove(e TriggerEvent) error {
+	return q.delete(triggerQueueKeygroup, e.Seq)
+}
+
+// bury moves an event from the queue to the dead letters.
+func (q *triggerQueue) bury(e TriggerEvent) error {
+	if err := q.put(triggerDeadKeygroup, e); err != nil {
+		return err
+	}
+
+	return q.delete(triggerQueueKeygroup, e.Seq)
+}
+
+// revive moves an event from the dead letters back to the end of the queue, with a new sequence number. It keeps its
+// keygroup sequence number and idempotency key, as it is still the same event for the trigger node.
+func (q *triggerQueue) revive(e TriggerEvent) (TriggerEvent, error) {
+	dead := e.Seq
+
+	e.Attempts = 0
+	e.LastError = ""
+	e.Replayed = true
+
+	e, err := q.push(e)
+
+	if err != nil {
+		return e, err
+	}
+
+	return e, q.delete(triggerDeadKeygroup, dead)
+}
+
+// read returns all events in the queue or in the dead letters, sorted by sequence number.
+func (q *triggerQueue) read(kg string) ([]TriggerEvent, error) {
+	items, err := q.store.ReadAll(kg)
+
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	events := make([]TriggerEvent, 0, len(items))
+
+	for id, val := range items {
+		var e TriggerEvent
+
+		if err := json.Unmarshal([]byte(val), &e); err != nil {
+			return nil, errors.Errorf("malformed trigger event %s: %v", id, err)
+		}
+
+		events = append(events, e)
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].Seq < events[j].Seq
+	})
+
+	q.Lock()
+	if len(events) > 0 && events[len(events)-1].Seq > q.last {
+		q.last = events[len(events)-1].Seq
+	}
+	q.Unlock()
+
+	return events, nil
+}
+
+// pending returns all events that still need to be delivered.
+func (q *triggerQueue) pending() ([]TriggerEvent, error) {
+	return q.read(triggerQueueKeygroup)
+}
+
+// dead returns all events of a keygroup that could not be delivered.
+func (q *triggerQueue) dead(kg KeygroupName) ([]TriggerEvent, error) {
+	events, err := q.read(triggerDeadKeygroup)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]TriggerEvent, 0)
+
+	for _, e := range events {
+		if e.Keygroup == kg {
+			res = append(res, e)
+		}
+	}
+
+	return res, nil
+}
+
+// drop removes all queued events and the high-water mark of a trigger node, e.g., because it has been removed from its
+// keygroup.
+func (q *triggerQueue) drop(kg KeygroupName, id string) error {
+	if q.store.Exists(triggerAckKeygroup, scopedID(kg, id)) {
+		if err := q.store.Delete(triggerAckKeygroup, scopedID(kg, id)); err != nil {
+			return errors.New(err)
+		}
+	}
+
+	events, err := q.pending()
+
+	if err != nil {
+		return err
+	}
+
+	for _, e := range events {
+		if e.Keygroup != kg || e.Trigger.ID != id {
+			continue
+		}
+
+		if err := q.remove(e); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
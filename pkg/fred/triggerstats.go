@@ -0,0 +1,212 @@
+package fred
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// triggerStatsKeygroup keeps the delivery statistics of all trigger nodes, so that they survive a restart.
+	triggerStatsKeygroup = "_triggerstats"
+	statsFlushInterval   = 1 * time.Second
+)
+
+// TriggerStats shows how well events are delivered to a trigger node. Delivered and Dead count events, Failed counts
+// failed attempts to deliver events. Backlog is the number of events that are waiting to be delivered and AvgLatency
+// is the average time the trigger node takes to accept a delivery.
+type TriggerStats struct {
+	Delivered   uint64
+	Failed      uint64
+	Dead        uint64
+	LastSuccess time.Time
+	LastFailure time.Time
+	LastError   string
+	Backlog     int
+	AvgLatency  time.Duration
+}
+
+// triggerStats is how the statistics of a trigger node are kept in the store. The latency is the total time of all
+// successful deliveries, of which there have been Calls.
+type triggerStats struct {
+	Keygroup    KeygroupName  `json:"keygroup"`
+	ID          string        `json:"id"`
+	Delivered   uint64        `json:"delivered"`
+	Failed      uint64        `json:"failed"`
+	Dead        uint64        `json:"dead"`
+	LastSuccess time.Time     `json:"lastSuccess"`
+	LastFailure time.Time     `json:"lastFailure"`
+	LastError   string        `json:"lastError,omitempty"`
+	Calls       uint64        `json:"calls"`
+	Latency     time.Duration `json:"latency"`
+}
+
+// statsKeeper collects the statistics of all trigger nodes in memory and writes changed statistics to the store in
+// the background, so that counting does not slow down the delivery of events.
+type statsKeeper struct {
+	store Store
+	sync.Mutex
+	stats map[triggerKey]*triggerStats
+	dirty map[triggerKey]struct{}
+}
+
+func newStatsKeeper(store Store) (*statsKeeper, error) {
+	if !store.ExistsKeygroup(triggerStatsKeygroup) {
+		if err := store.CreateKeygroup(triggerStatsKeygroup); err != nil {
+			return nil, errors.New(err)
+		}
+	}
+
+	stored, err := store.ReadAll(triggerStatsKeygroup)
+
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	k := &statsKeeper{
+		store: store,
+		stats: make(map[triggerKey]*triggerStats, len(stored)),
+		dirty: make(map[triggerKey]struct{}),
+	}
+
+	for id, val := range stored {
+		s := &triggerStats{}
+
+		if err := json.Unmarshal([]byte(val), s); err != nil {
+			return nil, errors.Errorf("malformed trigger statistics %s: %v", id, err)
+		}
+
+		k.stats[triggerKey{kg: s.Keygroup, id: s.ID}] = s
+	}
+
+	go k.run()
+
+	return k, nil
+}
+
+// update changes the statistics of a trigger node.
+func (k *statsKeeper) update(kg KeygroupName, id string, f func(s *triggerStats)) {
+	k.Lock()
+	defer k.Unlock()
+
+	key := triggerKey{kg: kg, id: id}
+
+	s, ok := k.stats[key]
+
+	if !ok {
+		s = &triggerStats{Keygroup: kg, ID: id}
+		k.stats[key] = s
+	}
+
+	f(s)
+
+	k.dirty[key] = struct{}{}
+}
+
+// success counts events that have been delivered in a single call.
+func (k *statsKeeper) success(kg KeygroupName, id string, events int, latency time.Duration) {
+	k.update(kg, id, func(s *triggerStats) {
+		s.Delivered += uint64(events)
+		s.LastSuccess = time.Now()
+		s.Calls++
+		s.Latency += latency
+	})
+}
+
+// failure counts a failed attempt to deliver events.
+func (k *statsKeeper) failure(kg KeygroupName, id string, err error) {
+	k.update(kg, id, func(s *triggerStats) {
+		s.Failed++
+		s.LastFailure = time.Now()
+		s.LastError = err.Error()
+	})
+}
+
+// dead counts an event that has been given up on.
+func (k *statsKeeper) dead(kg KeygroupName, id string) {
+	k.update(kg, id, func(s *triggerStats) {
+		s.Dead++
+	})
+}
+
+// get returns the statistics of a trigger node, without the backlog.
+func (k *statsKeeper) get(kg KeygroupName, id string) TriggerStats {
+	k.Lock()
+	defer k.Unlock()
+
+	s, ok := k.stats[triggerKey{kg: kg, id: id}]
+
+	if !ok {
+		return TriggerStats{}
+	}
+
+	res := TriggerStats{
+		Delivered:   s.Delivered,
+		Failed:      s.Failed,
+		Dead:        s.Dead,
+		LastSuccess: s.LastSuccess,
+		LastFailure: s.LastFailure,
+		LastError:   s.LastError,
+	}
+
+	if s.Calls > 0 {
+		res.AvgLatency = s.Latency / time.Duration(s.Calls)
+	}
+
+	return res
+}
+
+// remove forgets about the statistics of a trigger node, e.g., because it has been removed from its keygroup.
+func (k *statsKeeper) remove(kg KeygroupName, id string) error {
+	k.Lock()
+	defer k.Unlock()
+
+	key := triggerKey{kg: kg, id: id}
+
+	delete(k.stats, key)
+	delete(k.dirty, key)
+
+	if !k.store.Exists(triggerStatsKeygroup, scopedID(kg, id)) {
+		return nil
+	}
+
+	if err := k.store.Delete(triggerStatsKeygroup, scopedID(kg, id)); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+func (k *statsKeeper) run() {
+	t := time.NewTicker(statsFlushInterval)
+	defer t.Stop()
+
+	for range t.C {
+		k.flush()
+	}
+}
+
+// flush writes all statistics that have changed to the store.
+func (k *statsKeeper) flush() {
+	k.Lock()
+	defer k.Unlock()
+
+	for key := range k.dirty {
+		b, err := json.Marshal(k.stats[key])
+
+		if err != nil {
+			log.Err(err).Msgf("could not encode statistics of trigger node %s of keygroup %s", key.id, key.kg)
+			continue
+		}
+
+		if err := k.store.Update(triggerStatsKeygroup, scopedID(key.kg, key.id), string(b), false, 0); err != nil {
+			log.Err(err).Msgf("could not store statistics of trigger node %s of keygroup %s", key.id, key.kg)
+			continue
+		}
+
+		delete(k.dirty, key)
+	}
+}
@@ -1,22 +1,39 @@
 package fred
 
 import (
+	"path"
 	"regexp"
+	"unicode/utf8"
 
 	"github.com/go-errors/errors"
 )
 
-var expr = "^[a-zA-Z0-9]+$"
+// Keygroup names start with a letter or digit, so that they never clash with internal keygroups, and may not contain
+// "|" or "*". IDs can be any UTF-8 string, as stores encode their keys.
+var expr = "^[a-zA-Z0-9][a-zA-Z0-9._-]*$"
 var reg = regexp.MustCompile(expr)
 
+const (
+	maxKeygroupLength = 255
+	maxIDLength       = 1024
+)
+
+func validKeygroup(kg KeygroupName) bool {
+	return len(kg) <= maxKeygroupLength && reg.MatchString(string(kg))
+}
+
+func validID(id string) bool {
+	return id != "" && len(id) <= maxIDLength && utf8.ValidString(id)
+}
+
 func checkItem(params ...Item) error {
 	for _, p := range params {
-		if !reg.MatchString(string(p.Keygroup)) {
-			return errors.Errorf("checkItem failed for item %#v because the keygroup name does not match %s", p, expr)
+		if !validKeygroup(p.Keygroup) {
+			return errors.Errorf("checkItem failed for item %#v because the keygroup name does not match %s or is longer than %d characters", p, expr, maxKeygroupLength)
 		}
 
-		if !reg.MatchString(p.ID) {
-			return errors.Errorf("checkItem failed for item %#v because the ID does not match %s", p, expr)
+		if !validID(p.ID) {
+			return errors.Errorf("checkItem failed for item %#v because the ID is empty, not valid UTF-8 or longer than %d bytes", p, maxIDLength)
 		}
 	}
 
@@ -25,8 +42,8 @@ func checkItem(params ...Item) error {
 
 func checkKeygroup(params ...KeygroupName) error {
 	for _, p := range params {
-		if !reg.MatchString(string(p)) {
-			return errors.Errorf("checkKeygroup failed for keygroup %s because the keygroup name does not match %s", p, expr)
+		if !validKeygroup(p) {
+			return errors.Errorf("checkKeygroup failed for keygroup %s because the keygroup name does not match %s or is longer than %d characters", p, expr, maxKeygroupLength)
 		}
 	}
 
@@ -35,8 +52,8 @@ func checkKeygroup(params ...KeygroupName) error {
 
 func checkID(params ...string) error {
 	for _, p := range params {
-		if !reg.MatchString(p) {
-			return errors.Errorf("checkID failed for item %s because the id does not match %s", p, expr)
+		if !validID(p) {
+			return errors.Errorf("checkID failed for item %q because the id is empty, not valid UTF-8 or longer than %d bytes", p, maxIDLength)
 		}
 	}
 
@@ -51,3 +68,39 @@ func checkKGandID(kg KeygroupName, id string) error {
 	}
 	return err
 }
+
+var patternExpr = "^[a-zA-Z0-9*._-]+$"
+var patternReg = regexp.MustCompile(patternExpr)
+
+var nameExpr = "^[a-zA-Z0-9_-]+$"
+var nameReg = regexp.MustCompile(nameExpr)
+
+// checkKeygroupPattern checks a keygroup pattern that may contain "*" wildcards.
+func checkKeygroupPattern(params ...KeygroupName) error {
+	for _, p := range params {
+		if !patternReg.MatchString(string(p)) {
+			return errors.Errorf("checkKeygroupPattern failed for pattern %s because it does not match %s", p, patternExpr)
+		}
+	}
+
+	return nil
+}
+
+// checkName checks the name of a role or group.
+func checkName(params ...string) error {
+	for _, p := range params {
+		if !nameReg.MatchString(p) {
+			return errors.Errorf("checkName failed for %s because the name does not match %s", p, nameExpr)
+		}
+	}
+
+	return nil
+}
+
+// matchKeygroup checks whether a keygroup matches a keygroup pattern. As patterns only contain "*" and characters that
+// keygroup names may contain, a pattern also matches another pattern if it matches all the keygroups that the other pattern matches.
+func matchKeygroup(pattern KeygroupName, k KeygroupName) bool {
+	ok, err := path.Match(string(pattern), string(k))
+
+	return err == nil && ok
+}
@@ -0,0 +1,149 @@
+package fred
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/rs/zerolog/log"
+)
+
+// These are the headers that are sent with every webhook request. Webhooks may send the WebhookAckHeader with their
+// response to acknowledge a high-water mark, like trigger nodes do with the ackedSeq field.
+const (
+	WebhookSignatureHeader = "X-FReD-Signature"
+	WebhookEventHeader     = "X-FReD-Event"
+	WebhookAckHeader       = "X-FReD-Acked-Seq"
+)
+
+// Webhook is an HTTP(S) endpoint that trigger events are posted to as JSON, instead of a gRPC trigger node. If a
+// secret is set, every request is signed with an HMAC-SHA256 of its body in the X-FReD-Signature header.
+type Webhook struct {
+	URL    string `json:"url"`
+	Secret string `json:"secret,omitempty"`
+}
+
+// webhookEvent is the body of a webhook request.
+type webhookEvent struct {
+	Seq            uint64       `json:"seq"`
+	IdempotencyKey string       `json:"idempotencyKey"`
+	Keygroup       KeygroupName `json:"keygroup"`
+	ID             string       `json:"id,omitempty"`
+	Val            string       `json:"value,omitempty"`
+	Op             TriggerOp    `json:"operation"`
+	Origin         NodeID       `json:"origin"`
+	Node           NodeID       `json:"node"`
+	Timestamp      time.Time    `json:"timestamp"`
+}
+
+// checkWebhook makes sure that the URL of a webhook can be used.
+func checkWebhook(w Webhook) error {
+	u, err := url.Parse(w.URL)
+
+	if err != nil {
+		return errors.Errorf("invalid webhook URL %s: %v", w.URL, err)
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return errors.Errorf("invalid webhook URL %s: scheme must be http or https", w.URL)
+	}
+
+	if u.Host == "" {
+		return errors.Errorf("invalid webhook URL %s: missing host", w.URL)
+	}
+
+	return nil
+}
+
+// SignWebhook returns the signature of a webhook body, as it is sent in the X-FReD-Signature header.
+func SignWebhook(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// newWebhookClient creates the HTTP client for webhooks. It uses the same TLS configuration as connections to
+// trigger nodes.
+func newWebhookClient(config *tls.Config) *http.Client {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.TLSClientConfig = config
+
+	return &http.Client{
+		Transport: t,
+		Timeout:   triggerTimeout,
+	}
+}
+
+// deliverWebhook posts a single event to its webhook and returns the high-water mark it acknowledged, if any.
+func (t *triggerService) deliverWebhook(e TriggerEvent) (uint64, error) {
+	body, err := json.Marshal(webhookEvent{
+		Seq:            e.KeygroupSeq,
+		IdempotencyKey: e.IdempotencyKey,
+		Keygroup:       e.Keygroup,
+		ID:             e.ID,
+		Val:            e.Val,
+		Op:             e.Op,
+		Origin:         e.Origin,
+		Node:           t.node,
+		Timestamp:      e.Time,
+	})
+
+	if err != nil {
+		return 0, errors.New(err)
+	}
+
+	ctx, cncl := context.WithTimeout(context.Background(), triggerTimeout)
+	defer cncl()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.Trigger.Webhook.URL, bytes.NewReader(body))
+
+	if err != nil {
+		return 0, errors.New(err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookEventHeader, e.IdempotencyKey)
+
+	if e.Trigger.Webhook.Secret != "" {
+		req.Header.Set(WebhookSignatureHeader, SignWebhook(e.Trigger.Webhook.Secret, body))
+	}
+
+	res, err := t.hc.Do(req)
+
+	if err != nil {
+		return 0, errors.Errorf("could not post to webhook %s: %v", e.Trigger.Webhook.URL, err)
+	}
+
+	defer res.Body.Close()
+
+	// read the body so that the connection can be reused
+	_, _ = io.Copy(ioutil.Discard, io.LimitReader(res.Body, 1<<16))
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return 0, errors.Errorf("webhook %s returned status %s", e.Trigger.Webhook.URL, res.Status)
+	}
+
+	if h := res.Header.Get(WebhookAckHeader); h != "" {
+		acked, err := strconv.ParseUint(h, 10, 64)
+
+		if err != nil {
+			log.Warn().Msgf("webhook %s sent an invalid %s header: %s", e.Trigger.Webhook.URL, WebhookAckHeader, h)
+			return 0, nil
+		}
+
+		return acked, nil
+	}
+
+	return 0, nil
+}
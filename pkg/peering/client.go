@@ -2,59 +2,35 @@ package peering
 
 import (
 	"context"
-	"crypto/tls"
-	"crypto/x509"
-	"io/ioutil"
 
+	"git.tu-berlin.de/mcc-fred/fred/pkg/certs"
 	"git.tu-berlin.de/mcc-fred/fred/pkg/fred"
 	"git.tu-berlin.de/mcc-fred/fred/proto/peering"
 	"github.com/go-errors/errors"
 	"github.com/rs/zerolog/log"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/grpc/status"
 )
 
 // Client is an peering client to communicate with peers.
 type Client struct {
 	conn        map[string]peering.NodeClient
 	credentials credentials.TransportCredentials
+	origin      fred.NodeID
+	compress    bool
 }
 
-// NewClient creates a new empty client to communicate with peers.
-func NewClient(certFile string, keyFile string, caFile string) *Client {
-	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
-
-	if err != nil {
-		log.Fatal().Err(err).Msg("Cannot load certificates")
-
-		return nil
-	}
-
-	// Create a new cert pool and add our own CA certificate
-	rootCAs, err := x509.SystemCertPool()
-
-	if err != nil {
-		log.Fatal().Err(err).Msg("Cannot load root certificates")
-		return nil
-	}
-
-	loaded, err := ioutil.ReadFile(caFile)
-
-	if err != nil {
-		log.Fatal().Msgf("unexpected missing certfile: %v", err)
-	}
-
-	rootCAs.AppendCertsFromPEM(loaded)
-
-	tlsConfig := &tls.Config{
-		Certificates: []tls.Certificate{cert},
-		MinVersion:   tls.VersionTLS12,
-		RootCAs:      rootCAs,
-	}
-
+// NewClient creates a new empty client to communicate with peers. The ID of our node is sent along with changes, so
+// that peers know where they come from. If compress is set, requests to peers are compressed with gzip.
+func NewClient(nodeID fred.NodeID, provider *certs.Provider, compress bool) *Client {
 	return &Client{
 		conn:        make(map[string]peering.NodeClient),
-		credentials: credentials.NewTLS(tlsConfig),
+		credentials: credentials.NewTLS(provider.ClientConfig(true)),
+		origin:      nodeID,
+		compress:    compress,
 	}
 }
 
@@ -64,7 +40,14 @@ func (c *Client) getClient(host string) (peering.NodeClient, error) {
 		return client, nil
 	}
 
-	conn, err := grpc.Dial(host, grpc.WithTransportCredentials(c.credentials))
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(c.credentials)}
+
+	if c.compress {
+		// peers answer with the same compression
+		opts = append(opts, grpc.WithDefaultCallOptions(grpc.UseCompressor(gzip.Name)))
+	}
+
+	conn, err := grpc.Dial(host, opts...)
 
 	if err != nil {
 		log.Error().Err(err).Msg("Cannot create Grpc connection")
@@ -106,7 +89,7 @@ func (c *Client) SendDeleteKeygroup(host string, kgname fred.KeygroupName) error
 		return errors.New(err)
 	}
 
-	_, err = client.DeleteKeygroup(context.Background(), &peering.DeleteKeygroupRequest{Keygroup: string(kgname)})
+	_, err = client.DeleteKeygroup(context.Background(), &peering.DeleteKeygroupRequest{Keygroup: string(kgname), Origin: string(c.origin)})
 
 	if err != nil {
 		return errors.New(err)
@@ -126,6 +109,7 @@ func (c *Client) SendUpdate(host string, kgname fred.KeygroupName, id string, va
 		Keygroup: string(kgname),
 		Id:       id,
 		Data:     value,
+		Origin:   string(c.origin),
 	})
 
 	if err != nil {
@@ -146,6 +130,7 @@ func (c *Client) SendAppend(host string, kgname fred.KeygroupName, id string, va
 		Keygroup: string(kgname),
 		Id:       id,
 		Data:     value,
+		Origin:   string(c.origin),
 	})
 
 	if err != nil {
@@ -165,6 +150,7 @@ func (c *Client) SendDelete(host string, kgname fred.KeygroupName, id string) er
 	_, err = client.DeleteItem(context.Background(), &peering.DeleteItemRequest{
 		Keygroup: string(kgname),
 		Id:       id,
+		Origin:   string(c.origin),
 	})
 
 	if err != nil {
@@ -212,6 +198,41 @@ func (c *Client) SendRemoveReplica(host string, kgname fred.KeygroupName, node f
 	return nil
 }
 
+// SendGetKeygroupUsage sends this command to the server at this address
+func (c *Client) SendGetKeygroupUsage(host string, kgname fred.KeygroupName) (fred.KeygroupUsage, error) {
+	client, err := c.getClient(host)
+
+	if err != nil {
+		return fred.KeygroupUsage{}, errors.New(err)
+	}
+
+	res, err := client.GetKeygroupUsage(context.Background(), &peering.GetKeygroupUsageRequest{
+		Keygroup: string(kgname),
+	})
+
+	if status.Code(err) == codes.Unimplemented {
+		return fred.KeygroupUsage{}, fred.ErrNoKeygroupUsage
+	}
+
+	if err != nil {
+		return fred.KeygroupUsage{}, errors.New(err)
+	}
+
+	return fred.KeygroupUsage{
+		Items: res.Items,
+		Bytes: res.Bytes,
+		Quota: fred.Quota{
+			MaxItemSize: int(res.MaxItemSize),
+			MaxItems:    res.MaxItems,
+			MaxBytes:    res.MaxBytes,
+		},
+		NodeItems:    res.NodeItems,
+		NodeBytes:    res.NodeBytes,
+		NodeMaxItems: res.NodeMaxItems,
+		NodeMaxBytes: res.NodeMaxBytes,
+	}, nil
+}
+
 // SendGetItem sends this command to the server at this address
 func (c *Client) SendGetItem(host string, kgname fred.KeygroupName, id string) (fred.Item, error) {
 	client, err := c.getClient(host)
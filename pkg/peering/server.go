@@ -3,10 +3,9 @@ package peering
 import (
 	"context"
 	"crypto/tls"
-	"crypto/x509"
-	"io/ioutil"
 	"net"
 
+	"git.tu-berlin.de/mcc-fred/fred/pkg/certs"
 	"git.tu-berlin.de/mcc-fred/fred/proto/peering"
 	"github.com/rs/zerolog/log"
 	"google.golang.org/grpc"
@@ -21,35 +20,10 @@ type Server struct {
 	*grpc.Server
 }
 
-// NewServer creates a new Server for communication to the inthandler from other nodes
-func NewServer(host string, handler fred.IntHandler, certFile string, keyFile string, caFile string) *Server {
-	// Load server's certificate and private key
-	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
-
-	if err != nil {
-		log.Fatal().Msgf("could not load key pair: %v", err)
-	}
-
-	// Create a new cert pool and add our own CA certificate
-	rootCAs := x509.NewCertPool()
-
-	loaded, err := ioutil.ReadFile(caFile)
-
-	if err != nil {
-		log.Fatal().Msgf("unexpected missing certfile: %v", err)
-	}
-
-	rootCAs.AppendCertsFromPEM(loaded)
-
-	// Create the credentials and return it
-	config := &tls.Config{
-		Certificates: []tls.Certificate{cert},
-		ClientAuth:   tls.RequireAndVerifyClientCert,
-		ClientCAs:    rootCAs,
-		MinVersion:   tls.VersionTLS12,
-	}
-
-	s := &Server{handler, grpc.NewServer(grpc.Creds(credentials.NewTLS(config)))}
+// NewServer creates a new Server for communication to the inthandler from other nodes. Requests that peers compress
+// with gzip are accepted as the client in this package registers the gzip compressor.
+func NewServer(host string, handler fred.IntHandler, provider *certs.Provider) *Server {
+	s := &Server{handler, grpc.NewServer(grpc.Creds(credentials.NewTLS(provider.ServerConfig(tls.RequireAndVerifyClientCert))))}
 
 	lis, err := net.Listen("tcp", host)
 	if err != nil {
@@ -92,7 +66,7 @@ func (s *Server) CreateKeygroup(_ context.Context, request *peering.CreateKeygro
 // DeleteKeygroup calls this Method on the Inthandler
 func (s *Server) DeleteKeygroup(_ context.Context, request *peering.DeleteKeygroupRequest) (*peering.Empty, error) {
 	log.Info().Msgf("InterServer has rcvd DeleteKeygroup. In: %#v", request)
-	err := s.i.HandleDeleteKeygroup(fred.Keygroup{Name: fred.KeygroupName(request.Keygroup)})
+	err := s.i.HandleDeleteKeygroup(fred.Keygroup{Name: fred.KeygroupName(request.Keygroup)}, fred.NodeID(request.Origin))
 	if err != nil {
 		return nil, err
 	}
@@ -106,7 +80,7 @@ func (s *Server) PutItem(_ context.Context, request *peering.PutItemRequest) (*p
 		Keygroup: fred.KeygroupName(request.Keygroup),
 		ID:       request.Id,
 		Val:      request.Data,
-	})
+	}, fred.NodeID(request.Origin))
 	if err != nil {
 		return nil, err
 	}
@@ -121,7 +95,7 @@ func (s *Server) AppendItem(_ context.Context, request *peering.AppendItemReques
 		Keygroup: fred.KeygroupName(request.Keygroup),
 		ID:       request.Id,
 		Val:      request.Data,
-	})
+	}, fred.NodeID(request.Origin))
 
 	if err != nil {
 		return nil, err
@@ -176,7 +150,7 @@ func (s *Server) DeleteItem(_ context.Context, request *peering.DeleteItemReques
 	err := s.i.HandleDelete(fred.Item{
 		Keygroup: fred.KeygroupName(request.Keygroup),
 		ID:       request.Id,
-	})
+	}, fred.NodeID(request.Origin))
 	if err != nil {
 		return nil, err
 	}
@@ -202,3 +176,23 @@ func (s *Server) RemoveReplica(_ context.Context, request *peering.RemoveReplica
 	}
 	return &peering.Empty{}, nil
 }
+
+// GetKeygroupUsage calls this Method on the Inthandler
+func (s *Server) GetKeygroupUsage(_ context.Context, request *peering.GetKeygroupUsageRequest) (*peering.KeygroupUsage, error) {
+	log.Info().Msgf("InterServer has rcvd GetKeygroupUsage. In: %#v", request)
+	u, err := s.i.HandleGetKeygroupUsage(fred.Keygroup{Name: fred.KeygroupName(request.Keygroup)})
+	if err != nil {
+		return nil, err
+	}
+	return &peering.KeygroupUsage{
+		Items:        u.Items,
+		Bytes:        u.Bytes,
+		MaxItemSize:  int64(u.Quota.MaxItemSize),
+		MaxItems:     u.Quota.MaxItems,
+		MaxBytes:     u.Quota.MaxBytes,
+		NodeItems:    u.NodeItems,
+		NodeBytes:    u.NodeBytes,
+		NodeMaxItems: u.NodeMaxItems,
+		NodeMaxBytes: u.NodeMaxBytes,
+	}, nil
+}
@@ -5,10 +5,12 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"fmt"
-	"io/ioutil"
+	"io"
 	"net"
+	"strings"
 	"time"
 
+	"git.tu-berlin.de/mcc-fred/fred/pkg/certs"
 	"git.tu-berlin.de/mcc-fred/fred/proto/client"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
@@ -17,50 +19,33 @@ import (
 )
 
 type APIProxy struct {
-	p     *Proxy
-	port  int
-	conn  map[string]client.ClientClient
-	opts  grpc.DialOption
-	roots *x509.CertPool
+	p           *Proxy
+	port        int
+	conn        map[string]client.ClientClient
+	opts        grpc.DialOption
+	tls         *certs.Provider
+	allowTokens bool
 }
 
-func StartAPIProxy(p *Proxy, port int, cert string, key string, caCert string) (*grpc.Server, error) {
-	// Load server's certificate and private key
-	serverCert, err := tls.LoadX509KeyPair(cert, key)
+// StartAPIProxy starts a proxy for the client API. If allowTokens is set, clients may connect without a certificate if
+// they send a bearer token, which is then checked by the FReD node.
+func StartAPIProxy(p *Proxy, port int, provider *certs.Provider, allowTokens bool) (*grpc.Server, error) {
+	clientAuth := tls.RequireAndVerifyClientCert
 
-	if err != nil {
-		return nil, err
-	}
-
-	// Create a new cert pool and add our own CA certificate
-	rootCAs := x509.NewCertPool()
-
-	loaded, err := ioutil.ReadFile(caCert)
-
-	if err != nil {
-		return nil, err
-	}
-
-	rootCAs.AppendCertsFromPEM(loaded)
-	// Create the credentials and return it
-
-	config := &tls.Config{
-		Certificates: []tls.Certificate{serverCert},
-		ClientAuth:   tls.RequireAndVerifyClientCert,
-		ClientCAs:    rootCAs,
-		RootCAs:      rootCAs,
-		MinVersion:   tls.VersionTLS12,
+	if allowTokens {
+		clientAuth = tls.VerifyClientCertIfGiven
 	}
 
 	a := &APIProxy{
-		p:     p,
-		port:  port,
-		conn:  make(map[string]client.ClientClient),
-		opts:  grpc.WithTransportCredentials(credentials.NewTLS(config)),
-		roots: rootCAs,
+		p:           p,
+		port:        port,
+		conn:        make(map[string]client.ClientClient),
+		opts:        grpc.WithTransportCredentials(credentials.NewTLS(provider.ClientConfig(false))),
+		tls:         provider,
+		allowTokens: allowTokens,
 	}
 
-	s := grpc.NewServer(grpc.Creds(credentials.NewTLS(config)))
+	s := grpc.NewServer(grpc.Creds(credentials.NewTLS(provider.ServerConfig(clientAuth))))
 
 	client.RegisterClientServer(s, a)
 
@@ -125,9 +110,17 @@ func (a *APIProxy) addUserHeader(ctx context.Context) (context.Context, error) {
 
 	// check that the certificate exists
 	if len(tlsAuth.State.VerifiedChains) == 0 || len(tlsAuth.State.VerifiedChains[0]) == 0 {
+		if a.allowTokens {
+			return a.forwardToken(ctx)
+		}
+
 		return ctx, fmt.Errorf("could not verify peer certificate: %v", tlsAuth.State)
 	}
 
+	if err := a.tls.CheckRevoked(tlsAuth.State.VerifiedChains[0][0]); err != nil {
+		return ctx, err
+	}
+
 	host, _, err := net.SplitHostPort(p.Addr.String())
 
 	if err != nil {
@@ -141,7 +134,7 @@ func (a *APIProxy) addUserHeader(ctx context.Context) (context.Context, error) {
 	// 3) the certificate should be valid for client authentication
 	// 4) the certificate should have the clients address as a SAN
 	_, err = tlsAuth.State.VerifiedChains[0][0].Verify(x509.VerifyOptions{
-		Roots:         a.roots,
+		Roots:         a.tls.Roots(),
 		CurrentTime:   time.Now(),
 		Intermediates: x509.NewCertPool(),
 		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
@@ -172,6 +165,27 @@ func (a *APIProxy) addUserHeader(ctx context.Context) (context.Context, error) {
 
 }
 
+// forwardToken passes a request without a client certificate on to the backend if it has a bearer token. We don't
+// check the token here, the FReD node does that. We only make sure that the client cannot set the "user" header itself.
+func (a *APIProxy) forwardToken(ctx context.Context) (context.Context, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+
+	if !ok {
+		return ctx, fmt.Errorf("no metadata could be found for proxied request")
+	}
+
+	auth := md.Get("authorization")
+
+	if len(auth) != 1 || !strings.HasPrefix(strings.ToLower(auth[0]), "bearer ") {
+		return ctx, fmt.Errorf("request has neither a client certificate nor a bearer token")
+	}
+
+	newMD := md.Copy()
+	delete(newMD, "user")
+
+	return metadata.NewOutgoingContext(ctx, newMD), nil
+}
+
 // CreateKeygroup calls this method on the exthandler
 func (a *APIProxy) CreateKeygroup(ctx context.Context, req *client.CreateKeygroupRequest) (*client.StatusResponse, error) {
 
@@ -340,6 +354,22 @@ func (a *APIProxy) RemoveReplica(ctx context.Context, req *client.RemoveReplicaR
 	return c.RemoveReplica(ctx, req)
 }
 
+// GetKeygroupUsage calls this method on the exthandler
+func (a *APIProxy) GetKeygroupUsage(ctx context.Context, req *client.GetKeygroupUsageRequest) (*client.GetKeygroupUsageResponse, error) {
+	c, err := a.getConn(req.Keygroup)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err = a.addUserHeader(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.GetKeygroupUsage(ctx, req)
+}
+
 // GetReplica calls this method on the exthandler
 func (a *APIProxy) GetReplica(ctx context.Context, req *client.GetReplicaRequest) (*client.GetReplicaResponse, error) {
 	c, err := a.getAny()
@@ -451,3 +481,244 @@ func (a *APIProxy) RemoveUser(ctx context.Context, req *client.UserRequest) (*cl
 
 	return c.RemoveUser(ctx, req)
 }
+
+// GetKeygroupPermissions calls this method on the exthandler
+func (a *APIProxy) GetKeygroupPermissions(ctx context.Context, req *client.GetKeygroupPermissionsRequest) (*client.GetKeygroupPermissionsResponse, error) {
+	c, err := a.getConn(req.Keygroup)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err = a.addUserHeader(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.GetKeygroupPermissions(ctx, req)
+}
+
+// AddRole calls this method on the exthandler
+func (a *APIProxy) AddRole(ctx context.Context, req *client.AddRoleRequest) (*client.StatusResponse, error) {
+	c, err := a.getAny()
+
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err = a.addUserHeader(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.AddRole(ctx, req)
+}
+
+// RemoveRole calls this method on the exthandler
+func (a *APIProxy) RemoveRole(ctx context.Context, req *client.RemoveRoleRequest) (*client.StatusResponse, error) {
+	c, err := a.getAny()
+
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err = a.addUserHeader(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.RemoveRole(ctx, req)
+}
+
+// GetRoles calls this method on the exthandler
+func (a *APIProxy) GetRoles(ctx context.Context, req *client.GetRolesRequest) (*client.GetRolesResponse, error) {
+	c, err := a.getAny()
+
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err = a.addUserHeader(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.GetRoles(ctx, req)
+}
+
+// AddGroupMember calls this method on the exthandler
+func (a *APIProxy) AddGroupMember(ctx context.Context, req *client.GroupMemberRequest) (*client.StatusResponse, error) {
+	c, err := a.getAny()
+
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err = a.addUserHeader(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.AddGroupMember(ctx, req)
+}
+
+// RemoveGroupMember calls this method on the exthandler
+func (a *APIProxy) RemoveGroupMember(ctx context.Context, req *client.GroupMemberRequest) (*client.StatusResponse, error) {
+	c, err := a.getAny()
+
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err = a.addUserHeader(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.RemoveGroupMember(ctx, req)
+}
+
+// GetGroupMembers calls this method on the exthandler
+func (a *APIProxy) GetGroupMembers(ctx context.Context, req *client.GetGroupMembersRequest) (*client.GetGroupMembersResponse, error) {
+	c, err := a.getAny()
+
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err = a.addUserHeader(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.GetGroupMembers(ctx, req)
+}
+
+// GetAuditLog calls this method on the exthandler
+func (a *APIProxy) GetAuditLog(ctx context.Context, req *client.GetAuditLogRequest) (*client.GetAuditLogResponse, error) {
+	c, err := a.getConn(req.Keygroup)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err = a.addUserHeader(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.GetAuditLog(ctx, req)
+}
+
+// GetFailedTriggerEvents calls this method on the exthandler
+func (a *APIProxy) GetFailedTriggerEvents(ctx context.Context, req *client.GetFailedTriggerEventsRequest) (*client.GetFailedTriggerEventsResponse, error) {
+	c, err := a.getConn(req.Keygroup)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err = a.addUserHeader(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.GetFailedTriggerEvents(ctx, req)
+}
+
+// ReplayTriggerEvents calls this method on the exthandler
+func (a *APIProxy) ReplayTriggerEvents(ctx context.Context, req *client.ReplayTriggerEventsRequest) (*client.StatusResponse, error) {
+	c, err := a.getConn(req.Keygroup)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err = a.addUserHeader(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.ReplayTriggerEvents(ctx, req)
+}
+
+// Backup calls this method on the exthandler
+func (a *APIProxy) Backup(req *client.BackupRequest, stream client.Client_BackupServer) error {
+	c, err := a.getConn(req.Keygroup)
+
+	if err != nil {
+		return err
+	}
+
+	ctx, err := a.addUserHeader(stream.Context())
+	if err != nil {
+		return err
+	}
+
+	b, err := c.Backup(ctx, req)
+
+	if err != nil {
+		return err
+	}
+
+	for {
+		chunk, err := b.Recv()
+
+		if err == io.EOF {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if err := stream.Send(chunk); err != nil {
+			return err
+		}
+	}
+}
+
+// Restore calls this method on the exthandler. The keygroup is only known once the backup has been read, so any node
+// can restore it.
+func (a *APIProxy) Restore(stream client.Client_RestoreServer) error {
+	c, err := a.getAny()
+
+	if err != nil {
+		return err
+	}
+
+	ctx, err := a.addUserHeader(stream.Context())
+	if err != nil {
+		return err
+	}
+
+	r, err := c.Restore(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	for {
+		chunk, err := stream.Recv()
+
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if err := r.Send(chunk); err != nil {
+			return err
+		}
+	}
+
+	res, err := r.CloseAndRecv()
+
+	if err != nil {
+		return err
+	}
+
+	return stream.SendAndClose(res)
+}
@@ -3,10 +3,9 @@ package proxy
 import (
 	"context"
 	"crypto/tls"
-	"crypto/x509"
 	"fmt"
-	"io/ioutil"
 
+	"git.tu-berlin.de/mcc-fred/fred/pkg/certs"
 	"git.tu-berlin.de/mcc-fred/fred/proto/peering"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
@@ -19,42 +18,15 @@ type PeeringProxy struct {
 	opts grpc.DialOption
 }
 
-func StartPeeringProxy(p *Proxy, port int, cert string, key string, caCert string) (*grpc.Server, error) {
-	// Load server's certificate and private key
-	serverCert, err := tls.LoadX509KeyPair(cert, key)
-
-	if err != nil {
-		return nil, err
-	}
-
-	// Create a new cert pool and add our own CA certificate
-	rootCAs := x509.NewCertPool()
-
-	loaded, err := ioutil.ReadFile(caCert)
-
-	if err != nil {
-		return nil, err
-	}
-
-	rootCAs.AppendCertsFromPEM(loaded)
-	// Create the credentials and return it
-
-	config := &tls.Config{
-		Certificates: []tls.Certificate{serverCert},
-		ClientAuth:   tls.RequireAndVerifyClientCert,
-		ClientCAs:    rootCAs,
-		RootCAs:      rootCAs,
-		MinVersion:   tls.VersionTLS12,
-	}
-
+func StartPeeringProxy(p *Proxy, port int, provider *certs.Provider) (*grpc.Server, error) {
 	a := &PeeringProxy{
 		p:    p,
 		port: port,
 		conn: make(map[string]peering.NodeClient),
-		opts: grpc.WithTransportCredentials(credentials.NewTLS(config)),
+		opts: grpc.WithTransportCredentials(credentials.NewTLS(provider.ClientConfig(false))),
 	}
 
-	s := grpc.NewServer(grpc.Creds(credentials.NewTLS(config)))
+	s := grpc.NewServer(grpc.Creds(credentials.NewTLS(provider.ServerConfig(tls.RequireAndVerifyClientCert))))
 
 	peering.RegisterNodeServer(s, a)
 
@@ -178,3 +150,14 @@ func (p *PeeringProxy) RemoveReplica(ctx context.Context, req *peering.RemoveRep
 
 	return c.RemoveReplica(ctx, req)
 }
+
+// GetKeygroupUsage calls this Method on the Inthandler
+func (p *PeeringProxy) GetKeygroupUsage(ctx context.Context, req *peering.GetKeygroupUsageRequest) (*peering.KeygroupUsage, error) {
+	c, err := p.getConn(req.Keygroup)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return c.GetKeygroupUsage(ctx, req)
+}
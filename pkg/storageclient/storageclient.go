@@ -7,11 +7,14 @@ import (
 	"io"
 	"io/ioutil"
 
+	"git.tu-berlin.de/mcc-fred/fred/pkg/fred"
 	"git.tu-berlin.de/mcc-fred/fred/proto/storage"
 	"github.com/go-errors/errors"
 	"github.com/rs/zerolog/log"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
 )
 
 // Client to a grpc server
@@ -82,7 +85,7 @@ func (c *Client) Read(kg string, id string) (string, error) {
 		return "", errors.New(err)
 	}
 
-	return response.Val, nil
+	return string(response.Val), nil
 }
 
 // Scan calls the same method on the remote server
@@ -108,7 +111,7 @@ func (c *Client) ReadSome(kg string, id string, count uint64) (map[string]string
 			return nil, errors.New(err)
 		}
 
-		responses[in.Id] = in.Val
+		responses[in.Id] = string(in.Val)
 
 	}
 	return responses, nil
@@ -134,17 +137,45 @@ func (c *Client) ReadAll(kg string) (map[string]string, error) {
 			return nil, errors.New(err)
 		}
 
-		responses[in.Id] = in.Val
+		responses[in.Id] = string(in.Val)
 
 	}
 	return responses, nil
 }
 
+// Export calls the same method on the remote server. Servers from older versions of FReD cannot tell when items
+// expire, in which case it returns fred.ErrNoExport.
+func (c *Client) Export(kg string, fn func(id string, val string, expiresAt int64) error) error {
+	stream, err := c.dbClient.Export(context.Background(), &storage.Keygroup{Keygroup: kg})
+	if err != nil {
+		log.Err(err).Msgf("StorageClient: Error in Export in: %#v", kg)
+		return errors.New(err)
+	}
+
+	for {
+		in, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if status.Code(err) == codes.Unimplemented {
+			return fred.ErrNoExport
+		}
+		if err != nil {
+			log.Err(err).Msg("StorageClient: Error in Export while receiving an Item")
+			return errors.New(err)
+		}
+
+		if err := fn(in.Id, string(in.Val), in.ExpiresAt); err != nil {
+			return err
+		}
+	}
+}
+
 // Update calls the same method on the remote server
 func (c *Client) Update(kg string, id string, val string, append bool, expiry int) error {
 	response, err := c.dbClient.Update(context.Background(), &storage.UpdateItem{
 		Keygroup: kg,
-		Val:      val,
+		Val:      []byte(val),
 		Id:       id,
 		Append:   append,
 		Expiry:   int64(expiry)})
@@ -157,16 +188,59 @@ func (c *Client) Update(kg string, id string, val string, append bool, expiry in
 	return nil
 }
 
+// UpdateBulk streams items to the remote server, which writes them as they arrive. Servers from older versions of FReD
+// that cannot write items in bulk get them one by one.
+func (c *Client) UpdateBulk(items []fred.BulkItem) error {
+	stream, err := c.dbClient.BulkUpdate(context.Background())
+	if err != nil {
+		log.Err(err).Msgf("StorageClient: Error in UpdateBulk with %d items", len(items))
+		return errors.New(err)
+	}
+
+	for _, i := range items {
+		err := stream.Send(&storage.UpdateItem{
+			Keygroup: i.Keygroup,
+			Id:       i.ID,
+			Val:      []byte(i.Val),
+			Append:   i.Append,
+			Expiry:   int64(i.Expiry),
+		})
+		if err != nil {
+			// the server has ended the stream, its error is returned by CloseAndRecv
+			break
+		}
+	}
+
+	response, err := stream.CloseAndRecv()
+	log.Debug().Err(err).Msgf("StorageClient: UpdateBulk in: %d items out: %#v", len(items), response)
+
+	if status.Code(err) == codes.Unimplemented {
+		for _, i := range items {
+			if err := c.Update(i.Keygroup, i.ID, i.Val, i.Append, i.Expiry); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	if err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
 // Append calls the same method on the remote server
-func (c *Client) Append(kg string, val string, expiry int) (string, error) {
-	response, err := c.dbClient.Append(context.Background(), &storage.AppendItem{Keygroup: kg, Val: val, Expiry: int64(expiry)})
-	log.Debug().Err(err).Msgf("StorageClient: Append in: %#v,%#v out: %#v", kg, val, response)
+func (c *Client) Append(kg string, id string, val string, expiry int) error {
+	response, err := c.dbClient.Append(context.Background(), &storage.AppendItem{Keygroup: kg, Id: id, Val: []byte(val), Expiry: int64(expiry)})
+	log.Debug().Err(err).Msgf("StorageClient: Append in: %#v,%#v,%#v out: %#v", kg, id, val, response)
 
 	if err != nil {
-		return "", errors.New(err)
+		return errors.New(err)
 	}
 
-	return response.Id, nil
+	return nil
 }
 
 // Delete calls the same method on the remote server
@@ -253,6 +327,29 @@ func (c *Client) ExistsKeygroup(kg string) bool {
 	return response.Success
 }
 
+// Keygroups calls the same method on the remote server.
+func (c *Client) Keygroups() ([]string, error) {
+	stream, err := c.dbClient.Keygroups(context.Background(), &storage.KeygroupsRequest{})
+	if err != nil {
+		log.Err(err).Msg("StorageClient: Error in Keygroups")
+		return nil, errors.New(err)
+	}
+	var responses []string
+	for {
+		in, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			// read done.
+			break
+		}
+		if err != nil {
+			log.Err(err).Msg("StorageClient: Error in Keygroups while receiving a Keygroup")
+			return nil, errors.New(err)
+		}
+		responses = append(responses, in.Keygroup)
+	}
+	return responses, nil
+}
+
 // Destroy destroys the connection
 func (c *Client) Destroy() {
 	_ = c.con.Close()
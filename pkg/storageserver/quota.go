@@ -0,0 +1,297 @@
+package storageserver
+
+import (
+	"sync"
+	"time"
+
+	"git.tu-berlin.de/mcc-fred/fred/pkg/fred"
+	"github.com/go-errors/errors"
+)
+
+// Quota limits what a namespace may store. Bytes count the IDs and values of items. 0 means no limit.
+type Quota struct {
+	MaxItems int64
+	MaxBytes int64
+}
+
+// fits checks whether items and bytes can be added to what a namespace stores.
+func (q Quota) fits(ns string, u *usage, items, bytes int64) error {
+	if q.MaxItems > 0 && items > 0 && u.items+items > q.MaxItems {
+		return errors.Errorf("quota exceeded: namespace %s may have at most %d items and has %d", ns, q.MaxItems, u.items)
+	}
+
+	if q.MaxBytes > 0 && bytes > 0 && u.bytes+bytes > q.MaxBytes {
+		return errors.Errorf("quota exceeded: namespace %s may store at most %d bytes and stores %d", ns, q.MaxBytes, u.bytes)
+	}
+
+	return nil
+}
+
+// usage is what a namespace stores. Writes to a namespace hold its lock, so that each of them is checked against
+// what the ones before it stored.
+type usage struct {
+	sync.Mutex
+	counted bool
+	items   int64
+	bytes   int64
+	expires bool
+}
+
+// quotaTracker keeps track of how many items and bytes every namespace stores, which is counted in the store once and
+// then updated on every write.
+type quotaTracker struct {
+	store     fred.Store
+	keygroups func(ns string) ([]string, []string, error)
+	quota     Quota
+	quotas    map[string]Quota
+	mu        sync.Mutex
+	usage     map[string]*usage
+}
+
+func newQuotaTracker(store fred.Store, keygroups func(ns string) ([]string, []string, error), quota Quota, quotas map[string]Quota) *quotaTracker {
+	return &quotaTracker{
+		store:     store,
+		keygroups: keygroups,
+		quota:     quota,
+		quotas:    quotas,
+		usage:     make(map[string]*usage),
+	}
+}
+
+// limited checks whether there are any quotas. Usage is only tracked if there are.
+func (q *quotaTracker) limited() bool {
+	return q.quota.MaxItems > 0 || q.quota.MaxBytes > 0 || len(q.quotas) > 0
+}
+
+// quotaOf returns the quota of a namespace.
+func (q *quotaTracker) quotaOf(ns string) Quota {
+	if quota, ok := q.quotas[ns]; ok {
+		return quota
+	}
+
+	return q.quota
+}
+
+// count counts the items of a keygroup in the store and their bytes, except items that have expired.
+func (q *quotaTracker) count(kg string) (int64, int64, error) {
+	var items, bytes int64
+
+	e, ok := q.store.(fred.Exporter)
+
+	if !ok {
+		all, err := q.store.ReadAll(kg)
+
+		if err != nil {
+			return 0, 0, err
+		}
+
+		for id, val := range all {
+			items++
+			bytes += int64(len(id) + len(val))
+		}
+
+		return items, bytes, nil
+	}
+
+	now := time.Now().Unix()
+
+	err := e.Export(kg, func(id string, val string, expiresAt int64) error {
+		if expiresAt != 0 && expiresAt <= now {
+			return nil
+		}
+
+		items++
+		bytes += int64(len(id) + len(val))
+
+		return nil
+	})
+
+	return items, bytes, err
+}
+
+// countAll sets the usage of a namespace to what all of its keygroups in the store hold.
+func (q *quotaTracker) countAll(ns string, u *usage) error {
+	var items, bytes int64
+
+	kgs, _, err := q.keygroups(ns)
+
+	if err != nil {
+		return err
+	}
+
+	for _, kg := range kgs {
+		i, b, err := q.count(kg)
+
+		if err != nil {
+			return err
+		}
+
+		items += i
+		bytes += b
+	}
+
+	u.items = items
+	u.bytes = bytes
+	u.counted = true
+
+	return nil
+}
+
+// lock locks the usage of a namespace and counts it in the store if that has not happened yet.
+func (q *quotaTracker) lock(ns string) (*usage, error) {
+	q.mu.Lock()
+	u, ok := q.usage[ns]
+
+	if !ok {
+		u = &usage{}
+		q.usage[ns] = u
+	}
+
+	q.mu.Unlock()
+
+	u.Lock()
+
+	if u.counted {
+		return u, nil
+	}
+
+	if err := q.countAll(ns, u); err != nil {
+		u.Unlock()
+		return nil, err
+	}
+
+	return u, nil
+}
+
+// size returns how many bytes an item in the store counts towards quotas and whether it exists.
+func (q *quotaTracker) size(kg, id string) (int64, bool, error) {
+	if !q.store.Exists(kg, id) {
+		return 0, false, nil
+	}
+
+	val, err := q.store.Read(kg, id)
+
+	if err != nil {
+		return 0, false, err
+	}
+
+	return int64(len(id) + len(val)), true, nil
+}
+
+// write runs a write of an item with a value of size bytes to a keygroup in the store, unless the item does not fit
+// into the quota of the namespace. Items with an expiry may have expired since the namespace was counted, so it is
+// counted again before such a write is refused.
+func (q *quotaTracker) write(ns, kg, id string, size int, expiry int, fn func() error) error {
+	if !q.limited() {
+		return fn()
+	}
+
+	u, err := q.lock(ns)
+
+	if err != nil {
+		return err
+	}
+
+	defer u.Unlock()
+
+	old, existed, err := q.size(kg, id)
+
+	if err != nil {
+		return err
+	}
+
+	items := int64(1)
+
+	if existed {
+		items = 0
+	}
+
+	bytes := int64(len(id)+size) - old
+	quota := q.quotaOf(ns)
+
+	err = quota.fits(ns, u, items, bytes)
+
+	if err != nil && u.expires {
+		if err := q.countAll(ns, u); err != nil {
+			return err
+		}
+
+		err = quota.fits(ns, u, items, bytes)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if err := fn(); err != nil {
+		return err
+	}
+
+	u.items += items
+	u.bytes += bytes
+	u.expires = u.expires || expiry > 0
+
+	return nil
+}
+
+// remove runs the deletion of an item from a keygroup in the store and frees what it used.
+func (q *quotaTracker) remove(ns, kg, id string, fn func() error) error {
+	if !q.limited() {
+		return fn()
+	}
+
+	u, err := q.lock(ns)
+
+	if err != nil {
+		return err
+	}
+
+	defer u.Unlock()
+
+	old, existed, err := q.size(kg, id)
+
+	if err != nil {
+		return err
+	}
+
+	if err := fn(); err != nil {
+		return err
+	}
+
+	if existed {
+		u.items--
+		u.bytes -= old
+	}
+
+	return nil
+}
+
+// drop runs the deletion of a keygroup from the store and frees what its items used.
+func (q *quotaTracker) drop(ns, kg string, fn func() error) error {
+	if !q.limited() {
+		return fn()
+	}
+
+	u, err := q.lock(ns)
+
+	if err != nil {
+		return err
+	}
+
+	defer u.Unlock()
+
+	items, bytes, err := q.count(kg)
+
+	if err != nil {
+		return err
+	}
+
+	if err := fn(); err != nil {
+		return err
+	}
+
+	u.items -= items
+	u.bytes -= bytes
+
+	return nil
+}
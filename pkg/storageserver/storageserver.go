@@ -2,28 +2,150 @@ package storageserver
 
 import (
 	"context"
+	"io"
+	"strconv"
+	"strings"
 
 	"git.tu-berlin.de/mcc-fred/fred/pkg/fred"
 	"git.tu-berlin.de/mcc-fred/fred/proto/storage"
+	"github.com/go-errors/errors"
 	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 )
 
-// Server implements the DatabaseServer interface
+// Config configures how the store is shared between clients. Clients whose certificate has the Legacy common name use
+// the store without a namespace, like all clients did before there were namespaces, so that they keep their data.
+// Quota limits every namespace unless Quotas has a quota for it.
+type Config struct {
+	Legacy string
+	Quota  Quota
+	Quotas map[string]Quota
+}
+
+// Server implements the DatabaseServer interface. Every client has its own namespace in the store, named after the
+// common name of its certificate, so that FReD nodes that share a storage server can use the same keygroup names.
 type Server struct {
-	store fred.Store
+	store  fred.Store
+	legacy string
+	q      *quotaTracker
 }
 
 // NewStorageServer creates a new Server to serve GRPC Requests. It answers according to the data/Storage Interface
-func NewStorageServer(store *fred.Store) *Server {
+func NewStorageServer(store *fred.Store, config Config) *Server {
 	log.Debug().Msgf("Setting up new Server with store %#v", *store)
-	return &Server{store: *store}
+
+	s := &Server{
+		store:  *store,
+		legacy: config.Legacy,
+	}
+
+	s.q = newQuotaTracker(*store, s.keygroups, config.Quota, config.Quotas)
+
+	return s
+}
+
+// namespace returns the namespace of the client that sent a request, which is the common name of its certificate.
+func namespace(ctx context.Context) (string, error) {
+	p, ok := peer.FromContext(ctx)
+
+	if !ok {
+		return "", errors.Errorf("no peer found")
+	}
+
+	tlsAuth, ok := p.AuthInfo.(credentials.TLSInfo)
+
+	if !ok {
+		return "", errors.Errorf("unexpected peer transport credentials")
+	}
+
+	if len(tlsAuth.State.VerifiedChains) == 0 || len(tlsAuth.State.VerifiedChains[0]) == 0 {
+		return "", errors.Errorf("could not verify peer certificate: %v", tlsAuth.State)
+	}
+
+	name := tlsAuth.State.VerifiedChains[0][0].Subject.CommonName
+
+	if name == "" {
+		return "", errors.Errorf("invalid subject common name")
+	}
+
+	return name, nil
+}
+
+// prefix is what the keygroups of a namespace start with in the store. Keygroup names from FReD never start with "|",
+// so they cannot clash with the keygroups of the legacy namespace, and the namespace is prefixed with its length, so
+// that no namespace is a prefix of another one.
+func prefix(ns string) string {
+	return "|" + strconv.Itoa(len(ns)) + ":" + ns + "|"
+}
+
+// keygroup returns the name of a keygroup of a namespace in the store. Keygroup names starting with "|" are rejected
+// for every namespace, as they would otherwise let a legacy client reach the keygroups of other namespaces.
+func (s Server) keygroup(ns string, kg string) (string, error) {
+	if strings.HasPrefix(kg, "|") {
+		return "", errors.Errorf("invalid keygroup name %s", kg)
+	}
+
+	if ns == s.legacy {
+		return kg, nil
+	}
+
+	return prefix(ns) + kg, nil
+}
+
+// keygroups returns the names of all keygroups of a namespace, both in the store and as the client knows them.
+func (s Server) keygroups(ns string) (stored []string, names []string, err error) {
+	kgs, err := s.store.Keygroups()
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	p := prefix(ns)
+
+	for _, kg := range kgs {
+		if ns == s.legacy {
+			if !strings.HasPrefix(kg, "|") {
+				stored = append(stored, kg)
+				names = append(names, kg)
+			}
+
+			continue
+		}
+
+		if strings.HasPrefix(kg, p) {
+			stored = append(stored, kg)
+			names = append(names, strings.TrimPrefix(kg, p))
+		}
+	}
+
+	return stored, names, nil
+}
+
+// update writes an item for a namespace if it fits into the quota of the namespace.
+func (s Server) update(ns string, item *storage.UpdateItem) error {
+	kg, err := s.keygroup(ns, item.Keygroup)
+	if err != nil {
+		return err
+	}
+
+	return s.q.write(ns, kg, item.Id, len(item.Val), int(item.Expiry), func() error {
+		return s.store.Update(kg, item.Id, string(item.Val), item.Append, int(item.Expiry))
+	})
 }
 
 // Update calls specific method of the storage interface
-func (s *Server) Update(_ context.Context, item *storage.UpdateItem) (*storage.Response, error) {
+func (s *Server) Update(ctx context.Context, item *storage.UpdateItem) (*storage.Response, error) {
 	log.Debug().Msgf("GRPCServer: Update in=%#v", item)
 
-	err := s.store.Update(item.Keygroup, item.Id, item.Val, item.Append, int(item.Expiry))
+	ns, err := namespace(ctx)
+	if err != nil {
+		return &storage.Response{Success: false}, err
+	}
+
+	err = s.update(ns, item)
 	if err != nil {
 		log.Err(err).Msgf("GRPCServer has encountered an error while updating item %#v", item)
 		return &storage.Response{Success: false}, err
@@ -31,27 +153,76 @@ func (s *Server) Update(_ context.Context, item *storage.UpdateItem) (*storage.R
 	return &storage.Response{Success: true}, nil
 }
 
+// BulkUpdate writes all items that the client streams until it closes the stream and then returns how many items were
+// written. It stops at the first item that cannot be written.
+func (s *Server) BulkUpdate(server storage.Database_BulkUpdateServer) error {
+	log.Debug().Msg("GRPCServer: BulkUpdate")
+
+	ns, err := namespace(server.Context())
+	if err != nil {
+		return err
+	}
+
+	var written uint64
+
+	for {
+		item, err := server.Recv()
+		if errors.Is(err, io.EOF) {
+			return server.SendAndClose(&storage.BulkUpdateResponse{Items: written})
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := s.update(ns, item); err != nil {
+			log.Err(err).Msgf("GRPCServer has encountered an error while updating item %#v after %d items in bulk", item, written)
+			return err
+		}
+
+		written++
+	}
+}
+
 // Append calls specific method of the storage interface
-func (s *Server) Append(_ context.Context, item *storage.AppendItem) (*storage.Key, error) {
+func (s *Server) Append(ctx context.Context, item *storage.AppendItem) (*storage.Response, error) {
 	log.Debug().Msgf("GRPCServer: Append in=%#v", item)
 
-	res, err := s.store.Append(item.Keygroup, item.Val, int(item.Expiry))
+	ns, err := namespace(ctx)
+	if err != nil {
+		return &storage.Response{Success: false}, err
+	}
+
+	kg, err := s.keygroup(ns, item.Keygroup)
+	if err != nil {
+		return &storage.Response{Success: false}, err
+	}
+
+	err = s.q.write(ns, kg, item.Id, len(item.Val), int(item.Expiry), func() error {
+		return s.store.Append(kg, item.Id, string(item.Val), int(item.Expiry))
+	})
 
 	if err != nil {
 		log.Err(err).Msgf("GRPCServer has encountered an error while appending item %#v", item)
-		return &storage.Key{}, err
+		return &storage.Response{Success: false}, err
 	}
 
-	return &storage.Key{
-		Keygroup: item.Keygroup,
-		Id:       res,
-	}, nil
+	return &storage.Response{Success: true}, nil
 }
 
 // Delete calls specific method of the storage interface
-func (s Server) Delete(_ context.Context, key *storage.Key) (*storage.Response, error) {
+func (s Server) Delete(ctx context.Context, key *storage.Key) (*storage.Response, error) {
 	log.Debug().Msgf("GRPCServer: Delete in=%#v", key)
-	err := s.store.Delete(key.Keygroup, key.Id)
+	ns, err := namespace(ctx)
+	if err != nil {
+		return &storage.Response{Success: false}, err
+	}
+	kg, err := s.keygroup(ns, key.Keygroup)
+	if err != nil {
+		return &storage.Response{Success: false}, err
+	}
+	err = s.q.remove(ns, kg, key.Id, func() error {
+		return s.store.Delete(kg, key.Id)
+	})
 	if err != nil {
 		log.Err(err).Msgf("GRPCServer has encountered an error while deleting item %#v", key)
 		return &storage.Response{Success: false, Message: "Server has encountered an error while deleting an item"}, err
@@ -60,21 +231,37 @@ func (s Server) Delete(_ context.Context, key *storage.Key) (*storage.Response,
 }
 
 // Read calls specific method of the storage interface
-func (s Server) Read(_ context.Context, key *storage.Key) (*storage.Val, error) {
+func (s Server) Read(ctx context.Context, key *storage.Key) (*storage.Val, error) {
 	log.Debug().Msgf("GRPCServer: Read in=%#v", key)
-	res, err := s.store.Read(key.Keygroup, key.Id)
+	ns, err := namespace(ctx)
+	if err != nil {
+		return &storage.Val{}, err
+	}
+	stored, err := s.keygroup(ns, key.Keygroup)
+	if err != nil {
+		return &storage.Val{}, err
+	}
+	res, err := s.store.Read(stored, key.Id)
 	if err != nil {
 		log.Err(err).Msgf("GRPCServer has encountered an error while reading item %#v", key)
 		return &storage.Val{}, err
 	}
-	return &storage.Val{Val: res}, nil
+	return &storage.Val{Val: []byte(res)}, nil
 }
 
 // Scan calls specific method of the storage interface
 func (s Server) Scan(req *storage.ScanRequest, server storage.Database_ScanServer) error {
 	// Stream: call server.send for every item, return if none left.
 	log.Debug().Msgf("GRPCServer: Scan in=%#v", req)
-	res, err := s.store.ReadSome(req.Key.Keygroup, req.Key.Id, req.Count)
+	ns, err := namespace(server.Context())
+	if err != nil {
+		return err
+	}
+	stored, err := s.keygroup(ns, req.Key.Keygroup)
+	if err != nil {
+		return err
+	}
+	res, err := s.store.ReadSome(stored, req.Key.Id, req.Count)
 	if err != nil {
 		log.Err(err).Msgf("GRPCServer has encountered an error while scanning %d items from keygroup %#v", req.Count, req.Key.Keygroup)
 		return err
@@ -82,7 +269,7 @@ func (s Server) Scan(req *storage.ScanRequest, server storage.Database_ScanServe
 	for id, elem := range res {
 		err := server.Send(&storage.Item{
 			Id:  id,
-			Val: elem,
+			Val: []byte(elem),
 		})
 		if err != nil {
 			return err
@@ -96,7 +283,15 @@ func (s Server) Scan(req *storage.ScanRequest, server storage.Database_ScanServe
 func (s Server) ReadAll(kg *storage.Keygroup, server storage.Database_ReadAllServer) error {
 	// Stream: call server.send for every item, return if none left.
 	log.Debug().Msgf("GRPCServer: ReadAll in=%#v", kg)
-	res, err := s.store.ReadAll(kg.Keygroup)
+	ns, err := namespace(server.Context())
+	if err != nil {
+		return err
+	}
+	stored, err := s.keygroup(ns, kg.Keygroup)
+	if err != nil {
+		return err
+	}
+	res, err := s.store.ReadAll(stored)
 	if err != nil {
 		log.Err(err).Msgf("GRPCServer has encountered an error while reading whole keygroup %#v", kg)
 		return err
@@ -104,7 +299,7 @@ func (s Server) ReadAll(kg *storage.Keygroup, server storage.Database_ReadAllSer
 	for id, elem := range res {
 		err := server.Send(&storage.Item{
 			Id:  id,
-			Val: elem,
+			Val: []byte(elem),
 		})
 		if err != nil {
 			return err
@@ -114,10 +309,48 @@ func (s Server) ReadAll(kg *storage.Keygroup, server storage.Database_ReadAllSer
 	return nil
 }
 
+// Export sends all items of a keygroup with the time at which they expire. It fails if the store cannot tell when
+// items expire.
+func (s Server) Export(kg *storage.Keygroup, server storage.Database_ExportServer) error {
+	log.Debug().Msgf("GRPCServer: Export in=%#v", kg)
+	ns, err := namespace(server.Context())
+	if err != nil {
+		return err
+	}
+	e, ok := s.store.(fred.Exporter)
+	if !ok {
+		return status.Errorf(codes.Unimplemented, "store cannot export items with their expiry")
+	}
+	stored, err := s.keygroup(ns, kg.Keygroup)
+	if err != nil {
+		return err
+	}
+	err = e.Export(stored, func(id string, val string, expiresAt int64) error {
+		return server.Send(&storage.ExportItem{
+			Id:        id,
+			Val:       []byte(val),
+			ExpiresAt: expiresAt,
+		})
+	})
+	if err != nil {
+		log.Err(err).Msgf("GRPCServer has encountered an error while exporting keygroup %#v", kg)
+		return err
+	}
+	return nil
+}
+
 // IDs calls specific method of the storage interface
 func (s Server) IDs(kg *storage.Keygroup, server storage.Database_IDsServer) error {
 	log.Debug().Msgf("GRPCServer: IDs in=%#v", kg)
-	res, err := s.store.IDs(kg.Keygroup)
+	ns, err := namespace(server.Context())
+	if err != nil {
+		return err
+	}
+	stored, err := s.keygroup(ns, kg.Keygroup)
+	if err != nil {
+		return err
+	}
+	res, err := s.store.IDs(stored)
 	if err != nil {
 		log.Err(err).Msgf("GRPCServer has encountered an error while reading IDs %#v", kg)
 		return err
@@ -133,16 +366,32 @@ func (s Server) IDs(kg *storage.Keygroup, server storage.Database_IDsServer) err
 }
 
 // Exists calls specific method of the storage interface
-func (s Server) Exists(_ context.Context, key *storage.Key) (*storage.Response, error) {
+func (s Server) Exists(ctx context.Context, key *storage.Key) (*storage.Response, error) {
 	log.Debug().Msgf("GRPCServer: Exists in=%#v", key)
-	exists := s.store.Exists(key.Keygroup, key.Id)
+	ns, err := namespace(ctx)
+	if err != nil {
+		return &storage.Response{Success: false}, err
+	}
+	stored, err := s.keygroup(ns, key.Keygroup)
+	if err != nil {
+		return &storage.Response{Success: false}, err
+	}
+	exists := s.store.Exists(stored, key.Id)
 	return &storage.Response{Success: exists}, nil
 }
 
 // CreateKeygroup calls specific method of the storage interface
-func (s Server) CreateKeygroup(_ context.Context, kg *storage.Keygroup) (*storage.Response, error) {
+func (s Server) CreateKeygroup(ctx context.Context, kg *storage.Keygroup) (*storage.Response, error) {
 	log.Debug().Msgf("GRPCServer: CreateKeygroup in=%#v", kg)
-	err := s.store.CreateKeygroup(kg.Keygroup)
+	ns, err := namespace(ctx)
+	if err != nil {
+		return &storage.Response{Success: false}, err
+	}
+	stored, err := s.keygroup(ns, kg.Keygroup)
+	if err != nil {
+		return &storage.Response{Success: false}, err
+	}
+	err = s.store.CreateKeygroup(stored)
 	if err != nil {
 		log.Err(err).Msgf("GRPCServer has encountered an error while creating keygroup %#v", kg)
 		return &storage.Response{Success: false}, err
@@ -151,9 +400,19 @@ func (s Server) CreateKeygroup(_ context.Context, kg *storage.Keygroup) (*storag
 }
 
 // DeleteKeygroup calls specific method of the storage interface
-func (s Server) DeleteKeygroup(_ context.Context, kg *storage.Keygroup) (*storage.Response, error) {
+func (s Server) DeleteKeygroup(ctx context.Context, kg *storage.Keygroup) (*storage.Response, error) {
 	log.Debug().Msgf("GRPCServer: DeleteKeygroup in=%#v", kg)
-	err := s.store.DeleteKeygroup(kg.Keygroup)
+	ns, err := namespace(ctx)
+	if err != nil {
+		return &storage.Response{Success: false}, err
+	}
+	stored, err := s.keygroup(ns, kg.Keygroup)
+	if err != nil {
+		return &storage.Response{Success: false}, err
+	}
+	err = s.q.drop(ns, stored, func() error {
+		return s.store.DeleteKeygroup(stored)
+	})
 	if err != nil {
 		log.Err(err).Msgf("GRPCServer has encountered an error while deleting keygroup %#v", kg)
 		return &storage.Response{Success: false}, err
@@ -164,14 +423,52 @@ func (s Server) DeleteKeygroup(_ context.Context, kg *storage.Keygroup) (*storag
 // ExistsKeygroup calls specific method of the storage interface
 func (s Server) ExistsKeygroup(ctx context.Context, kg *storage.Keygroup) (*storage.Response, error) {
 	log.Debug().Msgf("GRPCServer: ExistsKeygroup in=%#v", kg)
-	exists := s.store.ExistsKeygroup(kg.Keygroup)
+	ns, err := namespace(ctx)
+	if err != nil {
+		return &storage.Response{Success: false}, err
+	}
+	stored, err := s.keygroup(ns, kg.Keygroup)
+	if err != nil {
+		return &storage.Response{Success: false}, err
+	}
+	exists := s.store.ExistsKeygroup(stored)
 	return &storage.Response{Success: exists}, nil
 }
 
+// Keygroups calls specific method of the storage interface
+func (s Server) Keygroups(_ *storage.KeygroupsRequest, server storage.Database_KeygroupsServer) error {
+	log.Debug().Msg("GRPCServer: Keygroups")
+	ns, err := namespace(server.Context())
+	if err != nil {
+		return err
+	}
+	_, res, err := s.keygroups(ns)
+	if err != nil {
+		log.Err(err).Msg("GRPCServer has encountered an error while listing keygroups")
+		return err
+	}
+	for _, kg := range res {
+		err := server.Send(&storage.Keygroup{Keygroup: kg})
+		if err != nil {
+			return err
+		}
+	}
+	// Return nil == successful transfer
+	return nil
+}
+
 // AddKeygroupTrigger calls specific method of the storage interface.
 func (s *Server) AddKeygroupTrigger(ctx context.Context, t *storage.KeygroupTrigger) (*storage.Response, error) {
 	log.Debug().Msgf("GRPCServer: AddKeygroupTrigger in=%#v", t)
-	err := s.store.AddKeygroupTrigger(t.Keygroup, t.Trigger.Id, t.Trigger.Host)
+	ns, err := namespace(ctx)
+	if err != nil {
+		return &storage.Response{Success: false}, err
+	}
+	stored, err := s.keygroup(ns, t.Keygroup)
+	if err != nil {
+		return &storage.Response{Success: false}, err
+	}
+	err = s.store.AddKeygroupTrigger(stored, t.Trigger.Id, t.Trigger.Host)
 	if err != nil {
 		log.Err(err).Msgf("GRPCServer has encountered an error while adding trigger %#v", t)
 		return &storage.Response{Success: false}, err
@@ -182,7 +479,15 @@ func (s *Server) AddKeygroupTrigger(ctx context.Context, t *storage.KeygroupTrig
 // DeleteKeygroupTrigger calls specific method of the storage interface.
 func (s *Server) DeleteKeygroupTrigger(ctx context.Context, t *storage.KeygroupTrigger) (*storage.Response, error) {
 	log.Debug().Msgf("GRPCServer: DeleteKeygroupTrigger in=%#v", t)
-	err := s.store.DeleteKeygroupTrigger(t.Keygroup, t.Trigger.Id)
+	ns, err := namespace(ctx)
+	if err != nil {
+		return &storage.Response{Success: false}, err
+	}
+	stored, err := s.keygroup(ns, t.Keygroup)
+	if err != nil {
+		return &storage.Response{Success: false}, err
+	}
+	err = s.store.DeleteKeygroupTrigger(stored, t.Trigger.Id)
 	if err != nil {
 		log.Err(err).Msgf("GRPCServer has encountered an error while deleting trigger %#v", t)
 		return &storage.Response{Success: false}, err
@@ -194,7 +499,15 @@ func (s *Server) DeleteKeygroupTrigger(ctx context.Context, t *storage.KeygroupT
 func (s *Server) GetKeygroupTrigger(kg *storage.Keygroup, server storage.Database_GetKeygroupTriggerServer) error {
 	// Steam: call server.send for every trigger, return if none left.
 	log.Debug().Msgf("GRPCServer: GetKeygroupTrigger in=%#v", kg)
-	res, err := s.store.GetKeygroupTrigger(kg.Keygroup)
+	ns, err := namespace(server.Context())
+	if err != nil {
+		return err
+	}
+	stored, err := s.keygroup(ns, kg.Keygroup)
+	if err != nil {
+		return err
+	}
+	res, err := s.store.GetKeygroupTrigger(stored)
 	if err != nil {
 		log.Err(err).Msgf("GRPCServer has encountered an error while reading all triggers for keygroup %#v", kg)
 		return err
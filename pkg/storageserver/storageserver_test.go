@@ -0,0 +1,389 @@
+package storageserver_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"git.tu-berlin.de/mcc-fred/fred/pkg/badgerdb"
+	"git.tu-berlin.de/mcc-fred/fred/pkg/fred"
+	"git.tu-berlin.de/mcc-fred/fred/pkg/storageclient"
+	"git.tu-berlin.de/mcc-fred/fred/pkg/storageserver"
+	"git.tu-berlin.de/mcc-fred/fred/proto/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// testCA issues certificates for a storage server and its clients.
+type testCA struct {
+	dir  string
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	file string
+}
+
+func newTestCA(t *testing.T) *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	ca := &testCA{dir: t.TempDir(), cert: cert, key: key}
+	ca.file = filepath.Join(ca.dir, "ca.crt")
+	require.NoError(t, ioutil.WriteFile(ca.file, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+
+	return ca
+}
+
+// issue writes a certificate and key for a common name and returns their files.
+func (ca *testCA) issue(t *testing.T, name string, usage x509.ExtKeyUsage) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile := filepath.Join(ca.dir, name+".crt")
+	keyFile := filepath.Join(ca.dir, name+".key")
+
+	require.NoError(t, ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	require.NoError(t, ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600))
+
+	return certFile, keyFile
+}
+
+// startServer starts a storage server with an in-memory store and returns its address.
+func startServer(t *testing.T, ca *testCA, config storageserver.Config) string {
+	certFile, keyFile := ca.issue(t, "storageserver", x509.ExtKeyUsageServerAuth)
+
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	require.NoError(t, err)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.cert)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	var store fred.Store = badgerdb.NewMemory()
+
+	s := grpc.NewServer(grpc.Creds(credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    pool,
+		MinVersion:   tls.VersionTLS12,
+	})))
+
+	storage.RegisterDatabaseServer(s, storageserver.NewStorageServer(&store, config))
+
+	go func() {
+		_ = s.Serve(lis)
+	}()
+
+	t.Cleanup(func() {
+		s.Stop()
+		_ = store.Close()
+	})
+
+	return lis.Addr().String()
+}
+
+// connect connects to a storage server with a client certificate for a common name.
+func connect(t *testing.T, ca *testCA, addr string, name string) *storageclient.Client {
+	certFile, keyFile := ca.issue(t, name, x509.ExtKeyUsageClientAuth)
+
+	c := storageclient.NewClient(addr, certFile, keyFile, []string{ca.file})
+
+	t.Cleanup(c.Destroy)
+
+	return c
+}
+
+func TestNamespaces(t *testing.T) {
+	ca := newTestCA(t)
+	addr := startServer(t, ca, storageserver.Config{Legacy: "old"})
+
+	a := connect(t, ca, addr, "nodeA")
+	b := connect(t, ca, addr, "nodeB")
+	old := connect(t, ca, addr, "old")
+
+	// both nodes use the same keygroup name without seeing each other's data
+	for _, c := range []*storageclient.Client{a, b, old} {
+		require.NoError(t, c.CreateKeygroup("kg"))
+		require.NoError(t, c.AddKeygroupTrigger("kg", "t1", "host"))
+	}
+
+	require.NoError(t, a.Update("kg", "id", "a", false, 0))
+	require.NoError(t, b.Update("kg", "id", "b", false, 0))
+	require.NoError(t, old.Update("kg", "id", "old", false, 0))
+	require.NoError(t, a.Append("kg", "only-a", "a", 0))
+	require.NoError(t, a.CreateKeygroup("a-only"))
+
+	val, err := a.Read("kg", "id")
+	assert.NoError(t, err)
+	assert.Equal(t, "a", val)
+
+	val, err = b.Read("kg", "id")
+	assert.NoError(t, err)
+	assert.Equal(t, "b", val)
+
+	val, err = old.Read("kg", "id")
+	assert.NoError(t, err)
+	assert.Equal(t, "old", val)
+
+	assert.False(t, b.Exists("kg", "only-a"))
+	assert.False(t, b.ExistsKeygroup("a-only"))
+
+	all, err := b.ReadAll("kg")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"id": "b"}, all)
+
+	kgs, err := a.Keygroups()
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"kg", "a-only"}, kgs)
+
+	kgs, err = old.Keygroups()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"kg"}, kgs)
+
+	// deleting a keygroup only deletes it in the namespace of the client
+	require.NoError(t, a.DeleteKeygroup("kg"))
+	assert.False(t, a.ExistsKeygroup("kg"))
+	assert.True(t, b.ExistsKeygroup("kg"))
+
+	triggers, err := b.GetKeygroupTrigger("kg")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"t1": "host"}, triggers)
+}
+
+func TestNamespaceIsolation(t *testing.T) {
+	ca := newTestCA(t)
+	addr := startServer(t, ca, storageserver.Config{Legacy: "old"})
+
+	a := connect(t, ca, addr, "nodeA")
+	old := connect(t, ca, addr, "old")
+
+	require.NoError(t, a.CreateKeygroup("kg"))
+	require.NoError(t, a.Update("kg", "id", "a", false, 0))
+	require.NoError(t, a.AddKeygroupTrigger("kg", "t1", "host"))
+
+	// this is how the keygroup of nodeA is named in the store, but no client may use that name
+	for _, c := range []*storageclient.Client{a, old} {
+		stored := "|5:nodeA|kg"
+
+		_, err := c.Read(stored, "id")
+		assert.Error(t, err)
+		_, err = c.ReadAll(stored)
+		assert.Error(t, err)
+		_, err = c.IDs(stored)
+		assert.Error(t, err)
+		_, err = c.GetKeygroupTrigger(stored)
+		assert.Error(t, err)
+		assert.False(t, c.Exists(stored, "id"))
+		assert.False(t, c.ExistsKeygroup(stored))
+
+		assert.Error(t, c.CreateKeygroup(stored))
+		assert.Error(t, c.Update(stored, "id", "evil", false, 0))
+		assert.Error(t, c.Append(stored, "other", "evil", 0))
+		assert.Error(t, c.AddKeygroupTrigger(stored, "t2", "evil"))
+		assert.Error(t, c.DeleteKeygroupTrigger(stored, "t1"))
+		assert.Error(t, c.Delete(stored, "id"))
+		assert.Error(t, c.DeleteKeygroup(stored))
+	}
+
+	all, err := a.ReadAll("kg")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"id": "a"}, all)
+
+	triggers, err := a.GetKeygroupTrigger("kg")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"t1": "host"}, triggers)
+}
+
+func TestNamespaceQuotas(t *testing.T) {
+	ca := newTestCA(t)
+	addr := startServer(t, ca, storageserver.Config{
+		Quota:  storageserver.Quota{MaxItems: 3},
+		Quotas: map[string]storageserver.Quota{"big": {MaxBytes: 100}},
+	})
+
+	small := connect(t, ca, addr, "small")
+	big := connect(t, ca, addr, "big")
+
+	require.NoError(t, small.CreateKeygroup("kg"))
+	require.NoError(t, small.CreateKeygroup("other"))
+
+	// the quota covers all keygroups of a namespace
+	require.NoError(t, small.Update("kg", "1", "a", false, 0))
+	require.NoError(t, small.Update("kg", "2", "a", false, 0))
+	require.NoError(t, small.Update("other", "3", "a", false, 0))
+
+	err := small.Update("other", "4", "a", false, 0)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "quota exceeded")
+
+	// overwriting and deleting items is always possible
+	assert.NoError(t, small.Update("kg", "1", "b", false, 0))
+	assert.NoError(t, small.Delete("kg", "2"))
+	assert.NoError(t, small.Update("other", "4", "a", false, 0))
+
+	// so is deleting a keygroup, which frees its items
+	assert.NoError(t, small.DeleteKeygroup("other"))
+	require.NoError(t, small.CreateKeygroup("other"))
+	assert.NoError(t, small.Update("other", "5", "a", false, 0))
+	assert.NoError(t, small.Update("other", "6", "a", false, 0))
+
+	// namespaces can have their own quota
+	require.NoError(t, big.CreateKeygroup("kg"))
+
+	for i := 0; i < 5; i++ {
+		assert.NoError(t, big.Update("kg", strconv.Itoa(i), "0123456789", false, 0))
+	}
+
+	err = big.Update("kg", "too-much", string(make([]byte, 60)), false, 0)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "quota exceeded")
+}
+
+func TestNamespaceQuotaConcurrent(t *testing.T) {
+	ca := newTestCA(t)
+	addr := startServer(t, ca, storageserver.Config{Quota: storageserver.Quota{MaxItems: 10}})
+
+	c := connect(t, ca, addr, "nodeA")
+	require.NoError(t, c.CreateKeygroup("kg"))
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+			_ = c.Update("kg", strconv.Itoa(i), "val", false, 0)
+		}(i)
+	}
+
+	wg.Wait()
+
+	ids, err := c.IDs("kg")
+	assert.NoError(t, err)
+	assert.Len(t, ids, 10)
+}
+
+func TestUpdateBulk(t *testing.T) {
+	ca := newTestCA(t)
+	addr := startServer(t, ca, storageserver.Config{
+		Quotas: map[string]storageserver.Quota{"limited": {MaxItems: 50}},
+	})
+
+	a := connect(t, ca, addr, "nodeA")
+	limited := connect(t, ca, addr, "limited")
+
+	require.NoError(t, a.CreateKeygroup("kg"))
+
+	items := make([]fred.BulkItem, 100)
+
+	for i := range items {
+		items[i] = fred.BulkItem{Keygroup: "kg", ID: strconv.Itoa(i), Val: "val" + strconv.Itoa(i)}
+	}
+
+	items[0].Expiry = 100
+
+	require.NoError(t, a.UpdateBulk(items))
+
+	all, err := a.ReadAll("kg")
+	assert.NoError(t, err)
+	assert.Len(t, all, len(items))
+	assert.Equal(t, "val42", all["42"])
+
+	// items that do not fit into the quota stop the bulk update
+	require.NoError(t, limited.CreateKeygroup("kg"))
+
+	err = limited.UpdateBulk(items)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "quota exceeded")
+
+	ids, err := limited.IDs("kg")
+	assert.NoError(t, err)
+	assert.Len(t, ids, 50)
+}
+
+func TestExport(t *testing.T) {
+	ca := newTestCA(t)
+	addr := startServer(t, ca, storageserver.Config{})
+
+	a := connect(t, ca, addr, "nodeA")
+
+	require.NoError(t, a.CreateKeygroup("kg"))
+	require.NoError(t, a.Update("kg", "forever", "a", false, 0))
+	require.NoError(t, a.Update("kg", "expires", "b", false, 100))
+
+	expires := make(map[string]int64)
+
+	err := a.Export("kg", func(id string, val string, expiresAt int64) error {
+		expires[id] = expiresAt
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Len(t, expires, 2)
+	assert.Equal(t, int64(0), expires["forever"])
+	assert.InDelta(t, time.Now().Unix()+100, expires["expires"], 5)
+
+	// migrating from a storage server keeps the remaining time to live of items
+	to := badgerdb.NewMemory()
+	defer to.Close()
+
+	stats, err := fred.MigrateStore(a, to)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, stats.Items)
+
+	err = to.Export("kg", func(id string, val string, expiresAt int64) error {
+		assert.InDelta(t, expires[id], expiresAt, 5)
+		return nil
+	})
+	assert.NoError(t, err)
+}
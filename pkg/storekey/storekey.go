@@ -0,0 +1,118 @@
+// Package storekey turns keygroup names and item IDs into keys for key-value stores. Keygroup names are prefixed with
+// their length, so that IDs can contain any character and all items of a keygroup still share a common prefix, in the
+// order of their IDs. Everything else that a store keeps, such as the configuration of keygroups and trigger nodes, is
+// stored under meta keys that never collide with item keys.
+package storekey
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Version is the format version of keys. Stores keep it under VersionKey, so that they can tell whether their keys
+// need to be migrated. Stores without a version are from before keys were encoded and use LegacySep as separator.
+const (
+	Version    = 2
+	VersionKey = "|fred|version"
+	LegacySep  = "|"
+)
+
+// These are the kinds of meta keys.
+const (
+	KindKeygroup = "keygroup"
+	KindTriggers = "triggers"
+	KindRolling  = "rolling"
+)
+
+// metaPrefix starts every meta key. Item keys start with a digit, so they cannot be mistaken for meta keys.
+const metaPrefix = "|fred|"
+
+// Keygroup returns the prefix that the keys of all items in a keygroup start with.
+func Keygroup(kg string) string {
+	return strconv.Itoa(len(kg)) + ":" + kg
+}
+
+// Item returns the key of an item.
+func Item(kg string, id string) string {
+	return Keygroup(kg) + id
+}
+
+// splitKeygroup splits the keygroup name from the beginning of a key.
+func splitKeygroup(key string) (kg string, rest string, ok bool) {
+	i := strings.IndexByte(key, ':')
+
+	if i <= 0 {
+		return "", "", false
+	}
+
+	n, err := strconv.Atoi(key[:i])
+
+	if err != nil || n < 0 || i+1+n > len(key) {
+		return "", "", false
+	}
+
+	return key[i+1 : i+1+n], key[i+1+n:], true
+}
+
+// ParseItem returns the keygroup and ID of an item key.
+func ParseItem(key string) (kg string, id string, ok bool) {
+	return splitKeygroup(key)
+}
+
+// Meta returns a meta key of a kind for a keygroup. The ID is optional, e.g., the ID of a trigger node.
+func Meta(kind string, kg string, id string) string {
+	return MetaPrefix(kind) + Keygroup(kg) + id
+}
+
+// MetaPrefix returns the prefix that all meta keys of a kind start with, e.g., to list all keygroups in a store.
+func MetaPrefix(kind string) string {
+	return metaPrefix + kind + "|"
+}
+
+// ParseMeta returns the keygroup and ID of a meta key of a kind.
+func ParseMeta(kind string, key string) (kg string, id string, ok bool) {
+	prefix := MetaPrefix(kind)
+
+	if !strings.HasPrefix(key, prefix) {
+		return "", "", false
+	}
+
+	return splitKeygroup(key[len(prefix):])
+}
+
+// legacyName matches the keygroup names that could be stored before keys were encoded. Encoded keys have a ":" where
+// such a name would be, so they are never mistaken for keys that need to be migrated.
+var legacyName = regexp.MustCompile("^[^:]+$")
+
+// Migrate returns the current key for a key from a store without a version. If markers is set, the store marked
+// existing keygroups with an item with an empty ID, which becomes a keygroup meta key. Keys that are not from such a
+// store, e.g., because they have already been migrated, are returned with false.
+func Migrate(key string, markers bool) (string, bool) {
+	s := strings.Split(key, LegacySep)
+
+	if len(s) == 4 && s[0] == "" && s[1] == "fred" && legacyName.MatchString(s[3]) {
+		switch s[2] {
+		case KindKeygroup, KindRolling:
+			return Meta(s[2], s[3], ""), true
+		}
+	}
+
+	if len(s) == 5 && s[0] == "" && s[1] == "fred" && s[2] == KindTriggers && legacyName.MatchString(s[3]) {
+		return Meta(KindTriggers, s[3], s[4]), true
+	}
+
+	if len(s) != 2 || !legacyName.MatchString(s[0]) {
+		return "", false
+	}
+
+	if s[1] == "" {
+		if !markers {
+			return "", false
+		}
+
+		return Meta(KindKeygroup, s[0], ""), true
+	}
+
+	return Item(s[0], s[1]), true
+}
@@ -0,0 +1,124 @@
+package storekey
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestItem(t *testing.T) {
+	for _, c := range []struct {
+		kg string
+		id string
+	}{
+		{"kg", "id"},
+		{"kg", "a|b"},
+		{"kg", "alice@example.com"},
+		{"kg", "/home/alice/notes.txt"},
+		{"kg", "123e4567-e89b-12d3-a456-426614174000"},
+		{"my-keygroup.v2", "ünïcödé ✓"},
+		{"kg", "1:kg"},
+		{"kg", ""},
+	} {
+		key := Item(c.kg, c.id)
+
+		kg, id, ok := ParseItem(key)
+		assert.True(t, ok, key)
+		assert.Equal(t, c.kg, kg)
+		assert.Equal(t, c.id, id)
+
+		_, _, ok = ParseMeta(KindKeygroup, key)
+		assert.False(t, ok)
+	}
+}
+
+func TestPrefix(t *testing.T) {
+	// no keygroup's prefix is the prefix of an item in another keygroup
+	kgs := []string{"a", "ab", "a1", "1", "1:a", "a:", "10"}
+
+	for _, kg := range kgs {
+		for _, other := range kgs {
+			if kg == other {
+				continue
+			}
+
+			for _, id := range []string{"", "b", ":b", "0:b", "1:b"} {
+				k := Item(other, id)
+				assert.NotEqual(t, Keygroup(kg), k[:min(len(k), len(Keygroup(kg)))], "%s in %s", id, other)
+			}
+		}
+	}
+
+	// items of a keygroup are ordered by their IDs
+	ids := []string{"b", "a", "a|b", "ab", "aa", "B", "10", "9"}
+	keys := make([]string, len(ids))
+
+	for i, id := range ids {
+		keys[i] = Item("kg", id)
+	}
+
+	sort.Strings(ids)
+	sort.Strings(keys)
+
+	for i := range ids {
+		_, id, _ := ParseItem(keys[i])
+		assert.Equal(t, ids[i], id)
+	}
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
+
+func TestMeta(t *testing.T) {
+	key := Meta(KindTriggers, "kg|x", "trigger|1")
+
+	kg, id, ok := ParseMeta(KindTriggers, key)
+	assert.True(t, ok)
+	assert.Equal(t, "kg|x", kg)
+	assert.Equal(t, "trigger|1", id)
+	assert.True(t, strings.HasPrefix(key, MetaPrefix(KindTriggers)))
+
+	_, _, ok = ParseMeta(KindKeygroup, key)
+	assert.False(t, ok)
+
+	_, _, ok = ParseItem(key)
+	assert.False(t, ok)
+}
+
+func TestMigrate(t *testing.T) {
+	for legacy, current := range map[string]string{
+		"kg|id":                    Item("kg", "id"),
+		"_triggerqueue|0000001":    Item("_triggerqueue", "0000001"),
+		"|fred|keygroup|kg":        Meta(KindKeygroup, "kg", ""),
+		"|fred|rolling|kg":         Meta(KindRolling, "kg", ""),
+		"|fred|triggers|kg|t1":     Meta(KindTriggers, "kg", "t1"),
+		"|fred|triggers|test-kg|t": Meta(KindTriggers, "test-kg", "t"),
+	} {
+		k, ok := Migrate(legacy, false)
+		assert.True(t, ok, legacy)
+		assert.Equal(t, current, k)
+
+		// migrated keys stay as they are
+		_, ok = Migrate(current, true)
+		assert.False(t, ok, current)
+	}
+
+	_, ok := Migrate("kg|", false)
+	assert.False(t, ok)
+
+	k, ok := Migrate("kg|", true)
+	assert.True(t, ok)
+	assert.Equal(t, Meta(KindKeygroup, "kg", ""), k)
+
+	for _, key := range []string{VersionKey, Item("kg", "a|b"), Meta(KindTriggers, "kg", "a|b"), Item("kg", "")} {
+		_, ok := Migrate(key, true)
+		assert.False(t, ok, key)
+	}
+}
@@ -0,0 +1,110 @@
+package triggernode
+
+import (
+	"context"
+
+	"git.tu-berlin.de/mcc-fred/fred/proto/trigger"
+	"github.com/rs/zerolog/log"
+)
+
+// server implements the gRPC interface of trigger nodes on top of a Node.
+type server struct {
+	n *Node
+}
+
+// response turns the result of handling events into a response. acked is the sequence number of the last event that
+// has been handled.
+func response(err error, acked uint64) *trigger.TriggerResponse {
+	if err != nil {
+		log.Err(err).Msg("trigger node could not handle event")
+
+		return &trigger.TriggerResponse{
+			Status:       trigger.EnumTriggerStatus_TRIGGER_ERROR,
+			ErrorMessage: err.Error(),
+			AckedSeq:     acked,
+		}
+	}
+
+	return &trigger.TriggerResponse{
+		Status: trigger.EnumTriggerStatus_TRIGGER_OK,
+	}
+}
+
+func (s *server) PutItemTrigger(ctx context.Context, request *trigger.PutItemTriggerRequest) (*trigger.TriggerResponse, error) {
+	log.Debug().Msgf("Trigger Node has rcvd PutItem. In: %#v", request)
+
+	op := request.Operation
+
+	if op == "" {
+		op = OpPut
+	}
+
+	return response(s.n.handle(ctx, Event{
+		Keygroup:       request.Keygroup,
+		ID:             request.Id,
+		Val:            request.Val,
+		Op:             op,
+		Origin:         request.Origin,
+		Seq:            request.Seq,
+		IdempotencyKey: request.IdempotencyKey,
+	}), 0), nil
+}
+
+func (s *server) DeleteItemTrigger(ctx context.Context, request *trigger.DeleteItemTriggerRequest) (*trigger.TriggerResponse, error) {
+	log.Debug().Msgf("Trigger Node has rcvd DeleteItem. In: %#v", request)
+
+	op := request.Operation
+
+	if op == "" {
+		op = OpDelete
+	}
+
+	return response(s.n.handle(ctx, Event{
+		Keygroup:       request.Keygroup,
+		ID:             request.Id,
+		Op:             op,
+		Origin:         request.Origin,
+		Seq:            request.Seq,
+		IdempotencyKey: request.IdempotencyKey,
+	}), 0), nil
+}
+
+func (s *server) DeleteKeygroupTrigger(ctx context.Context, request *trigger.DeleteKeygroupTriggerRequest) (*trigger.TriggerResponse, error) {
+	log.Debug().Msgf("Trigger Node has rcvd DeleteKeygroup. In: %#v", request)
+
+	return response(s.n.handle(ctx, Event{
+		Keygroup:       request.Keygroup,
+		Op:             OpDeleteKeygroup,
+		Origin:         request.Origin,
+		Seq:            request.Seq,
+		IdempotencyKey: request.IdempotencyKey,
+	}), 0), nil
+}
+
+// BatchTrigger handles the events of a batch in order and stops at the first one that fails. The events before that
+// are acknowledged, so that FReD does not send them again.
+func (s *server) BatchTrigger(ctx context.Context, request *trigger.BatchTriggerRequest) (*trigger.TriggerResponse, error) {
+	log.Debug().Msgf("Trigger Node has rcvd Batch of %d events", len(request.Events))
+
+	var acked uint64
+
+	for _, e := range request.Events {
+		err := s.n.handle(ctx, Event{
+			Keygroup:       e.Keygroup,
+			ID:             e.Id,
+			Val:            e.Val,
+			Op:             e.Operation,
+			Origin:         e.Origin,
+			Seq:            e.Seq,
+			IdempotencyKey: e.IdempotencyKey,
+		})
+
+		if err != nil {
+			return response(err, acked), nil
+		}
+
+		acked = e.Seq
+	}
+
+	return response(nil, acked), nil
+}
@@ -0,0 +1,285 @@
+// Package triggernode makes it easy to write a trigger node for FReD. A trigger node implements a Handler that is
+// called for every event that FReD sends, and the Node takes care of the gRPC server, TLS, and batches. Optionally, it
+// also serves a log of all events it has handled over HTTP, which is useful for testing.
+package triggernode
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"net"
+	"net/http"
+	"sync"
+
+	"git.tu-berlin.de/mcc-fred/fred/pkg/certs"
+	"git.tu-berlin.de/mcc-fred/fred/proto/trigger"
+	"github.com/go-errors/errors"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// These are the operations of events.
+const (
+	OpPut            = "put"
+	OpAppend         = "append"
+	OpDelete         = "delete"
+	OpExpire         = "expire"
+	OpDeleteKeygroup = "deletekeygroup"
+)
+
+// Event is a change in a keygroup that FReD informs the trigger node about. Seq increases with every event of the
+// keygroup on the sending FReD node and the IdempotencyKey is the same whenever an event is sent again.
+type Event struct {
+	Keygroup       string
+	ID             string
+	Val            string
+	Op             string
+	Origin         string
+	Seq            uint64
+	IdempotencyKey string
+}
+
+// Handler handles events. OnPut is called for puts and appends, OnDelete for deletes and expiries. If a handler
+// returns an error, FReD sends the event again later.
+type Handler interface {
+	OnPut(ctx context.Context, e Event) error
+	OnDelete(ctx context.Context, e Event) error
+}
+
+// KeygroupHandler can be implemented by a Handler that also wants to know when its keygroup is deleted.
+type KeygroupHandler interface {
+	OnDeleteKeygroup(ctx context.Context, e Event) error
+}
+
+// LogEntry is one entry of the log of events that the trigger node has handled. Op is "put" for puts and appends,
+// "del" for deletes and expiries, and "delkg" for deleted keygroups.
+type LogEntry struct {
+	Op     string `json:"op"`
+	Kg     string `json:"kg"`
+	ID     string `json:"id"`
+	Val    string `json:"val"`
+	Origin string `json:"origin,omitempty"`
+	Seq    uint64 `json:"seq,omitempty"`
+}
+
+// Config configures a trigger node. The node either uses the TLS configuration or loads its certificate, key, and CA
+// certificates from the given files. LogHost is the address of the HTTP server for the log, if it is empty there is no
+// log.
+type Config struct {
+	Host     string
+	LogHost  string
+	CertFile string
+	KeyFile  string
+	CAFiles  []string
+	TLS      *tls.Config
+}
+
+// Node is a trigger node.
+type Node struct {
+	h        Handler
+	s        *grpc.Server
+	lis      net.Listener
+	web      *http.Server
+	webLis   net.Listener
+	provider *certs.Provider
+	sync.Mutex
+	log []LogEntry
+}
+
+// New creates a trigger node that passes events to h and starts listening on the configured addresses. Call Serve to
+// start handling events.
+func New(config Config, h Handler) (*Node, error) {
+	n := &Node{
+		h:   h,
+		log: []LogEntry{},
+	}
+
+	tlsConfig := config.TLS
+
+	if tlsConfig == nil {
+		if config.CertFile == "" || config.KeyFile == "" || len(config.CAFiles) == 0 {
+			return nil, errors.New("a trigger node needs either a TLS configuration or a certificate, key, and CA certificates")
+		}
+
+		p, err := certs.NewProvider(config.CertFile, config.KeyFile, config.CAFiles, nil)
+
+		if err != nil {
+			return nil, err
+		}
+
+		n.provider = p
+		tlsConfig = p.ServerConfig(tls.RequireAndVerifyClientCert)
+	}
+
+	lis, err := net.Listen("tcp", config.Host)
+
+	if err != nil {
+		n.close()
+		return nil, errors.Errorf("could not listen on %s: %v", config.Host, err)
+	}
+
+	n.lis = lis
+	n.s = grpc.NewServer(grpc.Creds(credentials.NewTLS(tlsConfig)))
+	trigger.RegisterTriggerNodeServer(n.s, &server{n: n})
+
+	if config.LogHost != "" {
+		webLis, err := net.Listen("tcp", config.LogHost)
+
+		if err != nil {
+			n.close()
+			return nil, errors.Errorf("could not listen on %s: %v", config.LogHost, err)
+		}
+
+		mux := http.NewServeMux()
+		mux.HandleFunc("/", n.serveLog)
+
+		n.webLis = webLis
+		n.web = &http.Server{Handler: mux}
+	}
+
+	return n, nil
+}
+
+// close releases everything that New has set up.
+func (n *Node) close() {
+	if n.lis != nil {
+		_ = n.lis.Close()
+	}
+
+	if n.provider != nil {
+		n.provider.Close()
+	}
+}
+
+// Addr returns the address that the trigger node listens on for events.
+func (n *Node) Addr() string {
+	return n.lis.Addr().String()
+}
+
+// LogAddr returns the address of the HTTP server for the log, or an empty string if there is none.
+func (n *Node) LogAddr() string {
+	if n.webLis == nil {
+		return ""
+	}
+
+	return n.webLis.Addr().String()
+}
+
+// Serve handles events until the node is stopped. It returns nil after Stop. If the log server fails, the node stops
+// as well.
+func (n *Node) Serve() error {
+	errs := make(chan error, 1)
+
+	if n.web != nil {
+		go func() {
+			if err := n.web.Serve(n.webLis); err != nil && err != http.ErrServerClosed {
+				errs <- errors.New(err)
+				n.s.Stop()
+				return
+			}
+
+			errs <- nil
+		}()
+	}
+
+	log.Debug().Msgf("trigger node is listening on %s", n.Addr())
+
+	if err := n.s.Serve(n.lis); err != nil {
+		return errors.New(err)
+	}
+
+	if n.web != nil {
+		return <-errs
+	}
+
+	return nil
+}
+
+// Stop lets the events that are being handled finish and then stops the node. If the context ends before that, the
+// remaining connections are closed.
+func (n *Node) Stop(ctx context.Context) error {
+	done := make(chan struct{})
+
+	go func() {
+		n.s.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		n.s.Stop()
+	}
+
+	defer n.close()
+
+	if n.web != nil {
+		if err := n.web.Shutdown(ctx); err != nil {
+			return errors.New(err)
+		}
+	}
+
+	return nil
+}
+
+// Log returns all events that the trigger node has handled so far.
+func (n *Node) Log() []LogEntry {
+	n.Lock()
+	defer n.Unlock()
+
+	return append([]LogEntry{}, n.log...)
+}
+
+func (n *Node) serveLog(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(n.Log()); err != nil {
+		log.Err(err).Msg("error getting logs")
+	}
+}
+
+// handle passes an event to the handler and logs it if the handler succeeds.
+func (n *Node) handle(ctx context.Context, e Event) error {
+	var err error
+	var op string
+
+	switch e.Op {
+	case OpDelete, OpExpire:
+		op = "del"
+
+		if n.h != nil {
+			err = n.h.OnDelete(ctx, e)
+		}
+	case OpDeleteKeygroup:
+		op = "delkg"
+
+		if k, ok := n.h.(KeygroupHandler); ok {
+			err = k.OnDeleteKeygroup(ctx, e)
+		}
+	default:
+		// older FReD nodes do not send an operation for puts
+		op = "put"
+
+		if n.h != nil {
+			err = n.h.OnPut(ctx, e)
+		}
+	}
+
+	if err != nil {
+		return err
+	}
+
+	n.Lock()
+	n.log = append(n.log, LogEntry{
+		Op:     op,
+		Kg:     e.Keygroup,
+		ID:     e.ID,
+		Val:    e.Val,
+		Origin: e.Origin,
+		Seq:    e.Seq,
+	})
+	n.Unlock()
+
+	return nil
+}
@@ -0,0 +1,143 @@
+package triggernode_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"git.tu-berlin.de/mcc-fred/fred/pkg/certs"
+	"git.tu-berlin.de/mcc-fred/fred/pkg/triggernode"
+	"git.tu-berlin.de/mcc-fred/fred/proto/trigger"
+	"github.com/go-errors/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+const certBasePath = "../../tests/runner/certificates/"
+
+// failingHandler fails for all items with the ID "bad".
+type failingHandler struct{}
+
+func (failingHandler) OnPut(_ context.Context, e triggernode.Event) error {
+	if e.ID == "bad" {
+		return errors.New("bad item")
+	}
+
+	return nil
+}
+
+func (failingHandler) OnDelete(_ context.Context, _ triggernode.Event) error {
+	return nil
+}
+
+// start starts a trigger node and returns it with a client to send it events.
+func start(t *testing.T) (*triggernode.Node, trigger.TriggerNodeClient, chan error) {
+	n, err := triggernode.New(triggernode.Config{
+		Host:     "127.0.0.1:0",
+		LogHost:  "127.0.0.1:0",
+		CertFile: certBasePath + "nodeA.crt",
+		KeyFile:  certBasePath + "nodeA.key",
+		CAFiles:  []string{certBasePath + "ca.crt"},
+	}, failingHandler{})
+
+	require.NoError(t, err)
+
+	served := make(chan error, 1)
+
+	go func() {
+		served <- n.Serve()
+	}()
+
+	p, err := certs.NewProvider(certBasePath+"nodeA.crt", certBasePath+"nodeA.key", []string{certBasePath + "ca.crt"}, nil)
+	require.NoError(t, err)
+
+	conn, err := grpc.Dial(n.Addr(), grpc.WithTransportCredentials(credentials.NewTLS(p.ClientConfig(false))))
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = conn.Close()
+		p.Close()
+	})
+
+	return n, trigger.NewTriggerNodeClient(conn), served
+}
+
+func TestNewWithoutTLS(t *testing.T) {
+	_, err := triggernode.New(triggernode.Config{Host: "127.0.0.1:0"}, failingHandler{})
+	assert.Error(t, err)
+}
+
+func TestEvents(t *testing.T) {
+	n, c, served := start(t)
+
+	ctx := context.Background()
+
+	res, err := c.PutItemTrigger(ctx, &trigger.PutItemTriggerRequest{Keygroup: "kg", Id: "a", Val: "1", Origin: "X", Seq: 1})
+	require.NoError(t, err)
+	assert.Equal(t, trigger.EnumTriggerStatus_TRIGGER_OK, res.Status)
+
+	res, err = c.PutItemTrigger(ctx, &trigger.PutItemTriggerRequest{Keygroup: "kg", Id: "bad", Val: "1"})
+	require.NoError(t, err)
+	assert.Equal(t, trigger.EnumTriggerStatus_TRIGGER_ERROR, res.Status)
+	assert.Equal(t, "bad item", res.ErrorMessage)
+
+	_, err = c.DeleteItemTrigger(ctx, &trigger.DeleteItemTriggerRequest{Keygroup: "kg", Id: "a", Operation: "expire"})
+	assert.NoError(t, err)
+
+	// failingHandler does not implement KeygroupHandler, the event is still logged
+	_, err = c.DeleteKeygroupTrigger(ctx, &trigger.DeleteKeygroupTriggerRequest{Keygroup: "kg"})
+	assert.NoError(t, err)
+
+	expected := []triggernode.LogEntry{
+		{Op: "put", Kg: "kg", ID: "a", Val: "1", Origin: "X", Seq: 1},
+		{Op: "del", Kg: "kg", ID: "a"},
+		{Op: "delkg", Kg: "kg"},
+	}
+
+	assert.Equal(t, expected, n.Log())
+
+	r, err := http.Get("http://" + n.LogAddr() + "/")
+	require.NoError(t, err)
+
+	var logged []triggernode.LogEntry
+	assert.NoError(t, json.NewDecoder(r.Body).Decode(&logged))
+	assert.NoError(t, r.Body.Close())
+	assert.Equal(t, expected, logged)
+
+	ctx, cncl := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cncl()
+
+	assert.NoError(t, n.Stop(ctx))
+	assert.NoError(t, <-served)
+}
+
+func TestBatch(t *testing.T) {
+	n, c, served := start(t)
+
+	res, err := c.BatchTrigger(context.Background(), &trigger.BatchTriggerRequest{Events: []*trigger.TriggerEvent{
+		{Keygroup: "kg", Id: "a", Val: "1", Operation: "put", Seq: 4},
+		{Keygroup: "kg", Id: "a", Operation: "delete", Seq: 5},
+		{Keygroup: "kg", Id: "bad", Val: "1", Operation: "append", Seq: 6},
+		{Keygroup: "kg", Id: "c", Val: "1", Operation: "put", Seq: 7},
+	}})
+
+	// the batch stops at the broken event and acknowledges the ones before it
+	require.NoError(t, err)
+	assert.Equal(t, trigger.EnumTriggerStatus_TRIGGER_ERROR, res.Status)
+	assert.Equal(t, uint64(5), res.AckedSeq)
+
+	assert.Equal(t, []triggernode.LogEntry{
+		{Op: "put", Kg: "kg", ID: "a", Val: "1", Seq: 4},
+		{Op: "del", Kg: "kg", ID: "a", Seq: 5},
+	}, n.Log())
+
+	ctx, cncl := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cncl()
+
+	assert.NoError(t, n.Stop(ctx))
+	assert.NoError(t, <-served)
+}
@@ -28,6 +28,7 @@ type ClientClient interface {
 	AddReplica(ctx context.Context, in *AddReplicaRequest, opts ...grpc.CallOption) (*StatusResponse, error)
 	GetKeygroupReplica(ctx context.Context, in *GetKeygroupReplicaRequest, opts ...grpc.CallOption) (*GetKeygroupReplicaResponse, error)
 	RemoveReplica(ctx context.Context, in *RemoveReplicaRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	GetKeygroupUsage(ctx context.Context, in *GetKeygroupUsageRequest, opts ...grpc.CallOption) (*GetKeygroupUsageResponse, error)
 	GetReplica(ctx context.Context, in *GetReplicaRequest, opts ...grpc.CallOption) (*GetReplicaResponse, error)
 	GetAllReplica(ctx context.Context, in *GetAllReplicaRequest, opts ...grpc.CallOption) (*GetAllReplicaResponse, error)
 	GetKeygroupTriggers(ctx context.Context, in *GetKeygroupTriggerRequest, opts ...grpc.CallOption) (*GetKeygroupTriggerResponse, error)
@@ -35,6 +36,18 @@ type ClientClient interface {
 	RemoveTrigger(ctx context.Context, in *RemoveTriggerRequest, opts ...grpc.CallOption) (*StatusResponse, error)
 	AddUser(ctx context.Context, in *UserRequest, opts ...grpc.CallOption) (*StatusResponse, error)
 	RemoveUser(ctx context.Context, in *UserRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	GetKeygroupPermissions(ctx context.Context, in *GetKeygroupPermissionsRequest, opts ...grpc.CallOption) (*GetKeygroupPermissionsResponse, error)
+	AddRole(ctx context.Context, in *AddRoleRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	RemoveRole(ctx context.Context, in *RemoveRoleRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	GetRoles(ctx context.Context, in *GetRolesRequest, opts ...grpc.CallOption) (*GetRolesResponse, error)
+	AddGroupMember(ctx context.Context, in *GroupMemberRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	RemoveGroupMember(ctx context.Context, in *GroupMemberRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	GetGroupMembers(ctx context.Context, in *GetGroupMembersRequest, opts ...grpc.CallOption) (*GetGroupMembersResponse, error)
+	GetAuditLog(ctx context.Context, in *GetAuditLogRequest, opts ...grpc.CallOption) (*GetAuditLogResponse, error)
+	GetFailedTriggerEvents(ctx context.Context, in *GetFailedTriggerEventsRequest, opts ...grpc.CallOption) (*GetFailedTriggerEventsResponse, error)
+	ReplayTriggerEvents(ctx context.Context, in *ReplayTriggerEventsRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	Backup(ctx context.Context, in *BackupRequest, opts ...grpc.CallOption) (Client_BackupClient, error)
+	Restore(ctx context.Context, opts ...grpc.CallOption) (Client_RestoreClient, error)
 }
 
 type clientClient struct {
@@ -135,6 +148,15 @@ func (c *clientClient) RemoveReplica(ctx context.Context, in *RemoveReplicaReque
 	return out, nil
 }
 
+func (c *clientClient) GetKeygroupUsage(ctx context.Context, in *GetKeygroupUsageRequest, opts ...grpc.CallOption) (*GetKeygroupUsageResponse, error) {
+	out := new(GetKeygroupUsageResponse)
+	err := c.cc.Invoke(ctx, "/mcc.fred.client.Client/GetKeygroupUsage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *clientClient) GetReplica(ctx context.Context, in *GetReplicaRequest, opts ...grpc.CallOption) (*GetReplicaResponse, error) {
 	out := new(GetReplicaResponse)
 	err := c.cc.Invoke(ctx, "/mcc.fred.client.Client/GetReplica", in, out, opts...)
@@ -198,6 +220,162 @@ func (c *clientClient) RemoveUser(ctx context.Context, in *UserRequest, opts ...
 	return out, nil
 }
 
+func (c *clientClient) GetKeygroupPermissions(ctx context.Context, in *GetKeygroupPermissionsRequest, opts ...grpc.CallOption) (*GetKeygroupPermissionsResponse, error) {
+	out := new(GetKeygroupPermissionsResponse)
+	err := c.cc.Invoke(ctx, "/mcc.fred.client.Client/GetKeygroupPermissions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientClient) AddRole(ctx context.Context, in *AddRoleRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, "/mcc.fred.client.Client/AddRole", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientClient) RemoveRole(ctx context.Context, in *RemoveRoleRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, "/mcc.fred.client.Client/RemoveRole", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientClient) GetRoles(ctx context.Context, in *GetRolesRequest, opts ...grpc.CallOption) (*GetRolesResponse, error) {
+	out := new(GetRolesResponse)
+	err := c.cc.Invoke(ctx, "/mcc.fred.client.Client/GetRoles", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientClient) AddGroupMember(ctx context.Context, in *GroupMemberRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, "/mcc.fred.client.Client/AddGroupMember", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientClient) RemoveGroupMember(ctx context.Context, in *GroupMemberRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, "/mcc.fred.client.Client/RemoveGroupMember", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientClient) GetGroupMembers(ctx context.Context, in *GetGroupMembersRequest, opts ...grpc.CallOption) (*GetGroupMembersResponse, error) {
+	out := new(GetGroupMembersResponse)
+	err := c.cc.Invoke(ctx, "/mcc.fred.client.Client/GetGroupMembers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientClient) GetAuditLog(ctx context.Context, in *GetAuditLogRequest, opts ...grpc.CallOption) (*GetAuditLogResponse, error) {
+	out := new(GetAuditLogResponse)
+	err := c.cc.Invoke(ctx, "/mcc.fred.client.Client/GetAuditLog", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientClient) GetFailedTriggerEvents(ctx context.Context, in *GetFailedTriggerEventsRequest, opts ...grpc.CallOption) (*GetFailedTriggerEventsResponse, error) {
+	out := new(GetFailedTriggerEventsResponse)
+	err := c.cc.Invoke(ctx, "/mcc.fred.client.Client/GetFailedTriggerEvents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientClient) ReplayTriggerEvents(ctx context.Context, in *ReplayTriggerEventsRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, "/mcc.fred.client.Client/ReplayTriggerEvents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientClient) Backup(ctx context.Context, in *BackupRequest, opts ...grpc.CallOption) (Client_BackupClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Client_ServiceDesc.Streams[0], "/mcc.fred.client.Client/Backup", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &clientBackupClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Client_BackupClient interface {
+	Recv() (*BackupChunk, error)
+	grpc.ClientStream
+}
+
+type clientBackupClient struct {
+	grpc.ClientStream
+}
+
+func (x *clientBackupClient) Recv() (*BackupChunk, error) {
+	m := new(BackupChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *clientClient) Restore(ctx context.Context, opts ...grpc.CallOption) (Client_RestoreClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Client_ServiceDesc.Streams[1], "/mcc.fred.client.Client/Restore", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &clientRestoreClient{stream}
+	return x, nil
+}
+
+type Client_RestoreClient interface {
+	Send(*BackupChunk) error
+	CloseAndRecv() (*RestoreResponse, error)
+	grpc.ClientStream
+}
+
+type clientRestoreClient struct {
+	grpc.ClientStream
+}
+
+func (x *clientRestoreClient) Send(m *BackupChunk) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *clientRestoreClient) CloseAndRecv() (*RestoreResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(RestoreResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // ClientServer is the server API for Client service.
 // All implementations should embed UnimplementedClientServer
 // for forward compatibility
@@ -212,6 +390,7 @@ type ClientServer interface {
 	AddReplica(context.Context, *AddReplicaRequest) (*StatusResponse, error)
 	GetKeygroupReplica(context.Context, *GetKeygroupReplicaRequest) (*GetKeygroupReplicaResponse, error)
 	RemoveReplica(context.Context, *RemoveReplicaRequest) (*StatusResponse, error)
+	GetKeygroupUsage(context.Context, *GetKeygroupUsageRequest) (*GetKeygroupUsageResponse, error)
 	GetReplica(context.Context, *GetReplicaRequest) (*GetReplicaResponse, error)
 	GetAllReplica(context.Context, *GetAllReplicaRequest) (*GetAllReplicaResponse, error)
 	GetKeygroupTriggers(context.Context, *GetKeygroupTriggerRequest) (*GetKeygroupTriggerResponse, error)
@@ -219,6 +398,18 @@ type ClientServer interface {
 	RemoveTrigger(context.Context, *RemoveTriggerRequest) (*StatusResponse, error)
 	AddUser(context.Context, *UserRequest) (*StatusResponse, error)
 	RemoveUser(context.Context, *UserRequest) (*StatusResponse, error)
+	GetKeygroupPermissions(context.Context, *GetKeygroupPermissionsRequest) (*GetKeygroupPermissionsResponse, error)
+	AddRole(context.Context, *AddRoleRequest) (*StatusResponse, error)
+	RemoveRole(context.Context, *RemoveRoleRequest) (*StatusResponse, error)
+	GetRoles(context.Context, *GetRolesRequest) (*GetRolesResponse, error)
+	AddGroupMember(context.Context, *GroupMemberRequest) (*StatusResponse, error)
+	RemoveGroupMember(context.Context, *GroupMemberRequest) (*StatusResponse, error)
+	GetGroupMembers(context.Context, *GetGroupMembersRequest) (*GetGroupMembersResponse, error)
+	GetAuditLog(context.Context, *GetAuditLogRequest) (*GetAuditLogResponse, error)
+	GetFailedTriggerEvents(context.Context, *GetFailedTriggerEventsRequest) (*GetFailedTriggerEventsResponse, error)
+	ReplayTriggerEvents(context.Context, *ReplayTriggerEventsRequest) (*StatusResponse, error)
+	Backup(*BackupRequest, Client_BackupServer) error
+	Restore(Client_RestoreServer) error
 }
 
 // UnimplementedClientServer should be embedded to have forward compatible implementations.
@@ -255,6 +446,9 @@ func (UnimplementedClientServer) GetKeygroupReplica(context.Context, *GetKeygrou
 func (UnimplementedClientServer) RemoveReplica(context.Context, *RemoveReplicaRequest) (*StatusResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RemoveReplica not implemented")
 }
+func (UnimplementedClientServer) GetKeygroupUsage(context.Context, *GetKeygroupUsageRequest) (*GetKeygroupUsageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetKeygroupUsage not implemented")
+}
 func (UnimplementedClientServer) GetReplica(context.Context, *GetReplicaRequest) (*GetReplicaResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetReplica not implemented")
 }
@@ -276,6 +470,42 @@ func (UnimplementedClientServer) AddUser(context.Context, *UserRequest) (*Status
 func (UnimplementedClientServer) RemoveUser(context.Context, *UserRequest) (*StatusResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RemoveUser not implemented")
 }
+func (UnimplementedClientServer) GetKeygroupPermissions(context.Context, *GetKeygroupPermissionsRequest) (*GetKeygroupPermissionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetKeygroupPermissions not implemented")
+}
+func (UnimplementedClientServer) AddRole(context.Context, *AddRoleRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddRole not implemented")
+}
+func (UnimplementedClientServer) RemoveRole(context.Context, *RemoveRoleRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveRole not implemented")
+}
+func (UnimplementedClientServer) GetRoles(context.Context, *GetRolesRequest) (*GetRolesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRoles not implemented")
+}
+func (UnimplementedClientServer) AddGroupMember(context.Context, *GroupMemberRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddGroupMember not implemented")
+}
+func (UnimplementedClientServer) RemoveGroupMember(context.Context, *GroupMemberRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveGroupMember not implemented")
+}
+func (UnimplementedClientServer) GetGroupMembers(context.Context, *GetGroupMembersRequest) (*GetGroupMembersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGroupMembers not implemented")
+}
+func (UnimplementedClientServer) GetAuditLog(context.Context, *GetAuditLogRequest) (*GetAuditLogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAuditLog not implemented")
+}
+func (UnimplementedClientServer) GetFailedTriggerEvents(context.Context, *GetFailedTriggerEventsRequest) (*GetFailedTriggerEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFailedTriggerEvents not implemented")
+}
+func (UnimplementedClientServer) ReplayTriggerEvents(context.Context, *ReplayTriggerEventsRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReplayTriggerEvents not implemented")
+}
+func (UnimplementedClientServer) Backup(*BackupRequest, Client_BackupServer) error {
+	return status.Errorf(codes.Unimplemented, "method Backup not implemented")
+}
+func (UnimplementedClientServer) Restore(Client_RestoreServer) error {
+	return status.Errorf(codes.Unimplemented, "method Restore not implemented")
+}
 
 // UnsafeClientServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to ClientServer will
@@ -468,6 +698,24 @@ func _Client_RemoveReplica_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Client_GetKeygroupUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetKeygroupUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientServer).GetKeygroupUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mcc.fred.client.Client/GetKeygroupUsage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientServer).GetKeygroupUsage(ctx, req.(*GetKeygroupUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Client_GetReplica_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetReplicaRequest)
 	if err := dec(in); err != nil {
@@ -594,6 +842,233 @@ func _Client_RemoveUser_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Client_GetKeygroupPermissions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetKeygroupPermissionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientServer).GetKeygroupPermissions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mcc.fred.client.Client/GetKeygroupPermissions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientServer).GetKeygroupPermissions(ctx, req.(*GetKeygroupPermissionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Client_AddRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddRoleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientServer).AddRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mcc.fred.client.Client/AddRole",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientServer).AddRole(ctx, req.(*AddRoleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Client_RemoveRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveRoleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientServer).RemoveRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mcc.fred.client.Client/RemoveRole",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientServer).RemoveRole(ctx, req.(*RemoveRoleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Client_GetRoles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRolesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientServer).GetRoles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mcc.fred.client.Client/GetRoles",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientServer).GetRoles(ctx, req.(*GetRolesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Client_AddGroupMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GroupMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientServer).AddGroupMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mcc.fred.client.Client/AddGroupMember",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientServer).AddGroupMember(ctx, req.(*GroupMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Client_RemoveGroupMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GroupMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientServer).RemoveGroupMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mcc.fred.client.Client/RemoveGroupMember",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientServer).RemoveGroupMember(ctx, req.(*GroupMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Client_GetGroupMembers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGroupMembersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientServer).GetGroupMembers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mcc.fred.client.Client/GetGroupMembers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientServer).GetGroupMembers(ctx, req.(*GetGroupMembersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Client_GetAuditLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAuditLogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientServer).GetAuditLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mcc.fred.client.Client/GetAuditLog",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientServer).GetAuditLog(ctx, req.(*GetAuditLogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Client_GetFailedTriggerEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFailedTriggerEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientServer).GetFailedTriggerEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mcc.fred.client.Client/GetFailedTriggerEvents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientServer).GetFailedTriggerEvents(ctx, req.(*GetFailedTriggerEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Client_ReplayTriggerEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplayTriggerEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientServer).ReplayTriggerEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mcc.fred.client.Client/ReplayTriggerEvents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientServer).ReplayTriggerEvents(ctx, req.(*ReplayTriggerEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Client_Backup_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(BackupRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ClientServer).Backup(m, &clientBackupServer{stream})
+}
+
+type Client_BackupServer interface {
+	Send(*BackupChunk) error
+	grpc.ServerStream
+}
+
+type clientBackupServer struct {
+	grpc.ServerStream
+}
+
+func (x *clientBackupServer) Send(m *BackupChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Client_Restore_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ClientServer).Restore(&clientRestoreServer{stream})
+}
+
+type Client_RestoreServer interface {
+	SendAndClose(*RestoreResponse) error
+	Recv() (*BackupChunk, error)
+	grpc.ServerStream
+}
+
+type clientRestoreServer struct {
+	grpc.ServerStream
+}
+
+func (x *clientRestoreServer) SendAndClose(m *RestoreResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *clientRestoreServer) Recv() (*BackupChunk, error) {
+	m := new(BackupChunk)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // Client_ServiceDesc is the grpc.ServiceDesc for Client service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -641,6 +1116,10 @@ var Client_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RemoveReplica",
 			Handler:    _Client_RemoveReplica_Handler,
 		},
+		{
+			MethodName: "GetKeygroupUsage",
+			Handler:    _Client_GetKeygroupUsage_Handler,
+		},
 		{
 			MethodName: "GetReplica",
 			Handler:    _Client_GetReplica_Handler,
@@ -669,7 +1148,58 @@ var Client_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RemoveUser",
 			Handler:    _Client_RemoveUser_Handler,
 		},
+		{
+			MethodName: "GetKeygroupPermissions",
+			Handler:    _Client_GetKeygroupPermissions_Handler,
+		},
+		{
+			MethodName: "AddRole",
+			Handler:    _Client_AddRole_Handler,
+		},
+		{
+			MethodName: "RemoveRole",
+			Handler:    _Client_RemoveRole_Handler,
+		},
+		{
+			MethodName: "GetRoles",
+			Handler:    _Client_GetRoles_Handler,
+		},
+		{
+			MethodName: "AddGroupMember",
+			Handler:    _Client_AddGroupMember_Handler,
+		},
+		{
+			MethodName: "RemoveGroupMember",
+			Handler:    _Client_RemoveGroupMember_Handler,
+		},
+		{
+			MethodName: "GetGroupMembers",
+			Handler:    _Client_GetGroupMembers_Handler,
+		},
+		{
+			MethodName: "GetAuditLog",
+			Handler:    _Client_GetAuditLog_Handler,
+		},
+		{
+			MethodName: "GetFailedTriggerEvents",
+			Handler:    _Client_GetFailedTriggerEvents_Handler,
+		},
+		{
+			MethodName: "ReplayTriggerEvents",
+			Handler:    _Client_ReplayTriggerEvents_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Backup",
+			Handler:       _Client_Backup_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Restore",
+			Handler:       _Client_Restore_Handler,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "client.proto",
 }
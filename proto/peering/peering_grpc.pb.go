@@ -27,6 +27,7 @@ type NodeClient interface {
 	DeleteItem(ctx context.Context, in *DeleteItemRequest, opts ...grpc.CallOption) (*Empty, error)
 	AddReplica(ctx context.Context, in *AddReplicaRequest, opts ...grpc.CallOption) (*Empty, error)
 	RemoveReplica(ctx context.Context, in *RemoveReplicaRequest, opts ...grpc.CallOption) (*Empty, error)
+	GetKeygroupUsage(ctx context.Context, in *GetKeygroupUsageRequest, opts ...grpc.CallOption) (*KeygroupUsage, error)
 }
 
 type nodeClient struct {
@@ -118,6 +119,15 @@ func (c *nodeClient) RemoveReplica(ctx context.Context, in *RemoveReplicaRequest
 	return out, nil
 }
 
+func (c *nodeClient) GetKeygroupUsage(ctx context.Context, in *GetKeygroupUsageRequest, opts ...grpc.CallOption) (*KeygroupUsage, error) {
+	out := new(KeygroupUsage)
+	err := c.cc.Invoke(ctx, "/mcc.fred.peering.Node/GetKeygroupUsage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // NodeServer is the server API for Node service.
 // All implementations should embed UnimplementedNodeServer
 // for forward compatibility
@@ -131,6 +141,7 @@ type NodeServer interface {
 	DeleteItem(context.Context, *DeleteItemRequest) (*Empty, error)
 	AddReplica(context.Context, *AddReplicaRequest) (*Empty, error)
 	RemoveReplica(context.Context, *RemoveReplicaRequest) (*Empty, error)
+	GetKeygroupUsage(context.Context, *GetKeygroupUsageRequest) (*KeygroupUsage, error)
 }
 
 // UnimplementedNodeServer should be embedded to have forward compatible implementations.
@@ -164,6 +175,9 @@ func (UnimplementedNodeServer) AddReplica(context.Context, *AddReplicaRequest) (
 func (UnimplementedNodeServer) RemoveReplica(context.Context, *RemoveReplicaRequest) (*Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RemoveReplica not implemented")
 }
+func (UnimplementedNodeServer) GetKeygroupUsage(context.Context, *GetKeygroupUsageRequest) (*KeygroupUsage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetKeygroupUsage not implemented")
+}
 
 // UnsafeNodeServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to NodeServer will
@@ -338,6 +352,24 @@ func _Node_RemoveReplica_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Node_GetKeygroupUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetKeygroupUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).GetKeygroupUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mcc.fred.peering.Node/GetKeygroupUsage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).GetKeygroupUsage(ctx, req.(*GetKeygroupUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Node_ServiceDesc is the grpc.ServiceDesc for Node service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -381,6 +413,10 @@ var Node_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RemoveReplica",
 			Handler:    _Node_RemoveReplica_Handler,
 		},
+		{
+			MethodName: "GetKeygroupUsage",
+			Handler:    _Node_GetKeygroupUsage_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "peering.proto",
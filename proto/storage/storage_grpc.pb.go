@@ -20,7 +20,7 @@ const _ = grpc.SupportPackageIsVersion7
 type DatabaseClient interface {
 	Update(ctx context.Context, in *UpdateItem, opts ...grpc.CallOption) (*Response, error)
 	Delete(ctx context.Context, in *Key, opts ...grpc.CallOption) (*Response, error)
-	Append(ctx context.Context, in *AppendItem, opts ...grpc.CallOption) (*Key, error)
+	Append(ctx context.Context, in *AppendItem, opts ...grpc.CallOption) (*Response, error)
 	Read(ctx context.Context, in *Key, opts ...grpc.CallOption) (*Val, error)
 	Scan(ctx context.Context, in *ScanRequest, opts ...grpc.CallOption) (Database_ScanClient, error)
 	ReadAll(ctx context.Context, in *Keygroup, opts ...grpc.CallOption) (Database_ReadAllClient, error)
@@ -29,9 +29,12 @@ type DatabaseClient interface {
 	CreateKeygroup(ctx context.Context, in *Keygroup, opts ...grpc.CallOption) (*Response, error)
 	DeleteKeygroup(ctx context.Context, in *Keygroup, opts ...grpc.CallOption) (*Response, error)
 	ExistsKeygroup(ctx context.Context, in *Keygroup, opts ...grpc.CallOption) (*Response, error)
+	Keygroups(ctx context.Context, in *KeygroupsRequest, opts ...grpc.CallOption) (Database_KeygroupsClient, error)
 	AddKeygroupTrigger(ctx context.Context, in *KeygroupTrigger, opts ...grpc.CallOption) (*Response, error)
 	DeleteKeygroupTrigger(ctx context.Context, in *KeygroupTrigger, opts ...grpc.CallOption) (*Response, error)
 	GetKeygroupTrigger(ctx context.Context, in *Keygroup, opts ...grpc.CallOption) (Database_GetKeygroupTriggerClient, error)
+	BulkUpdate(ctx context.Context, opts ...grpc.CallOption) (Database_BulkUpdateClient, error)
+	Export(ctx context.Context, in *Keygroup, opts ...grpc.CallOption) (Database_ExportClient, error)
 }
 
 type databaseClient struct {
@@ -60,8 +63,8 @@ func (c *databaseClient) Delete(ctx context.Context, in *Key, opts ...grpc.CallO
 	return out, nil
 }
 
-func (c *databaseClient) Append(ctx context.Context, in *AppendItem, opts ...grpc.CallOption) (*Key, error) {
-	out := new(Key)
+func (c *databaseClient) Append(ctx context.Context, in *AppendItem, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
 	err := c.cc.Invoke(ctx, "/mcc.fred.storage.Database/Append", in, out, opts...)
 	if err != nil {
 		return nil, err
@@ -210,6 +213,38 @@ func (c *databaseClient) ExistsKeygroup(ctx context.Context, in *Keygroup, opts
 	return out, nil
 }
 
+func (c *databaseClient) Keygroups(ctx context.Context, in *KeygroupsRequest, opts ...grpc.CallOption) (Database_KeygroupsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Database_ServiceDesc.Streams[3], "/mcc.fred.storage.Database/Keygroups", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &databaseKeygroupsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Database_KeygroupsClient interface {
+	Recv() (*Keygroup, error)
+	grpc.ClientStream
+}
+
+type databaseKeygroupsClient struct {
+	grpc.ClientStream
+}
+
+func (x *databaseKeygroupsClient) Recv() (*Keygroup, error) {
+	m := new(Keygroup)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *databaseClient) AddKeygroupTrigger(ctx context.Context, in *KeygroupTrigger, opts ...grpc.CallOption) (*Response, error) {
 	out := new(Response)
 	err := c.cc.Invoke(ctx, "/mcc.fred.storage.Database/AddKeygroupTrigger", in, out, opts...)
@@ -229,7 +264,7 @@ func (c *databaseClient) DeleteKeygroupTrigger(ctx context.Context, in *Keygroup
 }
 
 func (c *databaseClient) GetKeygroupTrigger(ctx context.Context, in *Keygroup, opts ...grpc.CallOption) (Database_GetKeygroupTriggerClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Database_ServiceDesc.Streams[3], "/mcc.fred.storage.Database/GetKeygroupTrigger", opts...)
+	stream, err := c.cc.NewStream(ctx, &Database_ServiceDesc.Streams[4], "/mcc.fred.storage.Database/GetKeygroupTrigger", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -260,13 +295,79 @@ func (x *databaseGetKeygroupTriggerClient) Recv() (*Trigger, error) {
 	return m, nil
 }
 
+func (c *databaseClient) BulkUpdate(ctx context.Context, opts ...grpc.CallOption) (Database_BulkUpdateClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Database_ServiceDesc.Streams[5], "/mcc.fred.storage.Database/BulkUpdate", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &databaseBulkUpdateClient{stream}
+	return x, nil
+}
+
+type Database_BulkUpdateClient interface {
+	Send(*UpdateItem) error
+	CloseAndRecv() (*BulkUpdateResponse, error)
+	grpc.ClientStream
+}
+
+type databaseBulkUpdateClient struct {
+	grpc.ClientStream
+}
+
+func (x *databaseBulkUpdateClient) Send(m *UpdateItem) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *databaseBulkUpdateClient) CloseAndRecv() (*BulkUpdateResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(BulkUpdateResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *databaseClient) Export(ctx context.Context, in *Keygroup, opts ...grpc.CallOption) (Database_ExportClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Database_ServiceDesc.Streams[6], "/mcc.fred.storage.Database/Export", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &databaseExportClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Database_ExportClient interface {
+	Recv() (*ExportItem, error)
+	grpc.ClientStream
+}
+
+type databaseExportClient struct {
+	grpc.ClientStream
+}
+
+func (x *databaseExportClient) Recv() (*ExportItem, error) {
+	m := new(ExportItem)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // DatabaseServer is the server API for Database service.
 // All implementations should embed UnimplementedDatabaseServer
 // for forward compatibility
 type DatabaseServer interface {
 	Update(context.Context, *UpdateItem) (*Response, error)
 	Delete(context.Context, *Key) (*Response, error)
-	Append(context.Context, *AppendItem) (*Key, error)
+	Append(context.Context, *AppendItem) (*Response, error)
 	Read(context.Context, *Key) (*Val, error)
 	Scan(*ScanRequest, Database_ScanServer) error
 	ReadAll(*Keygroup, Database_ReadAllServer) error
@@ -275,9 +376,12 @@ type DatabaseServer interface {
 	CreateKeygroup(context.Context, *Keygroup) (*Response, error)
 	DeleteKeygroup(context.Context, *Keygroup) (*Response, error)
 	ExistsKeygroup(context.Context, *Keygroup) (*Response, error)
+	Keygroups(*KeygroupsRequest, Database_KeygroupsServer) error
 	AddKeygroupTrigger(context.Context, *KeygroupTrigger) (*Response, error)
 	DeleteKeygroupTrigger(context.Context, *KeygroupTrigger) (*Response, error)
 	GetKeygroupTrigger(*Keygroup, Database_GetKeygroupTriggerServer) error
+	BulkUpdate(Database_BulkUpdateServer) error
+	Export(*Keygroup, Database_ExportServer) error
 }
 
 // UnimplementedDatabaseServer should be embedded to have forward compatible implementations.
@@ -290,7 +394,7 @@ func (UnimplementedDatabaseServer) Update(context.Context, *UpdateItem) (*Respon
 func (UnimplementedDatabaseServer) Delete(context.Context, *Key) (*Response, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
 }
-func (UnimplementedDatabaseServer) Append(context.Context, *AppendItem) (*Key, error) {
+func (UnimplementedDatabaseServer) Append(context.Context, *AppendItem) (*Response, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Append not implemented")
 }
 func (UnimplementedDatabaseServer) Read(context.Context, *Key) (*Val, error) {
@@ -317,6 +421,9 @@ func (UnimplementedDatabaseServer) DeleteKeygroup(context.Context, *Keygroup) (*
 func (UnimplementedDatabaseServer) ExistsKeygroup(context.Context, *Keygroup) (*Response, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ExistsKeygroup not implemented")
 }
+func (UnimplementedDatabaseServer) Keygroups(*KeygroupsRequest, Database_KeygroupsServer) error {
+	return status.Errorf(codes.Unimplemented, "method Keygroups not implemented")
+}
 func (UnimplementedDatabaseServer) AddKeygroupTrigger(context.Context, *KeygroupTrigger) (*Response, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AddKeygroupTrigger not implemented")
 }
@@ -326,6 +433,12 @@ func (UnimplementedDatabaseServer) DeleteKeygroupTrigger(context.Context, *Keygr
 func (UnimplementedDatabaseServer) GetKeygroupTrigger(*Keygroup, Database_GetKeygroupTriggerServer) error {
 	return status.Errorf(codes.Unimplemented, "method GetKeygroupTrigger not implemented")
 }
+func (UnimplementedDatabaseServer) BulkUpdate(Database_BulkUpdateServer) error {
+	return status.Errorf(codes.Unimplemented, "method BulkUpdate not implemented")
+}
+func (UnimplementedDatabaseServer) Export(*Keygroup, Database_ExportServer) error {
+	return status.Errorf(codes.Unimplemented, "method Export not implemented")
+}
 
 // UnsafeDatabaseServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to DatabaseServer will
@@ -545,6 +658,27 @@ func _Database_ExistsKeygroup_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Database_Keygroups_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(KeygroupsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DatabaseServer).Keygroups(m, &databaseKeygroupsServer{stream})
+}
+
+type Database_KeygroupsServer interface {
+	Send(*Keygroup) error
+	grpc.ServerStream
+}
+
+type databaseKeygroupsServer struct {
+	grpc.ServerStream
+}
+
+func (x *databaseKeygroupsServer) Send(m *Keygroup) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _Database_AddKeygroupTrigger_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(KeygroupTrigger)
 	if err := dec(in); err != nil {
@@ -602,6 +736,53 @@ func (x *databaseGetKeygroupTriggerServer) Send(m *Trigger) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _Database_BulkUpdate_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(DatabaseServer).BulkUpdate(&databaseBulkUpdateServer{stream})
+}
+
+type Database_BulkUpdateServer interface {
+	SendAndClose(*BulkUpdateResponse) error
+	Recv() (*UpdateItem, error)
+	grpc.ServerStream
+}
+
+type databaseBulkUpdateServer struct {
+	grpc.ServerStream
+}
+
+func (x *databaseBulkUpdateServer) SendAndClose(m *BulkUpdateResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *databaseBulkUpdateServer) Recv() (*UpdateItem, error) {
+	m := new(UpdateItem)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Database_Export_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(Keygroup)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DatabaseServer).Export(m, &databaseExportServer{stream})
+}
+
+type Database_ExportServer interface {
+	Send(*ExportItem) error
+	grpc.ServerStream
+}
+
+type databaseExportServer struct {
+	grpc.ServerStream
+}
+
+func (x *databaseExportServer) Send(m *ExportItem) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // Database_ServiceDesc is the grpc.ServiceDesc for Database service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -666,11 +847,26 @@ var Database_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _Database_IDs_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "Keygroups",
+			Handler:       _Database_Keygroups_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "GetKeygroupTrigger",
 			Handler:       _Database_GetKeygroupTrigger_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "BulkUpdate",
+			Handler:       _Database_BulkUpdate_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "Export",
+			Handler:       _Database_Export_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "storage.proto",
 }
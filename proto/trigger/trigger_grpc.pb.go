@@ -20,6 +20,11 @@ const _ = grpc.SupportPackageIsVersion7
 type TriggerNodeClient interface {
 	PutItemTrigger(ctx context.Context, in *PutItemTriggerRequest, opts ...grpc.CallOption) (*TriggerResponse, error)
 	DeleteItemTrigger(ctx context.Context, in *DeleteItemTriggerRequest, opts ...grpc.CallOption) (*TriggerResponse, error)
+	// This is optional, trigger nodes that do not implement it are simply not informed about deleted keygroups
+	DeleteKeygroupTrigger(ctx context.Context, in *DeleteKeygroupTriggerRequest, opts ...grpc.CallOption) (*TriggerResponse, error)
+	// This is optional as well: if a trigger node implements it, FReD sends several events at once instead of calling the
+	// methods above for every event
+	BatchTrigger(ctx context.Context, in *BatchTriggerRequest, opts ...grpc.CallOption) (*TriggerResponse, error)
 }
 
 type triggerNodeClient struct {
@@ -48,12 +53,35 @@ func (c *triggerNodeClient) DeleteItemTrigger(ctx context.Context, in *DeleteIte
 	return out, nil
 }
 
+func (c *triggerNodeClient) DeleteKeygroupTrigger(ctx context.Context, in *DeleteKeygroupTriggerRequest, opts ...grpc.CallOption) (*TriggerResponse, error) {
+	out := new(TriggerResponse)
+	err := c.cc.Invoke(ctx, "/mcc.fred.trigger.TriggerNode/DeleteKeygroupTrigger", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *triggerNodeClient) BatchTrigger(ctx context.Context, in *BatchTriggerRequest, opts ...grpc.CallOption) (*TriggerResponse, error) {
+	out := new(TriggerResponse)
+	err := c.cc.Invoke(ctx, "/mcc.fred.trigger.TriggerNode/BatchTrigger", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TriggerNodeServer is the server API for TriggerNode service.
 // All implementations should embed UnimplementedTriggerNodeServer
 // for forward compatibility
 type TriggerNodeServer interface {
 	PutItemTrigger(context.Context, *PutItemTriggerRequest) (*TriggerResponse, error)
 	DeleteItemTrigger(context.Context, *DeleteItemTriggerRequest) (*TriggerResponse, error)
+	// This is optional, trigger nodes that do not implement it are simply not informed about deleted keygroups
+	DeleteKeygroupTrigger(context.Context, *DeleteKeygroupTriggerRequest) (*TriggerResponse, error)
+	// This is optional as well: if a trigger node implements it, FReD sends several events at once instead of calling the
+	// methods above for every event
+	BatchTrigger(context.Context, *BatchTriggerRequest) (*TriggerResponse, error)
 }
 
 // UnimplementedTriggerNodeServer should be embedded to have forward compatible implementations.
@@ -66,6 +94,12 @@ func (UnimplementedTriggerNodeServer) PutItemTrigger(context.Context, *PutItemTr
 func (UnimplementedTriggerNodeServer) DeleteItemTrigger(context.Context, *DeleteItemTriggerRequest) (*TriggerResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteItemTrigger not implemented")
 }
+func (UnimplementedTriggerNodeServer) DeleteKeygroupTrigger(context.Context, *DeleteKeygroupTriggerRequest) (*TriggerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteKeygroupTrigger not implemented")
+}
+func (UnimplementedTriggerNodeServer) BatchTrigger(context.Context, *BatchTriggerRequest) (*TriggerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchTrigger not implemented")
+}
 
 // UnsafeTriggerNodeServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to TriggerNodeServer will
@@ -114,6 +148,42 @@ func _TriggerNode_DeleteItemTrigger_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TriggerNode_DeleteKeygroupTrigger_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteKeygroupTriggerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TriggerNodeServer).DeleteKeygroupTrigger(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mcc.fred.trigger.TriggerNode/DeleteKeygroupTrigger",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TriggerNodeServer).DeleteKeygroupTrigger(ctx, req.(*DeleteKeygroupTriggerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TriggerNode_BatchTrigger_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchTriggerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TriggerNodeServer).BatchTrigger(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mcc.fred.trigger.TriggerNode/BatchTrigger",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TriggerNodeServer).BatchTrigger(ctx, req.(*BatchTriggerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // TriggerNode_ServiceDesc is the grpc.ServiceDesc for TriggerNode service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -129,6 +199,14 @@ var TriggerNode_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteItemTrigger",
 			Handler:    _TriggerNode_DeleteItemTrigger_Handler,
 		},
+		{
+			MethodName: "DeleteKeygroupTrigger",
+			Handler:    _TriggerNode_DeleteKeygroupTrigger_Handler,
+		},
+		{
+			MethodName: "BatchTrigger",
+			Handler:    _TriggerNode_BatchTrigger_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "trigger.proto",
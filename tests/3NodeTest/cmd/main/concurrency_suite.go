@@ -3,7 +3,6 @@ package main
 import (
 	"fmt"
 	"math/rand"
-	"strconv"
 
 	"git.tu-berlin.de/mcc-fred/fred/tests/3NodeTest/pkg/grpcclient"
 )
@@ -139,36 +138,21 @@ func concurrentUpdatesImmutable(nodes []*grpcclient.Node, concurrent int, update
 	}
 
 	// let's check if everything worked
-	// in this case we expect the keys to be in the range [0,updates[ and no same key to be in different maps
+	// in this case we expect every key to be given out once and to have the value that was appended under it
 
-	for k := 0; k < updates; k++ {
-		// got to convert to string first
-		key := strconv.Itoa(k)
-		v := nodes[0].GetItem(keygroup, key, false)
-		found := 0
-
-		for i := 0; i < concurrent; i++ {
-			val, ok := expected[i][key]
-
-			if !ok {
-				continue
-			}
-
-			found++
+	for i := 0; i < concurrent; i++ {
+		for key, val := range expected[i] {
+			v := nodes[0].GetItem(keygroup, key, false)
 
 			if val != v {
 				logNodeFailure(nodes[0], fmt.Sprintf("value for %s", key), fmt.Sprintf("got wrong value %s", v))
 			}
-		}
-
-		if found == 0 {
-			logNodeFailure(nodes[0], fmt.Sprintf("expected value for %s", key), "got no return value")
-			continue
-		}
 
-		if found > 1 {
-			logNodeFailure(nodes[0], fmt.Sprintf("only one client can write to key %s", key), fmt.Sprintf("%d clients were able to write to same id for %s", found, key))
-			continue
+			for j := i + 1; j < concurrent; j++ {
+				if _, ok := expected[j][key]; ok {
+					logNodeFailure(nodes[0], fmt.Sprintf("only one client can write to key %s", key), fmt.Sprintf("key %s was given out more than once", key))
+				}
+			}
 		}
 	}
 }
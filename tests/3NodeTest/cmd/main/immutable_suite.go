@@ -1,5 +1,7 @@
 package main
 
+import "strings"
+
 type ImmutableSuite struct {
 	c *Config
 }
@@ -16,8 +18,8 @@ func (t *ImmutableSuite) RunTests() {
 	logNodeAction(t.c.nodeB, "Creating an item in this keygroup")
 	res := t.c.nodeB.AppendItem("log", "value1", false)
 
-	if res != "0" {
-		logNodeFailure(t.c.nodeB, "0", res)
+	if !strings.HasSuffix(res, "-"+t.c.nodeB.ID) {
+		logNodeFailure(t.c.nodeB, "ID of nodeB", res)
 	}
 
 	logNodeAction(t.c.nodeB, "Updating an item in this keygroup")
@@ -31,19 +33,19 @@ func (t *ImmutableSuite) RunTests() {
 	}
 
 	logNodeAction(t.c.nodeB, "Deleting an item in immutable keygroup")
-	t.c.nodeB.DeleteItem("log", "0", true)
+	t.c.nodeB.DeleteItem("log", res, true)
 
 	logNodeAction(t.c.nodeB, "Adding nodeC as replica to immutable keygroup")
 	t.c.nodeB.AddKeygroupReplica("log", t.c.nodeC.ID, 0, false)
 
 	logNodeAction(t.c.nodeC, "Updating immutable item on other nodeC")
-	t.c.nodeC.PutItem("log", "0", "value-3", true)
+	t.c.nodeC.PutItem("log", res, "value-3", true)
 
 	logNodeAction(t.c.nodeC, "Appending another item to readonly log.")
-	res = t.c.nodeC.AppendItem("log", "value-4", false)
+	next := t.c.nodeC.AppendItem("log", "value-4", false)
 
-	if res != "1" {
-		logNodeFailure(t.c.nodeC, "1", res)
+	if !strings.HasSuffix(next, "-"+t.c.nodeC.ID) || next <= res {
+		logNodeFailure(t.c.nodeC, "ID of nodeC after "+res, next)
 	}
 }
 